@@ -4,11 +4,15 @@
 
 package m3ua
 
-import "github.com/wmnsk/go-m3ua/messages"
+import (
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
 
 func (c *Conn) initiateASPSM() error {
 	if _, err := c.WriteSignal(
-		messages.NewAspUp(c.cfg.AspIdentifier, nil),
+		messages.NewAspUp(c.config().AspIdentifier, nil),
 	); err != nil {
 		return err
 	}
@@ -23,10 +27,26 @@ func (c *Conn) handleAspUp(aspUp *messages.AspUp) error {
 	if c.StreamID() != 0 {
 		return NewInvalidSCTPStreamIDError(c.StreamID())
 	}
+	if err := c.validateAspIdentifier(aspUp, aspUp.AspIdentifier); err != nil {
+		return err
+	}
+	if c.draining.Load() {
+		return ErrManagementBlocking
+	}
+	if err := c.applyConfigForASP(aspUp.AspIdentifier); err != nil {
+		return err
+	}
+	if err := c.checkAspUpAllowed(aspUp.AspIdentifier); err != nil {
+		return err
+	}
+	c.setPeerASP(aspUp.AspIdentifier)
+	if id, err := aspUp.AspIdentifier.DecodeAspIdentifier(); err == nil {
+		c.addLogAttrs("peer_asp_id", id)
+	}
 
 	if _, err := c.WriteSignal(
 		messages.NewAspUpAck(
-			c.cfg.AspIdentifier,
+			c.config().AspIdentifier,
 			nil,
 		),
 	); err != nil {
@@ -43,6 +63,9 @@ func (c *Conn) handleAspUpAck(aspUpAck *messages.AspUpAck) error {
 	if c.StreamID() != 0 {
 		return NewInvalidSCTPStreamIDError(c.StreamID())
 	}
+	if err := c.validateAspIdentifier(aspUpAck, aspUpAck.AspIdentifier); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -58,8 +81,10 @@ func (c *Conn) handleAspDown(aspDown *messages.AspDown) error {
 		return NewInvalidSCTPStreamIDError(c.StreamID())
 	}
 
-	// XXX - Validate the params.
+	// Nothing to validate here; ASP Down contains only Info String,
+	// and any other parameter is rejected when parsing.
 
+	c.deactivateAS()
 	if _, err := c.WriteSignal(messages.NewAspDownAck(nil)); err != nil {
 		return err
 	}
@@ -78,3 +103,30 @@ func (c *Conn) handleAspDownAck(aspDownAck *messages.AspDownAck) error {
 
 	return nil
 }
+
+// applyConfigForASP replaces the Config of the Conn with the one returned by
+// Listener.ConfigForASP for the ASP Identifier, if it is set.
+//
+// The Config is resolved only with the first ASP Up in the association, and
+// the subsequent ones after ASP Down keep using it.
+func (c *Conn) applyConfigForASP(aspID *params.Param) error {
+	if c.configForASP == nil {
+		return nil
+	}
+	id, err := aspID.DecodeAspIdentifier()
+	if err != nil {
+		return ErrAspIDRequired
+	}
+	if c.cfgForASP.Swap(true) {
+		return nil
+	}
+
+	cfg := c.configForASP(id)
+	if cfg == nil {
+		return nil
+	}
+	c.cfg.Store(cfg.forConn())
+	c.initLogger(sctp.SCTPAssocID(c.info.AssocID))
+	c.initTap()
+	return nil
+}
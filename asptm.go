@@ -6,7 +6,6 @@ package m3ua
 
 import (
 	"context"
-	"crypto/rand"
 	"time"
 
 	"github.com/wmnsk/go-m3ua/messages"
@@ -15,7 +14,7 @@ import (
 
 func (c *Conn) initiateASPTM() error {
 	if _, err := c.WriteSignal(messages.NewAspActive(
-		c.cfg.TrafficModeType, c.cfg.RoutingContexts, nil,
+		c.config().TrafficModeType, c.config().RoutingContexts, nil,
 	)); err != nil {
 		return err
 	}
@@ -25,42 +24,46 @@ func (c *Conn) initiateASPTM() error {
 
 func (c *Conn) heartbeat(ctx context.Context) {
 	c.beatAllow.Wait()
-	if !c.cfg.HeartbeatInfo.Enabled {
+	info := c.config().HeartbeatInfo
+	if !info.Enabled {
 		return
 	}
-	data := make([]byte, 128)
-	beat := messages.NewHeartbeat(params.NewHeartbeatData(data))
 	for {
-		if _, err := rand.Read(data); err != nil {
+		data, err := c.hb.next(info)
+		if err != nil {
 			c.errChan <- err
 			return
 		}
-		beat.HeartbeatData = params.NewHeartbeatData(data)
-		if _, err := c.WriteSignal(beat); err != nil {
+		if _, err := c.WriteSignal(messages.NewHeartbeat(params.NewHeartbeatData(data))); err != nil {
+			c.log().Warn("failed to send BEAT", "error", err)
 			c.errChan <- ErrFailedToWriteSignal
 			return
 		}
-		c.cfg.HeartbeatInfo.Data = data
 
 		// wait for response
 		select {
 		case <-ctx.Done():
 			return
-		case _, ok := <-c.beatAckChan: // got valid BEAT response from peer
-			if !ok {
+		case <-c.closed:
+			return
+		case <-c.hb.ack: // got valid BEAT response from peer
+		case <-time.After(info.Timer): // timer expired
+			misses := c.hb.miss()
+			if misses >= info.maxMisses() {
+				c.log().Warn("BEAT ACK not received in time", "timer", info.Timer.String(), "misses", misses)
+				c.errChan <- ErrHeartbeatExpired
 				return
 			}
-			break
-		case <-time.After(c.cfg.HeartbeatInfo.Timer): // timer expired
-			c.errChan <- ErrHeartbeatExpired
-			return
+			c.log().Info("BEAT ACK not received in time, retrying", "timer", info.Timer.String(), "misses", misses)
 		}
 
 		// wait while next time
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(c.cfg.HeartbeatInfo.Interval):
+		case <-c.closed:
+			return
+		case <-time.After(info.Interval):
 			continue
 		}
 	}
@@ -71,8 +74,57 @@ func (c *Conn) handleAspActive(aspActive *messages.AspActive) error {
 		return NewUnexpectedMessageError(aspActive)
 	}
 
+	if c.draining.Load() {
+		return ErrManagementBlocking
+	}
+	if c.store != nil {
+		return c.handleAspActiveWithStore(aspActive)
+	}
+
+	if err := c.validateTrafficModeType(
+		aspActive, aspActive.TrafficModeType, c.config().TrafficModeType,
+	); err != nil {
+		return err
+	}
+	if err := c.validateRoutingContexts(
+		aspActive, aspActive.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
+
 	if _, err := c.WriteSignal(
-		messages.NewAspActiveAck(c.cfg.TrafficModeType, c.cfg.RoutingContexts, nil),
+		messages.NewAspActiveAck(c.config().TrafficModeType, c.config().RoutingContexts, nil),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleAspActiveWithStore activates the ASP for the ASes in Store instead of
+// the ones in Config, and notifies the ASP that the ASes are active.
+func (c *Conn) handleAspActiveWithStore(aspActive *messages.AspActive) error {
+	// the format is validated here, the values are checked against Store.
+	if err := c.validateTrafficModeType(aspActive, aspActive.TrafficModeType, nil); err != nil {
+		return err
+	}
+	if err := c.validateRoutingContexts(aspActive, aspActive.RoutingContext, nil); err != nil {
+		return err
+	}
+
+	rcs, err := c.activateAS(aspActive)
+	if err != nil {
+		return err
+	}
+
+	rcParam := params.NewRoutingContext(rcs...)
+	if _, err := c.WriteSignal(
+		messages.NewAspActiveAck(aspActive.TrafficModeType, rcParam, nil),
+	); err != nil {
+		return err
+	}
+	if _, err := c.WriteSignal(
+		messages.NewNotify(params.NewStatus(params.AsStateActive), nil, rcParam, nil),
 	); err != nil {
 		return err
 	}
@@ -85,7 +137,27 @@ func (c *Conn) handleAspActiveAck(aspAcAck *messages.AspActiveAck) error {
 		return NewUnexpectedMessageError(aspAcAck)
 	}
 
-	// XXX - Add some additional validation for aspAcAck here.
+	// the ack should reflect what is requested in initiateASPTM.
+	if err := c.validateNotRequested(
+		aspAcAck, aspAcAck.TrafficModeType, c.config().TrafficModeType,
+	); err != nil {
+		return err
+	}
+	if err := c.validateTrafficModeType(
+		aspAcAck, aspAcAck.TrafficModeType, c.config().TrafficModeType,
+	); err != nil {
+		return err
+	}
+	if err := c.validateNotRequested(
+		aspAcAck, aspAcAck.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
+	if err := c.validateRoutingContexts(
+		aspAcAck, aspAcAck.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -95,8 +167,15 @@ func (c *Conn) handleAspInactive(aspInactive *messages.AspInactive) error {
 		return NewUnexpectedMessageError(aspInactive)
 	}
 
+	if err := c.validateRoutingContexts(
+		aspInactive, aspInactive.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
+
+	c.deactivateAS()
 	if _, err := c.WriteSignal(
-		messages.NewAspInactiveAck(c.cfg.RoutingContexts, nil),
+		messages.NewAspInactiveAck(c.config().RoutingContexts, nil),
 	); err != nil {
 		return err
 	}
@@ -109,13 +188,26 @@ func (c *Conn) handleAspInactiveAck(aspAcAck *messages.AspInactiveAck) error {
 		return NewUnexpectedMessageError(aspAcAck)
 	}
 
-	// XXX - Add some additional validation for aspAcAck here.
+	if err := c.validateNotRequested(
+		aspAcAck, aspAcAck.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
+	if err := c.validateRoutingContexts(
+		aspAcAck, aspAcAck.RoutingContext, c.config().RoutingContexts,
+	); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// handleHeartbeat responds to the BEAT, which is allowed in ASP-Inactive
+// as well as in ASP-Active.
 func (c *Conn) handleHeartbeat(beat *messages.Heartbeat) error {
-	if c.State() != StateAspActive {
+	switch c.State() {
+	case StateAspInactive, StateAspActive:
+	default:
 		return NewUnexpectedMessageError(beat)
 	}
 
@@ -127,21 +219,30 @@ func (c *Conn) handleHeartbeat(beat *messages.Heartbeat) error {
 	return nil
 }
 
+// handleHeartbeatAck checks the BEAT ACK against the BEAT sent. The late
+// ack for the BEAT that is already given up is ignored.
 func (c *Conn) handleHeartbeatAck(beatAck *messages.HeartbeatAck) error {
-	if c.State() != StateAspActive {
+	switch c.State() {
+	case StateAspInactive, StateAspActive:
+	default:
 		return NewUnexpectedMessageError(beatAck)
 	}
 
-	myData := c.cfg.HeartbeatInfo.Data
-	dataFromPeer := beatAck.HeartbeatData.HeartbeatData()
-	if len(dataFromPeer) != len(myData) {
+	data, err := beatAck.HeartbeatData.DecodeHeartbeatData()
+	if err != nil {
 		return NewUnexpectedMessageError(beatAck)
 	}
-	for i, p := range dataFromPeer {
-		if p != myData[i] {
-			return NewUnexpectedMessageError(beatAck)
-		}
+
+	matched, rtt, valid := c.hb.acked(data)
+	if !valid {
+		return NewUnexpectedMessageError(beatAck)
+	}
+	if !matched {
+		c.log().Debug("ignored late BEAT ACK")
+		return nil
 	}
 
+	c.log().Debug("BEAT ACK received", "rtt", rtt.String())
+	c.observeHeartbeatRTT(rtt)
 	return nil
 }
@@ -0,0 +1,99 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+// defaultBufferSize is the size of the buffers allocated in bufferPool.
+const defaultBufferSize = 1500
+
+// bufferPool is the pool of the buffers used to read and write M3UA messages,
+// to avoid allocating a buffer for each message.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, defaultBufferSize)
+		return &b
+	},
+}
+
+// getBuffer returns a buffer of length n from bufferPool.
+// The buffer should be returned with putBuffer after use.
+func getBuffer(n int) *[]byte {
+	bp := bufferPool.Get().(*[]byte)
+	if cap(*bp) < n {
+		*bp = make([]byte, n)
+	}
+	*bp = (*bp)[:n]
+	return bp
+}
+
+// putBuffer returns the buffer to bufferPool.
+func putBuffer(bp *[]byte) {
+	bufferPool.Put(bp)
+}
+
+// dataPool is the pool of Data to decode the received Data messages into,
+// as they are the most frequent ones in the active state.
+var dataPool = sync.Pool{
+	New: func() any {
+		return &messages.Data{}
+	},
+}
+
+// readMessage reads a M3UA message into b with read, and returns the length
+// of the message.
+//
+// The end of the message is the end of the SCTP message (MSG_EOR), and a
+// message delivered partially is reassembled by reading until then. If the
+// message is larger than b, the rest of it is discarded and
+// MessageTooLargeError is returned. If the Message Length in the common header
+// does not match the length of the SCTP message, ErrLengthMismatch is returned
+// with the length of the message read.
+func readMessage(read func([]byte) (int, bool, error), b []byte) (int, error) {
+	n := 0
+	for {
+		nn, eor, err := read(b[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nn
+		if eor {
+			break
+		}
+
+		if n == len(b) {
+			var l uint32
+			if n >= 8 {
+				l = binary.BigEndian.Uint32(b[4:8])
+			}
+			head := bytes.Clone(b[:min(n, 40)])
+
+			// discard the rest of the message.
+			for !eor {
+				if _, eor, err = read(b); err != nil {
+					return 0, err
+				}
+			}
+			return 0, NewMessageTooLargeError(l, len(b), head)
+		}
+	}
+
+	// the message is left to the parser to be rejected if the length
+	// can't be determined.
+	if n < 8 {
+		return n, nil
+	}
+	if l := binary.BigEndian.Uint32(b[4:8]); int(l) != n {
+		return n, fmt.Errorf("%w: %d in header, %d received", ErrLengthMismatch, l, n)
+	}
+	return n, nil
+}
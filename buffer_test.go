@@ -0,0 +1,126 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// sctpReader mimics the SCTP reads of the messages, each of which returns at
+// most max bytes and leaves the rest of the message for the next read like a
+// partial delivery. The end of the message is reported like MSG_EOR.
+type sctpReader struct {
+	msgs [][]byte
+	max  int
+}
+
+func (r *sctpReader) read(b []byte) (int, bool, error) {
+	if len(r.msgs) == 0 {
+		return 0, false, io.EOF
+	}
+
+	if r.max > 0 && len(b) > r.max {
+		b = b[:r.max]
+	}
+	n := copy(b, r.msgs[0])
+	if n < len(r.msgs[0]) {
+		r.msgs[0] = r.msgs[0][n:]
+		return n, false, nil
+	}
+	r.msgs = r.msgs[1:]
+	return n, true, nil
+}
+
+func TestReadMessage(t *testing.T) {
+	large, err := messages.NewData(
+		nil, params.NewRoutingContext(1),
+		params.NewProtocolData(1, 2, 3, 1, 0, 1, bytes.Repeat([]byte{0xff}, 3000)),
+		nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	small, err := messages.NewAspUp(nil, nil).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("reassemble", func(t *testing.T) {
+		r := &sctpReader{msgs: [][]byte{large, small}, max: 1000}
+		buf := make([]byte, 4096)
+
+		n, err := readMessage(r.read, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf[:n], large) {
+			t.Errorf("got %d bytes, want %d bytes", n, len(large))
+		}
+
+		n, err = readMessage(r.read, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf[:n], small) {
+			t.Errorf("got %x, want %x", buf[:n], small)
+		}
+	})
+
+	t.Run("too-large", func(t *testing.T) {
+		r := &sctpReader{msgs: [][]byte{large, small}}
+		buf := make([]byte, 1500)
+
+		_, err := readMessage(r.read, buf)
+		var tooLarge *MessageTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("got %v, want %T", err, tooLarge)
+		}
+		if int(tooLarge.Length) != len(large) || !bytes.Equal(tooLarge.Head, large[:40]) {
+			t.Errorf("unexpected error: %v, %x", tooLarge, tooLarge.Head)
+		}
+
+		// the rest of the large message should have been discarded.
+		n, err := readMessage(r.read, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf[:n], small) {
+			t.Errorf("got %x, want %x", buf[:n], small)
+		}
+	})
+
+	t.Run("length-mismatch", func(t *testing.T) {
+		// the length in the header is larger or smaller than the message.
+		longer, shorter := bytes.Clone(small), append(bytes.Clone(small), 0, 0, 0, 0)
+		longer[7] = 0x20
+		r := &sctpReader{msgs: [][]byte{longer, shorter, small}}
+		buf := make([]byte, 1500)
+
+		for _, want := range [][]byte{longer, shorter} {
+			n, err := readMessage(r.read, buf)
+			if !errors.Is(err, ErrLengthMismatch) {
+				t.Fatalf("got %v, want %v", err, ErrLengthMismatch)
+			}
+			if !bytes.Equal(buf[:n], want) {
+				t.Errorf("got %x, want %x", buf[:n], want)
+			}
+		}
+
+		// the next message is not affected.
+		n, err := readMessage(r.read, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf[:n], small) {
+			t.Errorf("got %x, want %x", buf[:n], small)
+		}
+	})
+}
@@ -22,14 +22,10 @@ func Dial(ctx context.Context, net string, laddr, raddr *sctp.SCTPAddr, cfg *Con
 		muState:     new(sync.RWMutex),
 		mode:        modeClient,
 		stateChan:   make(chan State),
-		established: make(chan struct{}),
+		established: make(chan struct{}, 1),
 		sctpInfo:    &sctp.SndRcvInfo{PPID: 3, Stream: 0},
-		cfg:         cfg,
-	}
-
-	if conn.cfg.HeartbeatInfo.Interval == 0 {
-		conn.cfg.HeartbeatInfo.Enabled = false
 	}
+	conn.cfg.Store(cfg.forConn())
 
 	n, ok := netMap[net]
 	if !ok {
@@ -46,6 +42,7 @@ func Dial(ctx context.Context, net string, laddr, raddr *sctp.SCTPAddr, cfg *Con
 		return nil, fmt.Errorf("failed to get sctpConn status: %w", err)
 	}
 	conn.maxMessageStreamID = r.Ostreams - 1 // removing 1 for management messages of stream ID 0
+	conn.init(r.AssocID)
 
 	go func() {
 		conn.stateChan <- StateAspDown
@@ -59,7 +56,7 @@ func Dial(ctx context.Context, net string, laddr, raddr *sctp.SCTPAddr, cfg *Con
 			return nil, ErrFailedToEstablish
 		}
 		return conn, nil
-	case <-time.After(10 * time.Second):
+	case <-time.After(conn.config().establishTimeout()):
 		conn.sctpConn.Close()
 		return nil, ErrTimeout
 	}
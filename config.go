@@ -5,17 +5,30 @@
 package m3ua
 
 import (
+	"bytes"
+	"log/slog"
 	"time"
 
 	"github.com/wmnsk/go-m3ua/messages/params"
 )
 
 // HeartbeatInfo is a set of information for M3UA BEAT.
+//
+// BEATs are sent in ASP-Inactive and ASP-Active states, and the state of them,
+// such as the data to be acked, is kept per Conn.
 type HeartbeatInfo struct {
 	Enabled  bool
 	Interval time.Duration
 	Timer    time.Duration
-	Data     []byte
+	// Data is the data appended to the sequence number and timestamp in BEAT
+	// when Stamp is enabled. Otherwise, random bytes are sent in each BEAT.
+	Data []byte
+	// MaxMisses is the number of the consecutive BEATs without BEAT ACK in
+	// Timer to consider the peer is not alive. If zero, the first miss is.
+	MaxMisses int
+	// Stamp enables embedding the sequence number and the timestamp in BEAT,
+	// which makes the BEATs missed distinguishable in their late BEAT ACKs.
+	Stamp bool
 }
 
 // NewHeartbeatInfo creates a new HeartbeatInfo.
@@ -25,7 +38,28 @@ func NewHeartbeatInfo(interval, timer time.Duration, data []byte) *HeartbeatInfo
 	}
 }
 
+// Clone returns a deep copy of the HeartbeatInfo, or nil if h is nil.
+func (h *HeartbeatInfo) Clone() *HeartbeatInfo {
+	if h == nil {
+		return nil
+	}
+	hb := *h
+	hb.Data = bytes.Clone(h.Data)
+	return &hb
+}
+
+func (h *HeartbeatInfo) maxMisses() int {
+	if h.MaxMisses <= 0 {
+		return 1
+	}
+	return h.MaxMisses
+}
+
 // Config is a configuration that defines a M3UA server.
+//
+// Config is treated as a template; Dial and Listener.Accept take a copy of
+// it with Clone for each Conn, so that it can be shared by multiple Conns and
+// modified for the subsequent ones without affecting the established ones.
 type Config struct {
 	*HeartbeatInfo
 	AspIdentifier          *params.Param
@@ -39,6 +73,89 @@ type Config struct {
 	NetworkIndicator       uint8
 	MessagePriority        uint8
 	SignalingLinkSelection uint8
+	// StrictValidation enables the validation of the received ASPSM/ASPTM
+	// messages against the configuration, such as Traffic Mode Type and
+	// Routing Contexts. Otherwise, only the format and values that are
+	// invalid in any configuration are checked.
+	StrictValidation bool
+	// MaxMessageSize is the maximum size of the M3UA messages to be received,
+	// which is also the size of the receive buffer. The messages larger than
+	// this are discarded and responded with ERROR. If zero, DefaultMaxMessageSize
+	// is used.
+	MaxMessageSize int
+	// Tap is called with every M3UA message sent or received on the Conn,
+	// if set. See Tap for details.
+	Tap Tap
+	// Logger is the logger used by the Conn, with the attributes to identify
+	// the association, such as the addresses and ASP Identifier. If nil, the
+	// logs are printed with the package logger set by SetLogger.
+	Logger *slog.Logger
+	// Metrics collects the metrics of the Conn, if set. See Metrics for details.
+	Metrics Metrics
+	// EstablishTimeout is the time to wait for the Conn to be ASP-Active in
+	// Dial and Accept. If zero, DefaultEstablishTimeout is used.
+	EstablishTimeout time.Duration
+	// RoutingKeys is the Routing Keys configured for the peer, which are not
+	// used by Conn but kept for the application to route the DATA with, e.g.,
+	// RoutingKeyPayload.Matches.
+	RoutingKeys []*params.RoutingKeyPayload
+}
+
+// DefaultMaxMessageSize is the maximum size of the M3UA messages to be received
+// used when Config.MaxMessageSize is not set.
+const DefaultMaxMessageSize = 65535
+
+func (c *Config) maxMessageSize() int {
+	if c.MaxMessageSize <= 0 {
+		return DefaultMaxMessageSize
+	}
+	return c.MaxMessageSize
+}
+
+// DefaultEstablishTimeout is the time to wait for the Conn to be established
+// used when Config.EstablishTimeout is not set.
+const DefaultEstablishTimeout = 10 * time.Second
+
+func (c *Config) establishTimeout() time.Duration {
+	if c.EstablishTimeout <= 0 {
+		return DefaultEstablishTimeout
+	}
+	return c.EstablishTimeout
+}
+
+// Clone returns a deep copy of the Config. The Logger, Tap and Metrics are
+// shared with the copy, as they are meant to be used by multiple Conns.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	cfg := *c
+	cfg.HeartbeatInfo = c.HeartbeatInfo.Clone()
+	cfg.AspIdentifier = c.AspIdentifier.Clone()
+	cfg.TrafficModeType = c.TrafficModeType.Clone()
+	cfg.NetworkAppearance = c.NetworkAppearance.Clone()
+	cfg.RoutingContexts = c.RoutingContexts.Clone()
+	cfg.CorrelationID = c.CorrelationID.Clone()
+	if c.RoutingKeys != nil {
+		cfg.RoutingKeys = make([]*params.RoutingKeyPayload, len(c.RoutingKeys))
+		for i, rk := range c.RoutingKeys {
+			cfg.RoutingKeys[i] = rk.Clone()
+		}
+	}
+	return &cfg
+}
+
+// forConn returns the copy of the Config to be used by a Conn, with the
+// HeartbeatInfo that is never nil.
+func (c *Config) forConn() *Config {
+	cfg := c.Clone()
+	if cfg.HeartbeatInfo == nil {
+		cfg.HeartbeatInfo = &HeartbeatInfo{}
+	}
+	if cfg.HeartbeatInfo.Interval == 0 {
+		cfg.HeartbeatInfo.Enabled = false
+	}
+	return cfg
 }
 
 // NewConfig creates a new Config.
@@ -59,16 +176,41 @@ func NewConfig(opc, dpc uint32, si, ni, mp, sls uint8) *Config {
 }
 
 // EnableHeartbeat enables M3UA BEAT with interval and expiration timer
-// given.
+// given. MaxMisses and Stamp set before are kept.
 //
-// The data is hard-coded by default. Manipulate the exported field
-// Config.HeartbeatInfo.Data to customize it such as including current
-// time to identify the BEAT and BEAT ACK pair.
+// The data is hard-coded by default, which is sent only when Stamp is
+// enabled with SetHeartbeatStamp. Manipulate the exported field
+// Config.HeartbeatInfo.Data to customize it.
 func (c *Config) EnableHeartbeat(interval, timer time.Duration) *Config {
-	c.HeartbeatInfo = NewHeartbeatInfo(
+	hb := NewHeartbeatInfo(
 		interval, timer,
 		[]byte("Hi, this is a BEAT from go-m3ua. Are you alive?"),
 	)
+	// keep the policy set before.
+	if c.HeartbeatInfo != nil {
+		hb.MaxMisses, hb.Stamp = c.HeartbeatInfo.MaxMisses, c.HeartbeatInfo.Stamp
+	}
+	c.HeartbeatInfo = hb
+	return c
+}
+
+// SetHeartbeatMaxMisses sets the number of the consecutive BEATs without
+// BEAT ACK to consider the peer is not alive in Config.
+func (c *Config) SetHeartbeatMaxMisses(n int) *Config {
+	if c.HeartbeatInfo == nil {
+		c.HeartbeatInfo = &HeartbeatInfo{}
+	}
+	c.HeartbeatInfo.MaxMisses = n
+	return c
+}
+
+// SetHeartbeatStamp enables or disables embedding the sequence number and
+// the timestamp in BEAT in Config.
+func (c *Config) SetHeartbeatStamp(stamp bool) *Config {
+	if c.HeartbeatInfo == nil {
+		c.HeartbeatInfo = &HeartbeatInfo{}
+	}
+	c.HeartbeatInfo.Stamp = stamp
 	return c
 }
 
@@ -102,6 +244,51 @@ func (c *Config) SetCorrelationID(id uint32) *Config {
 	return c
 }
 
+// SetStrictValidation enables or disables the strict validation of the
+// received messages in Config.
+func (c *Config) SetStrictValidation(strict bool) *Config {
+	c.StrictValidation = strict
+	return c
+}
+
+// SetMaxMessageSize sets the maximum size of the M3UA messages to be received
+// in Config.
+func (c *Config) SetMaxMessageSize(size int) *Config {
+	c.MaxMessageSize = size
+	return c
+}
+
+// SetTap sets the Tap that is called with every M3UA message in Config.
+func (c *Config) SetTap(tap Tap) *Config {
+	c.Tap = tap
+	return c
+}
+
+// SetLogger sets the Logger used by the Conn in Config.
+func (c *Config) SetLogger(l *slog.Logger) *Config {
+	c.Logger = l
+	return c
+}
+
+// SetMetrics sets the Metrics that collects the metrics of the Conn in Config.
+func (c *Config) SetMetrics(m Metrics) *Config {
+	c.Metrics = m
+	return c
+}
+
+// SetEstablishTimeout sets the time to wait for the Conn to be established
+// in Config.
+func (c *Config) SetEstablishTimeout(timeout time.Duration) *Config {
+	c.EstablishTimeout = timeout
+	return c
+}
+
+// SetRoutingKeys sets the Routing Keys configured for the peer in Config.
+func (c *Config) SetRoutingKeys(rks ...*params.RoutingKeyPayload) *Config {
+	c.RoutingKeys = rks
+	return c
+}
+
 // NewClientConfig creates a new Config for Client.
 //
 // The optional parameters that is not required (like CorrelationID)
@@ -0,0 +1,147 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestConfigClone(t *testing.T) {
+	cfg := NewClientConfig(
+		NewHeartbeatInfo(time.Second, time.Second, []byte{1, 2, 3}),
+		1, 2, 3, 1, 0, 4, []uint32{10, 20}, 3, 2, 0, 1,
+	)
+	cloned := cfg.Clone()
+	if diff := cmp.Diff(cfg, cloned); diff != "" {
+		t.Fatal(diff)
+	}
+
+	cloned.HeartbeatInfo.Enabled = false
+	cloned.HeartbeatInfo.Data[0] = 0xff
+	cloned.RoutingContexts.Data[3] = 0xff
+	if !cfg.HeartbeatInfo.Enabled || cfg.HeartbeatInfo.Data[0] != 1 {
+		t.Errorf("HeartbeatInfo is shared: %+v", cfg.HeartbeatInfo)
+	}
+	if rcs, _ := cfg.RoutingContexts.DecodeRoutingContexts(); rcs[0] != 10 {
+		t.Errorf("RoutingContexts is shared: %v", rcs)
+	}
+
+	if (*Config)(nil).Clone() != nil {
+		t.Error("Clone of nil Config should be nil")
+	}
+}
+
+func TestConfigForConn(t *testing.T) {
+	// nil HeartbeatInfo is allowed and disables heartbeat.
+	cfg := NewConfig(1, 2, 3, 0, 0, 0).forConn()
+	if cfg.HeartbeatInfo == nil || cfg.HeartbeatInfo.Enabled {
+		t.Errorf("unexpected HeartbeatInfo: %+v", cfg.HeartbeatInfo)
+	}
+
+	// zero Interval disables heartbeat only in the copy.
+	tmpl := NewConfig(1, 2, 3, 0, 0, 0)
+	tmpl.HeartbeatInfo = &HeartbeatInfo{Enabled: true}
+	if cfg := tmpl.forConn(); cfg.HeartbeatInfo.Enabled || !tmpl.HeartbeatInfo.Enabled {
+		t.Errorf("got %v in copy and %v in template", cfg.HeartbeatInfo.Enabled, tmpl.HeartbeatInfo.Enabled)
+	}
+}
+
+func TestApplyConfigForASP(t *testing.T) {
+	perASP := NewConfig(1, 2, 3, 0, 0, 0).SetRoutingContexts(100)
+	newConn := func() *Conn {
+		return withConfig(&Conn{
+			configForASP: func(aspID uint32) *Config {
+				if aspID == 1 {
+					return perASP
+				}
+				return nil
+			},
+		}, NewConfig(1, 2, 3, 0, 0, 0).forConn())
+	}
+
+	c := newConn()
+	if err := c.applyConfigForASP(nil); !errors.Is(err, ErrAspIDRequired) {
+		t.Errorf("got %v, want %v", err, ErrAspIDRequired)
+	}
+	if err := c.applyConfigForASP(params.NewAspIdentifier(2)); err != nil || c.config().RoutingContexts != nil {
+		t.Errorf("got %v, %v", err, c.config().RoutingContexts)
+	}
+
+	c = newConn()
+	if err := c.applyConfigForASP(params.NewAspIdentifier(1)); err != nil {
+		t.Fatal(err)
+	}
+	if rcs, _ := c.config().RoutingContexts.DecodeRoutingContexts(); len(rcs) != 1 || rcs[0] != 100 || c.config() == perASP {
+		t.Errorf("Config for ASP is not applied as a copy: %v", rcs)
+	}
+
+	// the Config is not replaced again in the same association.
+	cfg := c.config()
+	if err := c.applyConfigForASP(params.NewAspIdentifier(2)); err != nil || c.config() != cfg {
+		t.Errorf("Config is replaced by the repeated ASP Up: %v", err)
+	}
+}
+
+func TestRepeatedAspUp(t *testing.T) {
+	var resolved atomic.Int32
+	c := withConfig(&Conn{
+		muState:  new(sync.RWMutex),
+		sctpInfo: &sctp.SndRcvInfo{},
+		sctpConn: sctp.NewSCTPConn(-1, nil),
+		configForASP: func(aspID uint32) *Config {
+			resolved.Add(1)
+			return NewConfig(1, 2, 3, 0, 0, 0).SetRoutingContexts(aspID)
+		},
+	}, NewConfig(1, 2, 3, 0, 0, 0).forConn())
+
+	// the Config is read concurrently by the other goroutines such as
+	// heartbeat and the user writing to the Conn.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			c.log()
+			c.countMessage(DirectionSent, messages.NewAspUp(nil, nil))
+			c.tap(DirectionSent, 0, nil, nil, nil)
+			_ = c.config().RoutingContexts
+		}
+	}()
+
+	for _, id := range []uint32{1, 2} {
+		// writing ASP Up Ack fails without SCTP, after the Config is applied.
+		_ = c.handleAspUp(messages.NewAspUp(params.NewAspIdentifier(id), nil))
+	}
+	close(done)
+	wg.Wait()
+
+	if got := resolved.Load(); got != 1 {
+		t.Errorf("Config for ASP is resolved %d times, want 1", got)
+	}
+	if rcs, _ := c.config().RoutingContexts.DecodeRoutingContexts(); len(rcs) != 1 || rcs[0] != 1 {
+		t.Errorf("got Routing Contexts %v, want [1]", rcs)
+	}
+}
+
+// withConfig sets the Config to the Conn for testing.
+func withConfig(c *Conn, cfg *Config) *Conn {
+	c.cfg.Store(cfg)
+	return c
+}
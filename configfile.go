@@ -0,0 +1,465 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages/params"
+	"github.com/wmnsk/go-m3ua/pc"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedConfigFormat is returned by LoadConfigFile if the file is
+// not in the supported format.
+var ErrUnsupportedConfigFormat = errors.New("unsupported config file format")
+
+// ConfigError is the error in a field of FileConfig. Validate returns all
+// the ConfigErrors found, joined with errors.Join.
+type ConfigError struct {
+	// Field is the name of the field in the file, e.g., "heartbeat.interval".
+	Field string
+	Err   error
+}
+
+// Error returns error string with the field name.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Duration is time.Duration in the form accepted by time.ParseDuration in the
+// config files, such as "1s" and "500ms".
+type Duration time.Duration
+
+// MarshalText returns the Duration in string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText parses the Duration in string.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// FileConfig is the declarative configuration of a M3UA endpoint, which can
+// be loaded from a file with LoadConfigFile.
+//
+// The field names are the same in JSON, YAML and TOML.
+//
+// The point codes are in the format given by PointCodeFormat, which is one of
+// the pc.Variant like "3-8-3", or in decimal if it is empty.
+type FileConfig struct {
+	// Network is one of "m3ua", "m3ua4" and "m3ua6". Default is "m3ua".
+	Network string `json:"network,omitempty" yaml:"network,omitempty" toml:"network,omitempty"`
+	// LocalAddrs and RemoteAddrs are the IP addresses of the endpoints, which
+	// can be multiple for multi-homing.
+	LocalAddrs  []string `json:"local_addrs,omitempty" yaml:"local_addrs,omitempty" toml:"local_addrs,omitempty"`
+	LocalPort   int      `json:"local_port,omitempty" yaml:"local_port,omitempty" toml:"local_port,omitempty"`
+	RemoteAddrs []string `json:"remote_addrs,omitempty" yaml:"remote_addrs,omitempty" toml:"remote_addrs,omitempty"`
+	RemotePort  int      `json:"remote_port,omitempty" yaml:"remote_port,omitempty" toml:"remote_port,omitempty"`
+
+	AspIdentifier     *uint32  `json:"asp_id,omitempty" yaml:"asp_id,omitempty" toml:"asp_id,omitempty"`
+	RoutingContexts   []uint32 `json:"routing_contexts,omitempty" yaml:"routing_contexts,omitempty" toml:"routing_contexts,omitempty"`
+	TrafficModeType   string   `json:"traffic_mode,omitempty" yaml:"traffic_mode,omitempty" toml:"traffic_mode,omitempty"`
+	NetworkAppearance *uint32  `json:"network_appearance,omitempty" yaml:"network_appearance,omitempty" toml:"network_appearance,omitempty"`
+	CorrelationID     *uint32  `json:"correlation_id,omitempty" yaml:"correlation_id,omitempty" toml:"correlation_id,omitempty"`
+
+	PointCodeFormat        string `json:"point_code_format,omitempty" yaml:"point_code_format,omitempty" toml:"point_code_format,omitempty"`
+	OriginatingPointCode   string `json:"opc" yaml:"opc" toml:"opc"`
+	DestinationPointCode   string `json:"dpc" yaml:"dpc" toml:"dpc"`
+	ServiceIndicator       uint8  `json:"si" yaml:"si" toml:"si"`
+	NetworkIndicator       uint8  `json:"ni" yaml:"ni" toml:"ni"`
+	MessagePriority        uint8  `json:"mp" yaml:"mp" toml:"mp"`
+	SignalingLinkSelection uint8  `json:"sls" yaml:"sls" toml:"sls"`
+
+	Heartbeat        *FileHeartbeatConfig `json:"heartbeat,omitempty" yaml:"heartbeat,omitempty" toml:"heartbeat,omitempty"`
+	EstablishTimeout Duration             `json:"establish_timeout,omitempty" yaml:"establish_timeout,omitempty" toml:"establish_timeout,omitempty"`
+	StrictValidation bool                 `json:"strict_validation,omitempty" yaml:"strict_validation,omitempty" toml:"strict_validation,omitempty"`
+	MaxMessageSize   int                  `json:"max_message_size,omitempty" yaml:"max_message_size,omitempty" toml:"max_message_size,omitempty"`
+
+	RoutingKeys []*FileRoutingKey `json:"routing_keys,omitempty" yaml:"routing_keys,omitempty" toml:"routing_keys,omitempty"`
+}
+
+// FileHeartbeatConfig is the configuration of M3UA BEAT in FileConfig.
+type FileHeartbeatConfig struct {
+	Interval  Duration `json:"interval" yaml:"interval" toml:"interval"`
+	Timer     Duration `json:"timer" yaml:"timer" toml:"timer"`
+	MaxMisses int      `json:"max_misses,omitempty" yaml:"max_misses,omitempty" toml:"max_misses,omitempty"`
+	Stamp     bool     `json:"stamp,omitempty" yaml:"stamp,omitempty" toml:"stamp,omitempty"`
+	// Data is the data in BEAT in string, which is used only with Stamp.
+	Data string `json:"data,omitempty" yaml:"data,omitempty" toml:"data,omitempty"`
+}
+
+// FileRoutingKey is a Routing Key in FileConfig. The point codes are in the
+// format given by FileConfig.PointCodeFormat.
+type FileRoutingKey struct {
+	LocalRoutingKeyIdentifier *uint32  `json:"local_rk_id,omitempty" yaml:"local_rk_id,omitempty" toml:"local_rk_id,omitempty"`
+	RoutingContext            *uint32  `json:"routing_context,omitempty" yaml:"routing_context,omitempty" toml:"routing_context,omitempty"`
+	TrafficModeType           string   `json:"traffic_mode,omitempty" yaml:"traffic_mode,omitempty" toml:"traffic_mode,omitempty"`
+	DestinationPointCode      string   `json:"dpc" yaml:"dpc" toml:"dpc"`
+	NetworkAppearance         *uint32  `json:"network_appearance,omitempty" yaml:"network_appearance,omitempty" toml:"network_appearance,omitempty"`
+	ServiceIndicators         []uint8  `json:"service_indicators,omitempty" yaml:"service_indicators,omitempty" toml:"service_indicators,omitempty"`
+	OriginatingPointCodes     []string `json:"opcs,omitempty" yaml:"opcs,omitempty" toml:"opcs,omitempty"`
+}
+
+var trafficModeTypes = map[string]uint32{
+	"override":  params.TrafficModeOverride,
+	"loadshare": params.TrafficModeLoadshare,
+	"broadcast": params.TrafficModeBroadcast,
+}
+
+// ConfigFormat is the format of the config file.
+type ConfigFormat string
+
+// ConfigFormat definitions.
+const (
+	ConfigFormatJSON ConfigFormat = "json"
+	ConfigFormatYAML ConfigFormat = "yaml"
+	ConfigFormatTOML ConfigFormat = "toml"
+)
+
+var configFormats = map[string]ConfigFormat{
+	".json": ConfigFormatJSON,
+	".yaml": ConfigFormatYAML,
+	".yml":  ConfigFormatYAML,
+	".toml": ConfigFormatTOML,
+}
+
+// LoadConfigFile reads the FileConfig from the file and validates it.
+// The format is determined by the extension, which is one of ".json",
+// ".yaml", ".yml" and ".toml".
+func LoadConfigFile(path string) (*FileConfig, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	format, ok := configFormats[ext]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedConfigFormat, ext)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadConfigFormat(file, format)
+}
+
+// ReadConfig reads the FileConfig in JSON from r and validates it.
+// The unknown fields are rejected to detect the typos.
+func ReadConfig(r io.Reader) (*FileConfig, error) {
+	return ReadConfigFormat(r, ConfigFormatJSON)
+}
+
+// ReadConfigFormat reads the FileConfig in the format from r and validates it.
+// The unknown fields are rejected to detect the typos.
+func ReadConfigFormat(r io.Reader, format ConfigFormat) (*FileConfig, error) {
+	f := &FileConfig{}
+	switch format {
+	case ConfigFormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(f); err != nil {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+	case ConfigFormatYAML:
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(f); err != nil {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+	case ConfigFormatTOML:
+		md, err := toml.NewDecoder(r).Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("failed to decode config: unknown field %q", undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedConfigFormat, format)
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Validate checks all the fields in the FileConfig and returns the
+// ConfigErrors joined with errors.Join if any of them is invalid.
+func (f *FileConfig) Validate() error {
+	var errs []error
+	add := func(field string, err error) {
+		errs = append(errs, &ConfigError{Field: field, Err: err})
+	}
+
+	if _, ok := netMap[f.network()]; !ok {
+		add("network", fmt.Errorf("unknown network %q", f.Network))
+	}
+	for i, a := range f.LocalAddrs {
+		if _, err := netip.ParseAddr(a); err != nil {
+			add(fmt.Sprintf("local_addrs[%d]", i), err)
+		}
+	}
+	for i, a := range f.RemoteAddrs {
+		if _, err := netip.ParseAddr(a); err != nil {
+			add(fmt.Sprintf("remote_addrs[%d]", i), err)
+		}
+	}
+	if f.LocalPort < 0 || f.LocalPort > 0xffff {
+		add("local_port", fmt.Errorf("%d is out of range", f.LocalPort))
+	}
+	if f.RemotePort < 0 || f.RemotePort > 0xffff {
+		add("remote_port", fmt.Errorf("%d is out of range", f.RemotePort))
+	}
+	if len(f.RemoteAddrs) > 0 && f.RemotePort == 0 {
+		add("remote_port", errors.New("required with remote_addrs"))
+	}
+
+	seen := map[uint32]bool{}
+	for i, rc := range f.RoutingContexts {
+		if seen[rc] {
+			add(fmt.Sprintf("routing_contexts[%d]", i), fmt.Errorf("%d is duplicated", rc))
+		}
+		seen[rc] = true
+	}
+	if _, err := parseTrafficModeType(f.TrafficModeType); err != nil {
+		add("traffic_mode", err)
+	}
+
+	variant := pc.Variant(f.PointCodeFormat)
+	if variant != pc.VariantNone && variant.BitLength() == 0 {
+		add("point_code_format", fmt.Errorf("unknown format %q", f.PointCodeFormat))
+	} else {
+		if _, err := parsePointCode(f.OriginatingPointCode, variant); err != nil {
+			add("opc", err)
+		}
+		if _, err := parsePointCode(f.DestinationPointCode, variant); err != nil {
+			add("dpc", err)
+		}
+	}
+	if f.ServiceIndicator > 0x0f {
+		add("si", fmt.Errorf("%d is out of range", f.ServiceIndicator))
+	}
+	if f.NetworkIndicator > 0x03 {
+		add("ni", fmt.Errorf("%d is out of range", f.NetworkIndicator))
+	}
+	if f.MessagePriority > 0x03 {
+		add("mp", fmt.Errorf("%d is out of range", f.MessagePriority))
+	}
+
+	if hb := f.Heartbeat; hb != nil {
+		if hb.Interval <= 0 {
+			add("heartbeat.interval", errors.New("must be positive"))
+		}
+		if hb.Timer <= 0 {
+			add("heartbeat.timer", errors.New("must be positive"))
+		}
+		if hb.MaxMisses < 0 {
+			add("heartbeat.max_misses", errors.New("must not be negative"))
+		}
+	}
+	if f.EstablishTimeout < 0 {
+		add("establish_timeout", errors.New("must not be negative"))
+	}
+	if f.MaxMessageSize < 0 {
+		add("max_message_size", errors.New("must not be negative"))
+	}
+
+	for i, rk := range f.RoutingKeys {
+		prefix := fmt.Sprintf("routing_keys[%d].", i)
+		if rk == nil {
+			add(prefix[:len(prefix)-1], errors.New("must not be null"))
+			continue
+		}
+		if _, err := parseTrafficModeType(rk.TrafficModeType); err != nil {
+			add(prefix+"traffic_mode", err)
+		}
+		if _, err := parsePointCode(rk.DestinationPointCode, variant); err != nil {
+			add(prefix+"dpc", err)
+		}
+		for j, si := range rk.ServiceIndicators {
+			if si > 0x0f {
+				add(fmt.Sprintf("%sservice_indicators[%d]", prefix, j), fmt.Errorf("%d is out of range", si))
+			}
+		}
+		for j, opc := range rk.OriginatingPointCodes {
+			if _, err := parsePointCode(opc, variant); err != nil {
+				add(fmt.Sprintf("%sopcs[%d]", prefix, j), err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Config creates a new Config from the FileConfig.
+func (f *FileConfig) Config() (*Config, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	variant := pc.Variant(f.PointCodeFormat)
+	opc, _ := parsePointCode(f.OriginatingPointCode, variant)
+	dpc, _ := parsePointCode(f.DestinationPointCode, variant)
+	cfg := NewConfig(opc, dpc, f.ServiceIndicator, f.NetworkIndicator, f.MessagePriority, f.SignalingLinkSelection)
+
+	if f.AspIdentifier != nil {
+		cfg.SetAspIdentifier(*f.AspIdentifier)
+	}
+	if len(f.RoutingContexts) > 0 {
+		cfg.SetRoutingContexts(f.RoutingContexts...)
+	}
+	if f.TrafficModeType != "" {
+		cfg.SetTrafficModeType(trafficModeTypes[strings.ToLower(f.TrafficModeType)])
+	}
+	if f.NetworkAppearance != nil {
+		cfg.SetNetworkAppearance(*f.NetworkAppearance)
+	}
+	if f.CorrelationID != nil {
+		cfg.SetCorrelationID(*f.CorrelationID)
+	}
+
+	if hb := f.Heartbeat; hb != nil {
+		cfg.HeartbeatInfo = NewHeartbeatInfo(time.Duration(hb.Interval), time.Duration(hb.Timer), []byte(hb.Data))
+		cfg.HeartbeatInfo.MaxMisses = hb.MaxMisses
+		cfg.HeartbeatInfo.Stamp = hb.Stamp
+	}
+	cfg.EstablishTimeout = time.Duration(f.EstablishTimeout)
+	cfg.StrictValidation = f.StrictValidation
+	cfg.MaxMessageSize = f.MaxMessageSize
+
+	for _, rk := range f.RoutingKeys {
+		cfg.RoutingKeys = append(cfg.RoutingKeys, rk.payload(variant))
+	}
+	return cfg, nil
+}
+
+// LocalAddr returns the local address in the FileConfig, which can be nil
+// to let the system choose it.
+func (f *FileConfig) LocalAddr() (*sctp.SCTPAddr, error) {
+	if len(f.LocalAddrs) == 0 && f.LocalPort == 0 {
+		return nil, nil
+	}
+	return f.sctpAddr(f.LocalAddrs, f.LocalPort)
+}
+
+// RemoteAddr returns the remote address in the FileConfig.
+func (f *FileConfig) RemoteAddr() (*sctp.SCTPAddr, error) {
+	if len(f.RemoteAddrs) == 0 {
+		return nil, &ConfigError{Field: "remote_addrs", Err: errors.New("not configured")}
+	}
+	return f.sctpAddr(f.RemoteAddrs, f.RemotePort)
+}
+
+// NetworkName returns the network to be passed to Dial or Listen.
+func (f *FileConfig) NetworkName() string {
+	return f.network()
+}
+
+func (f *FileConfig) network() string {
+	if f.Network == "" {
+		return "m3ua"
+	}
+	return f.Network
+}
+
+func (f *FileConfig) sctpAddr(addrs []string, port int) (*sctp.SCTPAddr, error) {
+	a := &sctp.SCTPAddr{Port: port}
+	for _, s := range addrs {
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, err
+		}
+		a.IPAddrs = append(a.IPAddrs, net.IPAddr{IP: ip.AsSlice(), Zone: ip.Zone()})
+	}
+	return a, nil
+}
+
+func (rk *FileRoutingKey) payload(variant pc.Variant) *params.RoutingKeyPayload {
+	r := &params.RoutingKeyPayload{}
+	if rk.LocalRoutingKeyIdentifier != nil {
+		r.LocalRoutingKeyIdentifier = params.NewLocalRoutingKeyIdentifier(*rk.LocalRoutingKeyIdentifier)
+	}
+	if rk.RoutingContext != nil {
+		r.RoutingContext = params.NewRoutingContext(*rk.RoutingContext)
+	}
+	if rk.TrafficModeType != "" {
+		r.TrafficModeType = params.NewTrafficModeType(trafficModeTypes[strings.ToLower(rk.TrafficModeType)])
+	}
+	dpc, _ := parsePointCode(rk.DestinationPointCode, variant)
+	r.DestinationPointCode = params.NewDestinationPointCode(dpc)
+	if rk.NetworkAppearance != nil {
+		r.NetworkAppearance = params.NewNetworkAppearance(*rk.NetworkAppearance)
+	}
+	if len(rk.ServiceIndicators) > 0 {
+		r.ServiceIndicators = params.NewServiceIndicators(rk.ServiceIndicators...)
+	}
+	if len(rk.OriginatingPointCodes) > 0 {
+		opcs := make([]uint32, len(rk.OriginatingPointCodes))
+		for i, s := range rk.OriginatingPointCodes {
+			opcs[i], _ = parsePointCode(s, variant)
+		}
+		r.OriginatingPointCodeList = params.NewOriginatingPointCodeList(opcs...)
+	}
+	return r
+}
+
+func parseTrafficModeType(s string) (uint32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	tmt, ok := trafficModeTypes[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown traffic mode %q, should be override, loadshare or broadcast", s)
+	}
+	return tmt, nil
+}
+
+// parsePointCode parses the point code in the format of the variant,
+// or in decimal if the variant is VariantNone.
+func parsePointCode(s string, variant pc.Variant) (uint32, error) {
+	if s == "" {
+		return 0, errors.New("required")
+	}
+
+	if variant == pc.VariantNone {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || v > 0xffffff {
+			return 0, fmt.Errorf("%q is not a point code in decimal", s)
+		}
+		return uint32(v), nil
+	}
+
+	p := pc.NewPointCodeFrom(s, variant)
+	// the formatted one should be the same when converted back, otherwise
+	// some of the members are out of range.
+	if p == nil || pc.NewPointCode(p.Uint32(), variant).String() != s {
+		return 0, fmt.Errorf("%q is not a point code in %s", s, variant)
+	}
+	return p.Uint32(), nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	files := map[string]string{
+		"m3ua.json": `{
+			"local_addrs": ["192.0.2.1", "198.51.100.1"],
+			"local_port": 2905,
+			"remote_addrs": ["192.0.2.2"],
+			"remote_port": 2905,
+			"asp_id": 1,
+			"routing_contexts": [10, 20],
+			"traffic_mode": "loadshare",
+			"network_appearance": 3,
+			"point_code_format": "3-8-3",
+			"opc": "1-2-3",
+			"dpc": "4-5-6",
+			"si": 3,
+			"ni": 2,
+			"sls": 5,
+			"heartbeat": {"interval": "30s", "timer": "5s", "max_misses": 3, "stamp": true},
+			"establish_timeout": "3s",
+			"routing_keys": [{"routing_context": 10, "dpc": "4-5-6", "service_indicators": [3], "opcs": ["1-2-3"]}]
+		}`,
+		"m3ua.yaml": `
+local_addrs: [192.0.2.1, 198.51.100.1]
+local_port: 2905
+remote_addrs: [192.0.2.2]
+remote_port: 2905
+asp_id: 1
+routing_contexts: [10, 20]
+traffic_mode: loadshare
+network_appearance: 3
+point_code_format: 3-8-3
+opc: 1-2-3
+dpc: 4-5-6
+si: 3
+ni: 2
+sls: 5
+heartbeat:
+  interval: 30s
+  timer: 5s
+  max_misses: 3
+  stamp: true
+establish_timeout: 3s
+routing_keys:
+  - routing_context: 10
+    dpc: 4-5-6
+    service_indicators: [3]
+    opcs: [1-2-3]
+`,
+		"m3ua.toml": `
+local_addrs = ["192.0.2.1", "198.51.100.1"]
+local_port = 2905
+remote_addrs = ["192.0.2.2"]
+remote_port = 2905
+asp_id = 1
+routing_contexts = [10, 20]
+traffic_mode = "loadshare"
+network_appearance = 3
+point_code_format = "3-8-3"
+opc = "1-2-3"
+dpc = "4-5-6"
+si = 3
+ni = 2
+sls = 5
+establish_timeout = "3s"
+
+[heartbeat]
+interval = "30s"
+timer = "5s"
+max_misses = 3
+stamp = true
+
+[[routing_keys]]
+routing_context = 10
+dpc = "4-5-6"
+service_indicators = [3]
+opcs = ["1-2-3"]
+`,
+	}
+
+	want := NewConfigWith(
+		WithPointCodes(0x0813, 0x202e),
+		WithMTP3(3, 2, 0, 5),
+		WithAspIdentifier(1),
+		WithRoutingContexts(10, 20),
+		WithTrafficModeType(params.TrafficModeLoadshare),
+		WithNetworkAppearance(3),
+		WithHeartbeatMaxMisses(3),
+		WithHeartbeatStamp(),
+		WithHeartbeat(30*time.Second, 5*time.Second),
+		WithEstablishTimeout(3*time.Second),
+		WithRoutingKeys(params.NewRoutingKeyPayload(
+			nil, params.NewRoutingContext(10), nil, params.NewDestinationPointCode(0x202e),
+			nil, params.NewServiceIndicators(3), params.NewOriginatingPointCodeList(0x0813),
+		)),
+	)
+	want.HeartbeatInfo.Data = []byte{}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := LoadConfigFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := f.Config()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, cfg); diff != "" {
+				t.Error(diff)
+			}
+
+			laddr, err := f.LocalAddr()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := laddr.String(); got != "192.0.2.1/198.51.100.1:2905" {
+				t.Errorf("got local address %s", got)
+			}
+			raddr, err := f.RemoteAddr()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := raddr.String(); got != "192.0.2.2:2905" {
+				t.Errorf("got remote address %s", got)
+			}
+		})
+	}
+}
+
+func TestReadConfigErrors(t *testing.T) {
+	_, err := ReadConfig(strings.NewReader(`{
+		"network": "tcp",
+		"remote_addrs": ["example.com"],
+		"routing_contexts": [1, 1],
+		"traffic_mode": "active",
+		"point_code_format": "3-8-3",
+		"opc": "1-256-1",
+		"dpc": "1-2",
+		"si": 16,
+		"heartbeat": {"interval": "0s", "timer": "1s"},
+		"routing_keys": [{"dpc": "1-1-1", "opcs": ["8-1-1"]}]
+	}`))
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ce *ConfigError
+		if !errors.As(e, &ce) {
+			t.Fatalf("unexpected error: %v", e)
+		}
+		fields = append(fields, ce.Field)
+	}
+	want := []string{
+		"network", "remote_addrs[0]", "remote_port", "routing_contexts[1]", "traffic_mode",
+		"opc", "dpc", "si", "heartbeat.interval", "routing_keys[0].opcs[0]",
+	}
+	if diff := cmp.Diff(want, fields); diff != "" {
+		t.Error(diff)
+	}
+
+	for format, content := range map[ConfigFormat]string{
+		ConfigFormatJSON: `{"opc": "1", "dpc": "2", "unknown": 1}`,
+		ConfigFormatYAML: "opc: \"1\"\ndpc: \"2\"\nunknown: 1\n",
+		ConfigFormatTOML: "opc = \"1\"\ndpc = \"2\"\nunknown = 1\n",
+	} {
+		if _, err := ReadConfigFormat(strings.NewReader(content), format); err == nil {
+			t.Errorf("%s: unknown field should be rejected", format)
+		}
+	}
+
+	// the errors in the fields are reported in the same way in all formats.
+	var ce *ConfigError
+	if _, err := ReadConfigFormat(strings.NewReader("opc: \"1\"\ndpc: \"2\"\nsi: 16\n"), ConfigFormatYAML); !errors.As(err, &ce) || ce.Field != "si" {
+		t.Errorf("got %v, want ConfigError in si", err)
+	}
+	if _, err := LoadConfigFile("m3ua.ini"); !errors.Is(err, ErrUnsupportedConfigFormat) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedConfigFormat)
+	}
+}
@@ -6,9 +6,11 @@ package m3ua
 
 import (
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ishidawataru/sctp"
@@ -38,8 +40,12 @@ type Conn struct {
 	stateChan chan State
 	// established notifies client/server the conn is established
 	established chan struct{}
-	// beatAckChan notifies that heartbeat gets the ack as expected
-	beatAckChan chan struct{}
+	// closed is closed when the Conn is closed
+	closed chan struct{}
+	// closeOnce is to close the channels only once
+	closeOnce sync.Once
+	// hb is the state of the heartbeat
+	hb heartbeatState
 	// dataChan is to pass the ProtocolDataPayload(=payload on M3UA DATA) to user
 	dataChan chan *params.ProtocolDataPayload
 	// errChan is to pass errors to goroutine that monitors status
@@ -48,10 +54,31 @@ type Conn struct {
 	sctpConn *sctp.SCTPConn
 	// sctpInfo is SndRcvInfo in SCTP association
 	sctpInfo *sctp.SndRcvInfo
-	// cfg is a configuration that is required to communicate between M3UA endpoints
-	cfg *Config
-	// Condition to allow heartbeat, only after the state is AspUp
+	// cfg is a configuration that is required to communicate between M3UA endpoints,
+	// which is replaced once by the one for the ASP in server mode
+	cfg atomic.Pointer[Config]
+	// cfgForASP is set when the Config for the ASP is resolved
+	cfgForASP atomic.Bool
+	// Condition to allow heartbeat, only after the state is AspInactive or AspActive
 	beatAllow *sync.Cond
+	// laddr and raddr are the primary addresses retrieved when established
+	laddr, raddr net.Addr
+	// info identifies the Conn in Metrics
+	info ConnInfo
+	// recvStream is the stream ID of the last message read, only with Tap
+	recvStream uint16
+	// configForASP is Listener.ConfigForASP, only in server mode
+	configForASP func(aspID uint32) *Config
+	// store is Listener.Store, only in server mode
+	store *Store
+	// as is the state of the peer regarding the ASes in store
+	as asState
+	// listener is the Listener that accepted the Conn, only in server mode
+	listener *Listener
+	// draining is set when the listener is shutting down
+	draining atomic.Bool
+	// logger is the logger with the attributes of the association
+	logger atomic.Pointer[slog.Logger]
 }
 
 var netMap = map[string]string{
@@ -60,10 +87,31 @@ var netMap = map[string]string{
 	"m3ua6": "sctp6",
 }
 
+// init prepares the Conn for logging, Tap and Metrics after the association
+// is established.
+func (c *Conn) init(assocID sctp.SCTPAssocID) {
+	c.laddr, c.raddr = c.sctpConn.LocalAddr(), c.sctpConn.RemoteAddr()
+	c.info = ConnInfo{AssocID: int32(assocID)}
+	if c.laddr != nil {
+		c.info.LocalAddr = c.laddr.String()
+	}
+	if c.raddr != nil {
+		c.info.RemoteAddr = c.raddr.String()
+	}
+
+	c.initLogger(assocID)
+	c.initTap()
+}
+
+// config returns the Config of the Conn.
+func (c *Conn) config() *Config {
+	return c.cfg.Load()
+}
+
 // Read reads data from the connection.
 func (c *Conn) Read(b []byte) (n int, err error) {
 	err = func() error {
-		if c.State() != StateAspActive {
+		if !c.readable() {
 			return ErrNotEstablished
 		}
 		return nil
@@ -85,7 +133,7 @@ func (c *Conn) Read(b []byte) (n int, err error) {
 // ReadPD reads the next ProtocolDataPayload from the connection.
 func (c *Conn) ReadPD() (pd *params.ProtocolDataPayload, err error) {
 	err = func() error {
-		if c.State() != StateAspActive {
+		if !c.readable() {
 			return ErrNotEstablished
 		}
 		return nil
@@ -102,6 +150,13 @@ func (c *Conn) ReadPD() (pd *params.ProtocolDataPayload, err error) {
 	return pd, nil
 }
 
+// readable reports whether the DATA can be read, which is when the ASP is
+// active, or the DATA received before is left while the listener is shutting
+// down.
+func (c *Conn) readable() bool {
+	return c.State() == StateAspActive || (c.draining.Load() && len(c.dataChan) > 0)
+}
+
 // Write writes data to the connection.
 func (c *Conn) Write(b []byte) (n int, err error) {
 	stream := c.chooseStreamID()
@@ -114,27 +169,12 @@ func (c *Conn) WriteToStream(b []byte, streamID uint16) (n int, err error) {
 	if c.State() != StateAspActive {
 		return 0, ErrNotEstablished
 	}
-	d, err := messages.NewData(
-		c.cfg.NetworkAppearance, c.cfg.RoutingContexts, params.NewProtocolData(
-			c.cfg.OriginatingPointCode, c.cfg.DestinationPointCode,
-			c.cfg.ServiceIndicator, c.cfg.NetworkIndicator,
-			c.cfg.MessagePriority, c.cfg.SignalingLinkSelection, b,
-		), c.cfg.CorrelationID,
-	).MarshalBinary()
-	if err != nil {
-		return 0, err
-	}
-
-	// taken by value to avoid race condition on the stream id
-	info := *c.sctpInfo
-	info.Stream = streamID
-	n, err = c.sctpConn.SCTPWrite(d, &info)
-	if err != nil {
-		return 0, err
-	}
-
-	n += len(d)
-	return n, nil
+	cfg := c.config()
+	return c.writeData(params.NewProtocolData(
+		cfg.OriginatingPointCode, cfg.DestinationPointCode,
+		cfg.ServiceIndicator, cfg.NetworkIndicator,
+		cfg.MessagePriority, cfg.SignalingLinkSelection, b,
+	), streamID)
 }
 
 // WritePD writes data with a specific mtp3 protocol data to the connection.
@@ -149,32 +189,46 @@ func (c *Conn) WritePDToStream(protocolData *params.Param, streamID uint16) (n i
 	if c.State() != StateAspActive {
 		return 0, ErrNotEstablished
 	}
-	d, err := messages.NewData(
-		c.cfg.NetworkAppearance, // cannot be changed on an active connection
-		c.cfg.RoutingContexts,   // cannot be changed on an active connection
-		protocolData,            // custom mtp3 protocol data OPC, DPC, SI, NI, MP, and SLS, flexible on active connections
-		c.cfg.CorrelationID,
-	).MarshalBinary()
-	if err != nil {
+	return c.writeData(protocolData, streamID)
+}
+
+// writeData writes a Data with the given Protocol Data to the connection and
+// specific stream, using a buffer from the pool.
+func (c *Conn) writeData(protocolData *params.Param, streamID uint16) (n int, err error) {
+	cfg := c.config()
+	d := messages.NewData(
+		cfg.NetworkAppearance, // cannot be changed on an active connection
+		cfg.RoutingContexts,   // cannot be changed on an active connection
+		protocolData,          // custom mtp3 protocol data OPC, DPC, SI, NI, MP, and SLS, flexible on active connections
+		cfg.CorrelationID,
+	)
+
+	bp := getBuffer(d.MarshalLen())
+	defer putBuffer(bp)
+	if err := d.MarshalTo(*bp); err != nil {
 		return 0, err
 	}
 
 	// taken by value to avoid race condition on the stream id
 	info := *c.sctpInfo
 	info.Stream = streamID
-	n, err = c.sctpConn.SCTPWrite(d, &info)
+	n, err = c.sctpConn.SCTPWrite(*bp, &info)
 	if err != nil {
 		return 0, err
 	}
+	c.tap(DirectionSent, streamID, d, *bp, nil)
+	c.countMessage(DirectionSent, d)
 
-	n += len(d)
+	n += len(*bp)
 	return n, nil
 }
 
 // WriteSignal writes any type of M3UA signals on top of SCTP Connection.
 func (c *Conn) WriteSignal(m3 messages.M3UA) (n int, err error) {
 	n = m3.MarshalLen()
-	buf := make([]byte, n)
+	bp := getBuffer(n)
+	defer putBuffer(bp)
+	buf := *bp
 	if err := m3.MarshalTo(buf); err != nil {
 		return 0, fmt.Errorf("failed to create %T: %w", m3, err)
 	}
@@ -189,6 +243,11 @@ func (c *Conn) WriteSignal(m3 messages.M3UA) (n int, err error) {
 	if err != nil {
 		return 0, fmt.Errorf("failed to write M3UA: %w", err)
 	}
+	c.tap(DirectionSent, sctpInfo.Stream, m3, buf, nil)
+	c.countMessage(DirectionSent, m3)
+	if m3.MessageClass() != messages.MsgClassTransfer {
+		c.log().Debug("sent message", "message", m3.MessageTypeName())
+	}
 
 	n += nn
 	return
@@ -196,17 +255,29 @@ func (c *Conn) WriteSignal(m3 messages.M3UA) (n int, err error) {
 
 // Close closes the connection.
 func (c *Conn) Close() error {
+	c.unregister()
+	defer c.forgetMetrics()
+
 	c.muState.Lock()
 	defer c.muState.Unlock()
 
-	if c.state == StateAspDown {
-		return c.sctpConn.Close()
+	// the channels are closed even if the ASP is already down, e.g., by
+	// Listener.Shutdown, to stop the goroutines waiting on them.
+	c.closeOnce.Do(func() {
+		for _, ch := range []chan struct{}{c.established, c.closed} {
+			if ch != nil {
+				close(ch)
+			}
+		}
+		if c.dataChan != nil {
+			close(c.dataChan)
+		}
+	})
+	if c.state != StateAspDown {
+		c.log().Info("state changed", "from", c.state.String(), "to", StateAspDown.String())
+		c.changeState(c.state, StateAspDown)
+		c.state = StateAspDown
 	}
-
-	close(c.established)
-	close(c.beatAckChan)
-	close(c.dataChan)
-	c.state = StateAspDown
 	return c.sctpConn.Close()
 }
 
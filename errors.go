@@ -5,6 +5,7 @@
 package m3ua
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -22,10 +23,20 @@ var (
 	ErrHeartbeatExpired    = errors.New("heartbeat timer expired")
 	ErrFailedToPeelOff     = errors.New("failed to peel off Protocol Data")
 	ErrFailedToWriteSignal = errors.New("failed to write signal")
+	ErrListenerClosed      = errors.New("M3UA listener closed")
+	ErrLengthMismatch      = errors.New("message length does not match the SCTP message")
 
 	// ErrAspIDRequired is used by an SGP in response to an ASP Up message that
 	// does not contain an ASP Identifier parameter when the SGP requires one.
 	ErrAspIDRequired = errors.New("ASP Identifier required")
+	// ErrManagementBlocking is used by an SGP in response to an ASP Up message
+	// from the ASP that is not allowed to serve any AS in Store, and to ASP Up
+	// and ASP Active messages while the Listener is shutting down.
+	ErrManagementBlocking = errors.New("refused due to management blocking")
+	// ErrNoConfiguredAS is used by an SGP in response to an ASP Active message
+	// without Routing Context from the ASP that is not allowed to serve any AS
+	// in Store.
+	ErrNoConfiguredAS = errors.New("no configured AS for ASP")
 )
 
 // InvalidVersionError is used if a message with an unsupported version is received.
@@ -60,15 +71,7 @@ func (e *UnsupportedClassError) Error() string {
 }
 
 func (e *UnsupportedClassError) first40Octets() []byte {
-	b, err := e.Msg.MarshalBinary()
-	if err != nil {
-		return nil
-	}
-	if len(b) < 40 {
-		return b
-	}
-
-	return b[:40]
+	return first40Octets(e.Msg)
 }
 
 // UnsupportedMessageError is used if a message with an
@@ -88,15 +91,7 @@ func (e *UnsupportedMessageError) Error() string {
 }
 
 func (e *UnsupportedMessageError) first40Octets() []byte {
-	b, err := e.Msg.MarshalBinary()
-	if err != nil {
-		return nil
-	}
-	if len(b) < 40 {
-		return b
-	}
-
-	return b[:40]
+	return first40Octets(e.Msg)
 }
 
 // UnexpectedMessageError is used if a defined and recognized message is received
@@ -131,6 +126,147 @@ func (e *InvalidSCTPStreamIDError) Error() string {
 	return fmt.Sprintf("invalid SCTP Stream ID: %d", e.ID)
 }
 
+// UnsupportedTrafficModeTypeError is used if a Traffic Mode Type that is unknown
+// or inconsistent with the configured one is received.
+type UnsupportedTrafficModeTypeError struct {
+	Mode uint32
+}
+
+// NewUnsupportedTrafficModeTypeError creates UnsupportedTrafficModeTypeError.
+func NewUnsupportedTrafficModeTypeError(mode uint32) *UnsupportedTrafficModeTypeError {
+	return &UnsupportedTrafficModeTypeError{Mode: mode}
+}
+
+// Error returns error string with violating traffic mode.
+func (e *UnsupportedTrafficModeTypeError) Error() string {
+	return fmt.Sprintf("unsupported traffic mode type: %d", e.Mode)
+}
+
+// InvalidRoutingContextError is used if a Routing Context that is not
+// configured for the ASP is received.
+type InvalidRoutingContextError struct {
+	RoutingContext uint32
+}
+
+// NewInvalidRoutingContextError creates InvalidRoutingContextError.
+func NewInvalidRoutingContextError(rtCtx uint32) *InvalidRoutingContextError {
+	return &InvalidRoutingContextError{RoutingContext: rtCtx}
+}
+
+// Error returns error string with violating routing context.
+func (e *InvalidRoutingContextError) Error() string {
+	return fmt.Sprintf("invalid routing context: %d", e.RoutingContext)
+}
+
+// MissingParameterError is used if a mandatory or conditionally required
+// parameter is not contained in the received message.
+type MissingParameterError struct {
+	Msg messages.M3UA
+	Tag uint16
+}
+
+// NewMissingParameterError creates MissingParameterError.
+func NewMissingParameterError(msg messages.M3UA, tag uint16) *MissingParameterError {
+	return &MissingParameterError{Msg: msg, Tag: tag}
+}
+
+// Error returns error string with message type and missing parameter tag.
+func (e *MissingParameterError) Error() string {
+	return fmt.Sprintf("missing parameter in %s. tag: %d", e.Msg.MessageTypeName(), e.Tag)
+}
+
+// UnexpectedParameterError is used if the received message contains a
+// parameter that is not expected in it.
+type UnexpectedParameterError struct {
+	Msg messages.M3UA
+	Tag uint16
+}
+
+// NewUnexpectedParameterError creates UnexpectedParameterError.
+func NewUnexpectedParameterError(msg messages.M3UA, tag uint16) *UnexpectedParameterError {
+	return &UnexpectedParameterError{Msg: msg, Tag: tag}
+}
+
+// Error returns error string with message type and unexpected parameter tag.
+func (e *UnexpectedParameterError) Error() string {
+	return fmt.Sprintf("unexpected parameter in %s. tag: %d", e.Msg.MessageTypeName(), e.Tag)
+}
+
+// InvalidParameterValueError is used if the received message contains a
+// parameter with an invalid value.
+type InvalidParameterValueError struct {
+	Msg messages.M3UA
+	Tag uint16
+}
+
+// NewInvalidParameterValueError creates InvalidParameterValueError.
+func NewInvalidParameterValueError(msg messages.M3UA, tag uint16) *InvalidParameterValueError {
+	return &InvalidParameterValueError{Msg: msg, Tag: tag}
+}
+
+// Error returns error string with message type and violating parameter tag.
+func (e *InvalidParameterValueError) Error() string {
+	return fmt.Sprintf("invalid parameter value in %s. tag: %d", e.Msg.MessageTypeName(), e.Tag)
+}
+
+// MessageTooLargeError is used if a message larger than the maximum size
+// configured is received. Head contains the first 40 octets of the message
+// at most, to be used as the Diagnostic Information.
+type MessageTooLargeError struct {
+	Length uint32
+	Max    int
+	Head   []byte
+}
+
+// NewMessageTooLargeError creates MessageTooLargeError.
+func NewMessageTooLargeError(length uint32, max int, head []byte) *MessageTooLargeError {
+	return &MessageTooLargeError{Length: length, Max: max, Head: head}
+}
+
+// Error returns error string with the length of the message and the maximum size.
+func (e *MessageTooLargeError) Error() string {
+	return fmt.Sprintf("message too large: %d bytes, max: %d", e.Length, e.Max)
+}
+
+// MalformedMessageError is used if a received message cannot be decoded.
+// Head contains the first 40 octets of the message at most, to be used as
+// the Diagnostic Information.
+type MalformedMessageError struct {
+	Head []byte
+	Err  error
+}
+
+// NewMalformedMessageError creates MalformedMessageError. The raw message is
+// copied as it may refer to the receive buffer.
+func NewMalformedMessageError(raw []byte, err error) *MalformedMessageError {
+	if len(raw) > 40 {
+		raw = raw[:40]
+	}
+	return &MalformedMessageError{Head: bytes.Clone(raw), Err: err}
+}
+
+// Error returns error string with the reason of the decoding failure.
+func (e *MalformedMessageError) Error() string {
+	return fmt.Sprintf("malformed message: %v", e.Err)
+}
+
+// Unwrap returns the error of the decoding failure.
+func (e *MalformedMessageError) Unwrap() error {
+	return e.Err
+}
+
+func first40Octets(msg messages.M3UA) []byte {
+	b, err := msg.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	if len(b) < 40 {
+		return b
+	}
+
+	return b[:40]
+}
+
 func (c *Conn) handleErrors(e error) error {
 	var res messages.M3UA
 	var InvalidVersionError *InvalidVersionError
@@ -160,10 +296,10 @@ func (c *Conn) handleErrors(e error) error {
 	if errors.As(e, &UnexpectedMessageError) {
 		res = messages.NewError(
 			params.NewErrorCode(params.UnexpectedMessageError),
-			c.cfg.RoutingContexts,
-			c.cfg.NetworkAppearance,
+			c.config().RoutingContexts,
+			c.config().NetworkAppearance,
 			params.NewAffectedPointCode(
-				c.cfg.OriginatingPointCode,
+				c.config().OriginatingPointCode,
 			),
 			nil,
 		)
@@ -175,17 +311,85 @@ func (c *Conn) handleErrors(e error) error {
 			nil, nil, nil, nil,
 		)
 	}
+	var UnsupportedTrafficModeTypeError *UnsupportedTrafficModeTypeError
+	if errors.As(e, &UnsupportedTrafficModeTypeError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrUnsupportedTrafficModeType),
+			nil, nil, nil, nil,
+		)
+	}
+	var InvalidRoutingContextError *InvalidRoutingContextError
+	if errors.As(e, &InvalidRoutingContextError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrInvalidRoutingContext),
+			params.NewRoutingContext(InvalidRoutingContextError.RoutingContext),
+			nil, nil, nil,
+		)
+	}
+	var MissingParameterError *MissingParameterError
+	if errors.As(e, &MissingParameterError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrMissingParameter),
+			nil, nil, nil,
+			params.NewDiagnosticInformation(first40Octets(MissingParameterError.Msg)),
+		)
+	}
+	var UnexpectedParameterError *UnexpectedParameterError
+	if errors.As(e, &UnexpectedParameterError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrUnexpectedParameter),
+			nil, nil, nil,
+			params.NewDiagnosticInformation(first40Octets(UnexpectedParameterError.Msg)),
+		)
+	}
+	var InvalidParameterValueError *InvalidParameterValueError
+	if errors.As(e, &InvalidParameterValueError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrInvalidParameterValue),
+			nil, nil, nil,
+			params.NewDiagnosticInformation(first40Octets(InvalidParameterValueError.Msg)),
+		)
+	}
+	var MessageTooLargeError *MessageTooLargeError
+	if errors.As(e, &MessageTooLargeError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrProtocolError),
+			nil, nil, nil,
+			params.NewDiagnosticInformation(MessageTooLargeError.Head),
+		)
+	}
+	var MalformedMessageError *MalformedMessageError
+	if errors.As(e, &MalformedMessageError) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrParameterFieldError),
+			nil, nil, nil,
+			params.NewDiagnosticInformation(MalformedMessageError.Head),
+		)
+	}
 	if errors.Is(e, ErrAspIDRequired) {
 		res = messages.NewError(
 			params.NewErrorCode(params.ErrAspIdentifierRequired),
 			nil, nil, nil, nil,
 		)
 	}
+	if errors.Is(e, ErrManagementBlocking) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrRefusedManagementBlocking),
+			nil, nil, nil, nil,
+		)
+	}
+	if errors.Is(e, ErrNoConfiguredAS) {
+		res = messages.NewError(
+			params.NewErrorCode(params.ErrNoConfiguredAsForAsp),
+			nil, nil, nil, nil,
+		)
+	}
 
 	if res == nil {
 		return e
 	}
 
+	c.log().Warn("responding with ERROR", "error", e)
 	if _, err := c.WriteSignal(res); err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ package m3ua
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"sync"
 
@@ -47,6 +48,10 @@ func (c *Conn) handleStateUpdate(current State) error {
 	defer c.muState.Unlock()
 	previous := c.state
 	c.state = current
+	if current != previous {
+		c.log().Info("state changed", "from", previous.String(), "to", current.String())
+		c.changeState(previous, current)
+	}
 
 	switch c.mode {
 	case modeClient:
@@ -69,10 +74,13 @@ func (c *Conn) handleStateUpdateAsClient(current, previous State) error {
 	case StateAspDown:
 		return c.initiateASPSM()
 	case StateAspInactive:
+		if current != previous {
+			c.beatAllow.Broadcast()
+		}
 		return c.initiateASPTM()
 	case StateAspActive:
 		if current != previous {
-			c.established <- struct{}{}
+			c.notifyEstablished()
 			c.beatAllow.Broadcast()
 		}
 		return nil
@@ -89,12 +97,15 @@ func (c *Conn) handleStateUpdateAsServer(current, previous State) error {
 		// do nothing. just wait for the message from peer and state is updated
 		return nil
 	case StateAspInactive:
-		// do nothing. just wait for the message from peer and state is updated
+		// just wait for the message from peer and state is updated
 		// XXX - send DAVA to notify peer?
+		if current != previous {
+			c.beatAllow.Broadcast()
+		}
 		return nil
 	case StateAspActive:
 		if current != previous {
-			c.established <- struct{}{}
+			c.notifyEstablished()
 			c.beatAllow.Broadcast()
 		}
 		return nil
@@ -105,6 +116,16 @@ func (c *Conn) handleStateUpdateAsServer(current, previous State) error {
 	}
 }
 
+// notifyEstablished notifies Dial or Accept that the Conn is established.
+// It does not block when the ASP becomes active again later, which is not
+// waited for by anyone.
+func (c *Conn) notifyEstablished() {
+	select {
+	case c.established <- struct{}{}:
+	default:
+	}
+}
+
 func (c *Conn) handleSignals(ctx context.Context, m3 messages.M3UA) {
 	select {
 	case <-ctx.Done():
@@ -112,57 +133,78 @@ func (c *Conn) handleSignals(ctx context.Context, m3 messages.M3UA) {
 	default:
 	}
 
+	if _, ok := m3.(*messages.Data); !ok {
+		c.log().Debug("received message", "message", m3.MessageTypeName())
+	}
+
 	// Signal validations
 	if m3.Version() != 1 {
 		c.errChan <- NewInvalidVersionError(m3.Version())
+		c.stateChan <- c.State()
 		return
 	}
 
 	switch msg := m3.(type) {
 	// Transfer message
 	case *messages.Data:
-		go c.handleData(ctx, msg)
+		c.handleData(ctx, msg)
 		c.stateChan <- c.State()
 	// ASPSM
 	case *messages.AspUp:
 		if err := c.handleAspUp(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspInactive
 	case *messages.AspUpAck:
 		if err := c.handleAspUpAck(msg); err != nil {
 			c.errChan <- err
+			c.giveUpOnInvalidAck(err)
+			return
 		}
 		c.stateChan <- StateAspInactive
 	case *messages.AspDown:
 		if err := c.handleAspDown(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspDown
 	case *messages.AspDownAck:
 		if err := c.handleAspDownAck(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspDown
 	// ASPTM
 	case *messages.AspActive:
 		if err := c.handleAspActive(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspActive
 	case *messages.AspActiveAck:
 		if err := c.handleAspActiveAck(msg); err != nil {
 			c.errChan <- err
+			c.giveUpOnInvalidAck(err)
+			return
 		}
 		c.stateChan <- StateAspActive
 	case *messages.AspInactive:
 		if err := c.handleAspInactive(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspInactive
 	case *messages.AspInactiveAck:
 		if err := c.handleAspInactiveAck(msg); err != nil {
 			c.errChan <- err
+			c.stateChan <- c.State()
+			return
 		}
 		c.stateChan <- StateAspInactive
 	case *messages.Heartbeat:
@@ -174,7 +216,6 @@ func (c *Conn) handleSignals(ctx context.Context, m3 messages.M3UA) {
 		if err := c.handleHeartbeatAck(msg); err != nil {
 			c.errChan <- err
 		}
-		c.beatAckChan <- struct{}{}
 		c.stateChan <- c.State()
 		// Management
 	case *messages.Error:
@@ -194,17 +235,30 @@ func (c *Conn) handleSignals(ctx context.Context, m3 messages.M3UA) {
 	}
 }
 
+// giveUpOnInvalidAck stops establishing the connection if the ack from peer
+// is invalid, as resending the request would just repeat the same exchange.
+// Otherwise, it keeps the current state.
+func (c *Conn) giveUpOnInvalidAck(err error) {
+	var unexpected *UnexpectedMessageError
+	if errors.As(err, &unexpected) || c.State() == StateAspActive {
+		c.stateChan <- c.State()
+		return
+	}
+
+	c.errChan <- ErrFailedToEstablish
+}
+
 func (c *Conn) monitor(ctx context.Context) {
 	c.errChan = make(chan error)
 	c.dataChan = make(chan *params.ProtocolDataPayload, 0xffff)
-	c.beatAckChan = make(chan struct{})
+	c.closed = make(chan struct{})
+	c.hb.ack = make(chan struct{}, 1)
 
 	c.beatAllow = sync.NewCond(&sync.Mutex{})
 	c.beatAllow.L.Lock()
 	go c.heartbeat(ctx)
 	defer c.beatAllow.Broadcast()
 
-	buf := make([]byte, 1500)
 	for {
 		select {
 		case <-ctx.Done():
@@ -212,6 +266,7 @@ func (c *Conn) monitor(ctx context.Context) {
 			return
 		case err := <-c.errChan:
 			if e := c.handleErrors(err); e != nil {
+				c.log().Error("closing connection on error", "error", e)
 				c.Close()
 				return
 			}
@@ -220,30 +275,90 @@ func (c *Conn) monitor(ctx context.Context) {
 			// Act properly based on current state.
 			if err := c.handleStateUpdate(state); err != nil {
 				if errors.Is(err, ErrSCTPNotAlive) {
+					c.log().Error("closing connection as SCTP association is not alive")
 					c.Close()
 					return
 				}
 			}
 
 			// Read from conn to see something coming from the peer.
-			n, _, err := c.sctpConn.SCTPRead(buf)
+			bp := getBuffer(c.config().maxMessageSize())
+			n, err := readMessage(c.sctpRead, *bp)
 			if err != nil {
+				if errors.Is(err, ErrLengthMismatch) {
+					stream := c.recvStream
+					go func() {
+						defer putBuffer(bp)
+						c.discard(ctx, (*bp)[:n], stream, err)
+					}()
+					continue
+				}
+				putBuffer(bp)
+
+				var tooLarge *MessageTooLargeError
+				if errors.As(err, &tooLarge) {
+					c.log().Warn("discarded too large message", "error", err)
+					c.tap(DirectionReceived, c.recvStream, nil, tooLarge.Head, err)
+					go func() {
+						c.errChan <- err
+						c.stateChan <- c.State()
+					}()
+					continue
+				}
+
+				c.log().Info("closing connection as reading from SCTP failed", "error", err)
 				c.Close()
 				return
 			}
 
-			raw := make([]byte, n)
-			copy(raw, buf)
+			stream := c.recvStream
 			go func() {
-				// Parse the received packet as M3UA. Undecodable packets are ignored.
-				msg, err := messages.Parse(raw)
-				if err != nil {
-					logf("failed to parse M3UA message: %v, %x", err, raw)
-					return
-				}
-
-				c.handleSignals(ctx, msg)
+				// The buffer can be reused after handleSignals returns, as
+				// nothing is read until the state is updated in it.
+				defer putBuffer(bp)
+				c.parseAndHandle(ctx, (*bp)[:n], stream)
 			}()
 		}
 	}
 }
+
+// parseAndHandle parses the received packet as M3UA and handles it.
+// Undecodable packets are discarded with ERROR, and the next one is read.
+func (c *Conn) parseAndHandle(ctx context.Context, raw []byte, streamID uint16) {
+	if len(raw) >= 4 && raw[2] == messages.MsgClassTransfer && raw[3] == messages.MsgTypePayloadData {
+		d := dataPool.Get().(*messages.Data)
+		defer dataPool.Put(d)
+
+		if err := messages.ParseDataInto(raw, d); err != nil {
+			c.discard(ctx, raw, streamID, err)
+			return
+		}
+		c.tap(DirectionReceived, streamID, d, raw, nil)
+		c.countMessage(DirectionReceived, d)
+		c.handleSignals(ctx, d)
+		return
+	}
+
+	msg, err := messages.Parse(raw)
+	if err != nil {
+		c.discard(ctx, raw, streamID, err)
+		return
+	}
+	c.tap(DirectionReceived, streamID, msg, raw, nil)
+	c.countMessage(DirectionReceived, msg)
+	c.handleSignals(ctx, msg)
+}
+
+// discard discards the received packet that cannot be parsed as M3UA,
+// responds with ERROR and keeps the current state to read the next one.
+func (c *Conn) discard(ctx context.Context, raw []byte, streamID uint16, err error) {
+	c.tap(DirectionReceived, streamID, nil, raw, err)
+	c.log().Warn("discarded undecodable message", "error", err, "raw", hex.EncodeToString(raw))
+
+	select {
+	case <-ctx.Done():
+		return
+	case c.errChan <- NewMalformedMessageError(raw, err):
+	}
+	c.stateChan <- c.State()
+}
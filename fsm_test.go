@@ -0,0 +1,63 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestParseAndHandleMalformed(t *testing.T) {
+	c := withConfig(&Conn{
+		muState:   new(sync.RWMutex),
+		state:     StateAspActive,
+		errChan:   make(chan error),
+		stateChan: make(chan State),
+		dataChan:  make(chan *params.ProtocolDataPayload, 1),
+	}, NewConfig(1, 2, 3, 0, 0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the DATA longer than the packet, and the unknown parameters
+	// with the invalid length.
+	garbage := [][]byte{
+		{0x01, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x20, 0x02, 0x10, 0x00, 0x08, 0xde, 0xad, 0xbe, 0xef},
+		{0x01, 0x00, 0x03, 0x01, 0x00, 0x00, 0x00, 0x0c, 0xff, 0xff, 0x00, 0xff},
+	}
+	for _, raw := range garbage {
+		go c.parseAndHandle(ctx, raw, 1)
+
+		var malformed *MalformedMessageError
+		if err := <-c.errChan; !errors.As(err, &malformed) {
+			t.Fatalf("got %v, want MalformedMessageError", err)
+		}
+		if len(malformed.Head) != len(raw) {
+			t.Errorf("got %d octets of diagnostic, want %d", len(malformed.Head), len(raw))
+		}
+		// the state is kept to read the next message.
+		if got := <-c.stateChan; got != StateAspActive {
+			t.Errorf("got %s, want %s", got, StateAspActive)
+		}
+	}
+
+	valid, err := messages.NewData(
+		nil, nil, params.NewProtocolData(1, 2, params.ServiceIndSCCP, 0, 0, 0, []byte{1, 2, 3}), nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go c.parseAndHandle(ctx, valid, 1)
+	if got := <-c.stateChan; got != StateAspActive {
+		t.Errorf("got %s, want %s", got, StateAspActive)
+	}
+	if pd := <-c.dataChan; len(pd.Data) != 3 {
+		t.Errorf("unexpected data: %v", pd.Data)
+	}
+}
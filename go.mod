@@ -3,7 +3,9 @@ module github.com/wmnsk/go-m3ua
 go 1.23
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/google/go-cmp v0.7.0
 	github.com/ishidawataru/sctp v0.0.0-20251114114122-19ddcbc6aae2
 	github.com/pascaldekloe/goe v0.1.1
+	gopkg.in/yaml.v3 v3.0.1
 )
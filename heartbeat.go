@@ -0,0 +1,130 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"slices"
+	"sync"
+	"time"
+)
+
+// stampLen is the length of the sequence number and the timestamp embedded
+// in the BEAT data when HeartbeatInfo.Stamp is enabled.
+const stampLen = 16
+
+// maxOutstandingBeats is the number of the BEATs without ack remembered to
+// tell the late ack from the invalid one.
+const maxOutstandingBeats = 16
+
+// heartbeatState is the state of the heartbeat of a Conn, which is kept per
+// Conn so that the Conns sharing the same Config do not interfere each other.
+type heartbeatState struct {
+	mu sync.Mutex
+	// seq is the sequence number of the last BEAT sent
+	seq uint64
+	// expected is the data of the last BEAT sent, which is nil after the ack
+	expected []byte
+	// outstanding is the data of the BEATs sent before the last one and not
+	// acked yet, to tell the late ack from the invalid one
+	outstanding [][]byte
+	// sentAt is the time the last BEAT is sent
+	sentAt time.Time
+	// rtt is the round trip time measured with the last ack
+	rtt time.Duration
+	// misses is the number of consecutive BEATs without ack
+	misses int
+	// ack notifies that the ack for the last BEAT is received
+	ack chan struct{}
+}
+
+// next returns the data of the next BEAT to be sent and records it as the
+// one to be acked.
+func (h *heartbeatState) next(info *HeartbeatInfo) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// discard the ack that is left unread after the timer expired.
+	select {
+	case <-h.ack:
+	default:
+	}
+
+	h.seq++
+	now := time.Now()
+
+	var data []byte
+	if info.Stamp {
+		data = make([]byte, stampLen, stampLen+len(info.Data))
+		binary.BigEndian.PutUint64(data[0:8], h.seq)
+		binary.BigEndian.PutUint64(data[8:16], uint64(now.UnixNano()))
+		data = append(data, info.Data...)
+	} else {
+		data = make([]byte, 128)
+		if _, err := rand.Read(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if h.expected != nil {
+		h.outstanding = append(h.outstanding, h.expected)
+		if len(h.outstanding) > maxOutstandingBeats {
+			h.outstanding = h.outstanding[1:]
+		}
+	}
+	h.expected = data
+	h.sentAt = now
+	return data, nil
+}
+
+// acked checks the data in the BEAT ACK received. The matched is true with
+// the RTT if it is the ack for the last BEAT, and false if it is the late one
+// for the previous BEATs. The valid is false if it does not correspond to any
+// BEAT that is sent and not acked yet.
+func (h *heartbeatState) acked(data []byte) (matched bool, rtt time.Duration, valid bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.expected != nil && bytes.Equal(data, h.expected) {
+		h.expected = nil
+		h.outstanding = nil
+		h.misses = 0
+		h.rtt = time.Since(h.sentAt)
+
+		select {
+		case h.ack <- struct{}{}:
+		default:
+		}
+		return true, h.rtt, true
+	}
+
+	for i, b := range h.outstanding {
+		if bytes.Equal(data, b) {
+			h.outstanding = slices.Delete(h.outstanding, i, i+1)
+			return false, 0, true
+		}
+	}
+	return false, 0, false
+}
+
+// miss records that the ack for the last BEAT is not received in time, and
+// returns the number of consecutive misses.
+func (h *heartbeatState) miss() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.misses++
+	return h.misses
+}
+
+// HeartbeatRTT returns the round trip time of BEAT and BEAT ACK measured
+// with the last BEAT ACK, or zero if it is not measured yet.
+func (c *Conn) HeartbeatRTT() time.Duration {
+	c.hb.mu.Lock()
+	defer c.hb.mu.Unlock()
+	return c.hb.rtt
+}
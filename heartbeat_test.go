@@ -0,0 +1,120 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestHeartbeatState(t *testing.T) {
+	info := &HeartbeatInfo{Enabled: true, Timer: time.Second, Data: []byte("hi"), MaxMisses: 3, Stamp: true}
+	h := &heartbeatState{ack: make(chan struct{}, 1)}
+
+	first, err := h.next(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != stampLen+2 || binary.BigEndian.Uint64(first) != 1 || !bytes.HasSuffix(first, info.Data) {
+		t.Fatalf("unexpected stamped data: %x", first)
+	}
+	if got := h.miss(); got != 1 {
+		t.Errorf("got %d misses, want 1", got)
+	}
+
+	second, _ := h.next(info)
+	third, _ := h.next(info)
+	if binary.BigEndian.Uint64(third) != 3 {
+		t.Fatalf("unexpected sequence number: %x", third)
+	}
+
+	// the acks for the missed BEATs are ignored without error, only once.
+	for _, late := range [][]byte{first, second} {
+		if matched, _, valid := h.acked(late); matched || !valid {
+			t.Errorf("late ack %x: got matched=%v, valid=%v", late, matched, valid)
+		}
+	}
+	if _, _, valid := h.acked(first); valid {
+		t.Error("duplicated late ack should be invalid")
+	}
+	if _, _, valid := h.acked([]byte("unknown")); valid {
+		t.Error("unknown ack should be invalid")
+	}
+
+	// the forged ack with the sequence number lower than the last one is
+	// invalid, and does not affect the misses or the RTT.
+	h.miss()
+	forged := bytes.Clone(second)
+	binary.BigEndian.PutUint64(forged[8:16], 0)
+	for _, b := range [][]byte{forged, first} {
+		if matched, _, valid := h.acked(b); matched || valid {
+			t.Errorf("forged ack %x: got matched=%v, valid=%v", b, matched, valid)
+		}
+	}
+	if h.misses != 2 || h.rtt != 0 {
+		t.Errorf("got misses=%d, rtt=%v", h.misses, h.rtt)
+	}
+
+	matched, rtt, valid := h.acked(third)
+	if !matched || !valid || rtt <= 0 {
+		t.Errorf("got matched=%v, rtt=%v, valid=%v", matched, rtt, valid)
+	}
+	select {
+	case <-h.ack:
+	default:
+		t.Error("ack is not notified")
+	}
+	if h.misses != 0 {
+		t.Errorf("misses are not reset: %d", h.misses)
+	}
+
+	// the duplicated ack is not notified again.
+	if matched, _, _ := h.acked(third); matched {
+		t.Error("duplicated ack should not match")
+	}
+}
+
+func TestHandleHeartbeatAck(t *testing.T) {
+	c := withConfig(&Conn{
+		muState: new(sync.RWMutex),
+		state:   StateAspInactive,
+	}, NewConfig(1, 2, 3, 0, 0, 0).EnableHeartbeat(time.Second, time.Second))
+	c.hb.ack = make(chan struct{}, 1)
+
+	data, err := c.hb.next(c.config().HeartbeatInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 128 {
+		t.Errorf("got %d bytes of random data, want 128", len(data))
+	}
+
+	// the Conns sharing the Config have the independent state.
+	other := withConfig(&Conn{muState: new(sync.RWMutex), state: StateAspActive}, c.config())
+	other.hb.next(c.config().HeartbeatInfo)
+
+	if err := c.handleHeartbeatAck(messages.NewHeartbeatAck(params.NewHeartbeatData(data))); err != nil {
+		t.Fatal(err)
+	}
+	if c.HeartbeatRTT() <= 0 {
+		t.Error("RTT is not measured")
+	}
+	var unexpected *UnexpectedMessageError
+	if err := other.handleHeartbeatAck(messages.NewHeartbeatAck(params.NewHeartbeatData(data))); !errors.As(err, &unexpected) {
+		t.Errorf("got %v, want UnexpectedMessageError", err)
+	}
+
+	c.state = StateAspDown
+	if err := c.handleHeartbeatAck(messages.NewHeartbeatAck(params.NewHeartbeatData(data))); !errors.As(err, &unexpected) {
+		t.Errorf("got %v, want UnexpectedMessageError in AspDown", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// ACM is an Address Complete message.
+//
+// Spec: ITU-T Q.763.
+type ACM struct {
+	*Header
+	BackwardCallIndicators uint16
+	Optional               Parameters
+}
+
+// NewACM creates a new ACM.
+func NewACM(v Variant, cic uint16, bci uint16, opts ...*Parameter) *ACM {
+	return &ACM{
+		Header:                 newHeader(v, cic, MsgTypeACM),
+		BackwardCallIndicators: bci,
+		Optional:               opts,
+	}
+}
+
+var acmLayout = layout{fixed: 2, optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *ACM) parts() ([]byte, [][]byte, error) {
+	fixed := make([]byte, 2)
+	putUint16(fixed, m.BackwardCallIndicators)
+	return fixed, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from an ACM.
+func (m *ACM) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *ACM) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return acmLayout.marshalTo(b, m.Header, MsgTypeACM, fixed, variable, m.Optional)
+}
+
+// ParseACM decodes given byte sequence as an ACM in the variant.
+func ParseACM(b []byte, v Variant) (*ACM, error) {
+	m := &ACM{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an ACM.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *ACM) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, _, opts, err := acmLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.BackwardCallIndicators = uint16From(fixed)
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of ACM.
+func (m *ACM) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return acmLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *ACM) MessageType() MsgType {
+	return MsgTypeACM
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *ACM) MessageTypeName() string {
+	return MsgTypeACM.String()
+}
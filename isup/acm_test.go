@@ -0,0 +1,42 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestACM(t *testing.T) {
+	cases := []testCase{
+		{
+			"with-optional",
+			VariantITU,
+			NewACM(VariantITU, 0x0123, 0x1416, NewParameter(ParamOptionalBackwardCallIndicators, []byte{0x01})),
+			[]byte{
+				// CIC, Type
+				0x23, 0x01, 0x06,
+				// BCI
+				0x16, 0x14,
+				// Pointer
+				0x01,
+				// Optional Backward Call Indicators
+				0x29, 0x01, 0x01,
+				// End of Optional Parameters
+				0x00,
+			},
+		}, {
+			"no-optional",
+			VariantITU,
+			NewACM(VariantITU, 0x0123, 0x1416),
+			[]byte{0x23, 0x01, 0x06, 0x16, 0x14, 0x00},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseACM(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
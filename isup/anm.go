@@ -0,0 +1,85 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// ANM is an Answer message.
+//
+// Spec: ITU-T Q.763.
+type ANM struct {
+	*Header
+	Optional Parameters
+}
+
+// NewANM creates a new ANM.
+func NewANM(v Variant, cic uint16, opts ...*Parameter) *ANM {
+	return &ANM{
+		Header:   newHeader(v, cic, MsgTypeANM),
+		Optional: opts,
+	}
+}
+
+var anmLayout = layout{optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *ANM) parts() ([]byte, [][]byte, error) {
+	return nil, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from an ANM.
+func (m *ANM) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *ANM) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return anmLayout.marshalTo(b, m.Header, MsgTypeANM, fixed, variable, m.Optional)
+}
+
+// ParseANM decodes given byte sequence as an ANM in the variant.
+func ParseANM(b []byte, v Variant) (*ANM, error) {
+	m := &ANM{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an ANM.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *ANM) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	_, _, opts, err := anmLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of ANM.
+func (m *ANM) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return anmLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *ANM) MessageType() MsgType {
+	return MsgTypeANM
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *ANM) MessageTypeName() string {
+	return MsgTypeANM.String()
+}
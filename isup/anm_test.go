@@ -0,0 +1,31 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestANM(t *testing.T) {
+	cases := []testCase{
+		{
+			"itu",
+			VariantITU,
+			NewANM(VariantITU, 0x0123),
+			[]byte{0x23, 0x01, 0x09, 0x00},
+		}, {
+			"ansi",
+			VariantANSI,
+			NewANM(VariantANSI, 0x3123),
+			[]byte{0x23, 0x31, 0x09, 0x00},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseANM(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
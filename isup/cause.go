@@ -0,0 +1,148 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// Coding Standard definitions.
+const (
+	CodingStandardITU      uint8 = 0
+	CodingStandardISO      uint8 = 1
+	CodingStandardNational uint8 = 2
+	CodingStandardNetwork  uint8 = 3
+)
+
+// Location definitions.
+const (
+	LocationUser                     uint8 = 0
+	LocationPrivateNetworkLocalUser  uint8 = 1
+	LocationPublicNetworkLocalUser   uint8 = 2
+	LocationTransitNetwork           uint8 = 3
+	LocationPublicNetworkRemoteUser  uint8 = 4
+	LocationPrivateNetworkRemoteUser uint8 = 5
+	LocationInternationalNetwork     uint8 = 7
+	LocationBeyondInterworkingPoint  uint8 = 10
+)
+
+// Cause Value definitions, which are the commonly used ones.
+//
+// Spec: ITU-T Q.850.
+const (
+	CauseUnallocatedNumber            uint8 = 1
+	CauseNoRouteToDestination         uint8 = 3
+	CauseNormalCallClearing           uint8 = 16
+	CauseUserBusy                     uint8 = 17
+	CauseNoUserResponding             uint8 = 18
+	CauseNoAnswer                     uint8 = 19
+	CauseCallRejected                 uint8 = 21
+	CauseNumberChanged                uint8 = 22
+	CauseDestinationOutOfOrder        uint8 = 27
+	CauseInvalidNumberFormat          uint8 = 28
+	CauseNormalUnspecified            uint8 = 31
+	CauseNoCircuitAvailable           uint8 = 34
+	CauseNetworkOutOfOrder            uint8 = 38
+	CauseTemporaryFailure             uint8 = 41
+	CauseSwitchingEquipmentCongestion uint8 = 42
+	CauseResourceUnavailable          uint8 = 47
+	CauseBearerCapNotAvailable        uint8 = 58
+	CauseServiceNotImplemented        uint8 = 79
+	CauseRecoveryOnTimerExpiry        uint8 = 102
+	CauseInterworkingUnspecified      uint8 = 127
+)
+
+// CauseIndicators is the Cause Indicators.
+//
+// Spec: 3.12, ITU-T Q.763 and ITU-T Q.850.
+type CauseIndicators struct {
+	CodingStandard uint8
+	Location       uint8
+	// Recommendation is present if HasRecommendation is true, which is not
+	// used in the ITU-T standard coding.
+	HasRecommendation bool
+	Recommendation    uint8
+	CauseValue        uint8
+	Diagnostic        []byte
+}
+
+// NewCauseIndicators creates a new CauseIndicators in the ITU-T standard coding.
+func NewCauseIndicators(location, cause uint8, diag []byte) *CauseIndicators {
+	return &CauseIndicators{
+		CodingStandard: CodingStandardITU,
+		Location:       location,
+		CauseValue:     cause,
+		Diagnostic:     diag,
+	}
+}
+
+// ParseCauseIndicators decodes given byte sequence as a CauseIndicators.
+func ParseCauseIndicators(b []byte) (*CauseIndicators, error) {
+	c := &CauseIndicators{}
+	if err := c.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a CauseIndicators.
+func (c *CauseIndicators) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return ErrTooShortToParse
+	}
+	c.CodingStandard = (b[0] >> 5) & 0x03
+	c.Location = b[0] & 0x0f
+
+	offset := 1
+	c.HasRecommendation = b[0]&0x80 == 0
+	if c.HasRecommendation {
+		if len(b) < 3 {
+			return ErrTooShortToParse
+		}
+		c.Recommendation = b[1] & 0x7f
+		offset++
+	}
+	c.CauseValue = b[offset] & 0x7f
+	c.Diagnostic = b[offset+1:]
+	return nil
+}
+
+// MarshalBinary returns the byte sequence generated from a CauseIndicators.
+func (c *CauseIndicators) MarshalBinary() ([]byte, error) {
+	b := make([]byte, c.MarshalLen())
+	if err := c.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (c *CauseIndicators) MarshalTo(b []byte) error {
+	if len(b) < c.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	b[0] = 0x80 | (c.CodingStandard&0x03)<<5 | c.Location&0x0f
+	offset := 1
+	if c.HasRecommendation {
+		b[0] &^= 0x80
+		b[1] = 0x80 | c.Recommendation&0x7f
+		offset++
+	}
+	b[offset] = 0x80 | c.CauseValue&0x7f
+	copy(b[offset+1:], c.Diagnostic)
+	return nil
+}
+
+// MarshalLen returns the serial length of CauseIndicators.
+func (c *CauseIndicators) MarshalLen() int {
+	l := 2 + len(c.Diagnostic)
+	if c.HasRecommendation {
+		l++
+	}
+	return l
+}
+
+// Parameter returns the CauseIndicators as the optional Parameter.
+func (c *CauseIndicators) Parameter() *Parameter {
+	b, _ := c.MarshalBinary()
+	return NewParameter(ParamCauseIndicators, b)
+}
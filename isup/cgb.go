@@ -0,0 +1,98 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// CGB is a Circuit Group Blocking message.
+//
+// Spec: ITU-T Q.763.
+type CGB struct {
+	*Header
+	CircuitGroupSupervisionType uint8
+	RangeAndStatus              *RangeAndStatus
+}
+
+// NewCGB creates a new CGB.
+func NewCGB(v Variant, cic uint16, typ uint8, rs *RangeAndStatus) *CGB {
+	return &CGB{
+		Header:                      newHeader(v, cic, MsgTypeCGB),
+		CircuitGroupSupervisionType: typ,
+		RangeAndStatus:              rs,
+	}
+}
+
+var cgbLayout = layout{fixed: 1, variable: 1}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *CGB) parts() ([]byte, [][]byte, error) {
+	if m.RangeAndStatus == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	rs, err := m.RangeAndStatus.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte{m.CircuitGroupSupervisionType}, [][]byte{rs}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a CGB.
+func (m *CGB) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *CGB) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return cgbLayout.marshalTo(b, m.Header, MsgTypeCGB, fixed, variable, nil)
+}
+
+// ParseCGB decodes given byte sequence as a CGB in the variant.
+func ParseCGB(b []byte, v Variant) (*CGB, error) {
+	m := &CGB{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a CGB.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *CGB) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, variable, _, err := cgbLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.CircuitGroupSupervisionType = fixed[0]
+	m.RangeAndStatus, err = ParseRangeAndStatus(variable[0])
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of CGB.
+func (m *CGB) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return cgbLayout.marshalLen(variable, nil)
+}
+
+// MessageType returns the Message Type.
+func (m *CGB) MessageType() MsgType {
+	return MsgTypeCGB
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *CGB) MessageTypeName() string {
+	return MsgTypeCGB.String()
+}
@@ -0,0 +1,35 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestCGB(t *testing.T) {
+	cases := []testCase{
+		{
+			"maintenance",
+			VariantITU,
+			NewCGB(VariantITU, 0x0123, SupervisionMaintenance, NewRangeAndStatus(9, 0, 1, 8)),
+			[]byte{
+				// CIC, Type
+				0x23, 0x01, 0x18,
+				// Circuit Group Supervision Message Type
+				0x00,
+				// Pointer
+				0x01,
+				// Range and Status
+				0x03, 0x09, 0x03, 0x01,
+			},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseCGB(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
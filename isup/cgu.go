@@ -0,0 +1,98 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// CGU is a Circuit Group Unblocking message.
+//
+// Spec: ITU-T Q.763.
+type CGU struct {
+	*Header
+	CircuitGroupSupervisionType uint8
+	RangeAndStatus              *RangeAndStatus
+}
+
+// NewCGU creates a new CGU.
+func NewCGU(v Variant, cic uint16, typ uint8, rs *RangeAndStatus) *CGU {
+	return &CGU{
+		Header:                      newHeader(v, cic, MsgTypeCGU),
+		CircuitGroupSupervisionType: typ,
+		RangeAndStatus:              rs,
+	}
+}
+
+var cguLayout = layout{fixed: 1, variable: 1}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *CGU) parts() ([]byte, [][]byte, error) {
+	if m.RangeAndStatus == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	rs, err := m.RangeAndStatus.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte{m.CircuitGroupSupervisionType}, [][]byte{rs}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a CGU.
+func (m *CGU) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *CGU) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return cguLayout.marshalTo(b, m.Header, MsgTypeCGU, fixed, variable, nil)
+}
+
+// ParseCGU decodes given byte sequence as a CGU in the variant.
+func ParseCGU(b []byte, v Variant) (*CGU, error) {
+	m := &CGU{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a CGU.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *CGU) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, variable, _, err := cguLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.CircuitGroupSupervisionType = fixed[0]
+	m.RangeAndStatus, err = ParseRangeAndStatus(variable[0])
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of CGU.
+func (m *CGU) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return cguLayout.marshalLen(variable, nil)
+}
+
+// MessageType returns the Message Type.
+func (m *CGU) MessageType() MsgType {
+	return MsgTypeCGU
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *CGU) MessageTypeName() string {
+	return MsgTypeCGU.String()
+}
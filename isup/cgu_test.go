@@ -0,0 +1,26 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestCGU(t *testing.T) {
+	cases := []testCase{
+		{
+			"hardware",
+			VariantANSI,
+			NewCGU(VariantANSI, 0x3123, SupervisionHardware, NewRangeAndStatus(7, 0, 1, 2, 3, 4, 5, 6, 7)),
+			[]byte{0x23, 0x31, 0x19, 0x01, 0x01, 0x02, 0x07, 0xff},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseCGU(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// CPG is a Call Progress message.
+//
+// Spec: ITU-T Q.763.
+type CPG struct {
+	*Header
+	EventInformation uint8
+	Optional         Parameters
+}
+
+// NewCPG creates a new CPG.
+func NewCPG(v Variant, cic uint16, event uint8, opts ...*Parameter) *CPG {
+	return &CPG{
+		Header:           newHeader(v, cic, MsgTypeCPG),
+		EventInformation: event,
+		Optional:         opts,
+	}
+}
+
+var cpgLayout = layout{fixed: 1, optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *CPG) parts() ([]byte, [][]byte, error) {
+	return []byte{m.EventInformation}, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a CPG.
+func (m *CPG) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *CPG) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return cpgLayout.marshalTo(b, m.Header, MsgTypeCPG, fixed, variable, m.Optional)
+}
+
+// ParseCPG decodes given byte sequence as a CPG in the variant.
+func ParseCPG(b []byte, v Variant) (*CPG, error) {
+	m := &CPG{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a CPG.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *CPG) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, _, opts, err := cpgLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.EventInformation = fixed[0]
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of CPG.
+func (m *CPG) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return cpgLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *CPG) MessageType() MsgType {
+	return MsgTypeCPG
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *CPG) MessageTypeName() string {
+	return MsgTypeCPG.String()
+}
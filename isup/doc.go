@@ -0,0 +1,23 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+/*
+Package isup provides encoding/decoding feature of the ISUP messages, which
+are carried as the MTP3 user payload in M3UA DATA with SI=5 (ISUP).
+
+The payload is the ISUP message after the routing label, which starts with
+the Circuit Identification Code, as in ProtocolDataPayload.Data returned by
+m3ua.Conn.ReadPD. It can be decoded with ParsePD.
+
+The common call control and circuit group supervision messages are supported
+with the typed mandatory parameters. The optional parameters are kept as
+Parameter, and the typed ones such as Calling Party Number can be decoded
+from the Value.
+
+Both of the ITU-T and ANSI variants are supported. The differences are the
+length of the CIC and the mandatory parameters in IAM.
+
+Specification: ITU-T Q.763, ANSI T1.113
+*/
+package isup
@@ -0,0 +1,65 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import (
+	"errors"
+	"testing"
+)
+
+func fuzzSeeds() []Message {
+	cause := NewCauseIndicators(LocationPublicNetworkLocalUser, CauseNormalCallClearing, nil)
+	cgpn := testCgPN().Parameter(ParamCallingPartyNumber)
+	return []Message{
+		NewIAM(1, 0x00, 0x6020, 0x0a, 0x00, testCdPN(), cgpn),
+		NewIAMANSI(1, 0x00, 0x6020, 0x0a, []byte{0x80, 0x90, 0xa2}, testCdPN(), cgpn),
+		NewACM(VariantITU, 1, 0x1416),
+		NewANM(VariantITU, 1),
+		NewREL(VariantITU, 1, cause, cause.Parameter()),
+		NewRLC(VariantITU, 1),
+		NewCPG(VariantITU, 1, 0x01),
+		NewSUS(VariantITU, 1, 0x00),
+		NewRES(VariantITU, 1, 0x00),
+		NewGRS(VariantITU, 1, &RangeAndStatus{Range: 7}),
+		NewGRA(VariantITU, 1, NewRangeAndStatus(7, 0)),
+		NewCGB(VariantITU, 1, SupervisionMaintenance, NewRangeAndStatus(7, 0)),
+		NewCGU(VariantITU, 1, SupervisionMaintenance, NewRangeAndStatus(7, 0)),
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, m := range fuzzSeeds() {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b, false)
+		f.Add(b, true)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte, ansi bool) {
+		v := VariantITU
+		if ansi {
+			v = VariantANSI
+		}
+		m, err := Parse(b, v)
+		if err != nil {
+			return
+		}
+
+		// anything decoded successfully should be encoded and decoded again,
+		// unless the parts overlapping in b are too long to be laid out.
+		encoded, err := m.MarshalBinary()
+		if errors.Is(err, ErrTooLong) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to encode decoded message: %v", err)
+		}
+		if _, err := Parse(encoded, v); err != nil {
+			t.Fatalf("failed to decode encoded message %x: %v", encoded, err)
+		}
+	})
+}
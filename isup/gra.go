@@ -0,0 +1,95 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// GRA is a Circuit Group Reset Acknowledgement message.
+//
+// Spec: ITU-T Q.763.
+type GRA struct {
+	*Header
+	RangeAndStatus *RangeAndStatus
+}
+
+// NewGRA creates a new GRA.
+func NewGRA(v Variant, cic uint16, rs *RangeAndStatus) *GRA {
+	return &GRA{
+		Header:         newHeader(v, cic, MsgTypeGRA),
+		RangeAndStatus: rs,
+	}
+}
+
+var graLayout = layout{variable: 1}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *GRA) parts() ([]byte, [][]byte, error) {
+	if m.RangeAndStatus == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	rs, err := m.RangeAndStatus.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, [][]byte{rs}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a GRA.
+func (m *GRA) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *GRA) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return graLayout.marshalTo(b, m.Header, MsgTypeGRA, fixed, variable, nil)
+}
+
+// ParseGRA decodes given byte sequence as a GRA in the variant.
+func ParseGRA(b []byte, v Variant) (*GRA, error) {
+	m := &GRA{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a GRA.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *GRA) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	_, variable, _, err := graLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.RangeAndStatus, err = ParseRangeAndStatus(variable[0])
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of GRA.
+func (m *GRA) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return graLayout.marshalLen(variable, nil)
+}
+
+// MessageType returns the Message Type.
+func (m *GRA) MessageType() MsgType {
+	return MsgTypeGRA
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *GRA) MessageTypeName() string {
+	return MsgTypeGRA.String()
+}
@@ -0,0 +1,26 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestGRA(t *testing.T) {
+	cases := []testCase{
+		{
+			"two-blocked",
+			VariantITU,
+			NewGRA(VariantITU, 0x0123, NewRangeAndStatus(7, 0, 2)),
+			[]byte{0x23, 0x01, 0x29, 0x01, 0x02, 0x07, 0x05},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseGRA(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
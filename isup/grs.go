@@ -0,0 +1,95 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// GRS is a Circuit Group Reset message.
+//
+// Spec: ITU-T Q.763.
+type GRS struct {
+	*Header
+	RangeAndStatus *RangeAndStatus
+}
+
+// NewGRS creates a new GRS.
+func NewGRS(v Variant, cic uint16, rs *RangeAndStatus) *GRS {
+	return &GRS{
+		Header:         newHeader(v, cic, MsgTypeGRS),
+		RangeAndStatus: rs,
+	}
+}
+
+var grsLayout = layout{variable: 1}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *GRS) parts() ([]byte, [][]byte, error) {
+	if m.RangeAndStatus == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	rs, err := m.RangeAndStatus.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, [][]byte{rs}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a GRS.
+func (m *GRS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *GRS) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return grsLayout.marshalTo(b, m.Header, MsgTypeGRS, fixed, variable, nil)
+}
+
+// ParseGRS decodes given byte sequence as a GRS in the variant.
+func ParseGRS(b []byte, v Variant) (*GRS, error) {
+	m := &GRS{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a GRS.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *GRS) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	_, variable, _, err := grsLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.RangeAndStatus, err = ParseRangeAndStatus(variable[0])
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of GRS.
+func (m *GRS) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return grsLayout.marshalLen(variable, nil)
+}
+
+// MessageType returns the Message Type.
+func (m *GRS) MessageType() MsgType {
+	return MsgTypeGRS
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *GRS) MessageTypeName() string {
+	return MsgTypeGRS.String()
+}
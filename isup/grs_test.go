@@ -0,0 +1,33 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestGRS(t *testing.T) {
+	cases := []testCase{
+		{
+			"range-only",
+			VariantITU,
+			NewGRS(VariantITU, 0x0123, &RangeAndStatus{Range: 7}),
+			[]byte{
+				// CIC, Type
+				0x23, 0x01, 0x17,
+				// Pointer
+				0x01,
+				// Range and Status
+				0x01, 0x07,
+			},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseGRS(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
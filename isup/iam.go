@@ -0,0 +1,149 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// IAM is an Initial Address message.
+//
+// The mandatory fixed part differs in the variants; Transmission Medium
+// Requirement is used only in ITU-T, and User Service Information is used
+// only in ANSI as the mandatory variable parameter.
+//
+// Spec: ITU-T Q.763 and ANSI T1.113.
+type IAM struct {
+	*Header
+	NatureOfConnectionIndicators  uint8
+	ForwardCallIndicators         uint16
+	CallingPartyCategory          uint8
+	TransmissionMediumRequirement uint8
+	UserServiceInformation        []byte
+	CalledPartyNumber             *PartyNumber
+	Optional                      Parameters
+}
+
+// NewIAM creates a new IAM in ITU-T variant.
+func NewIAM(cic uint16, nci uint8, fci uint16, cpc, tmr uint8, cdpn *PartyNumber, opts ...*Parameter) *IAM {
+	return &IAM{
+		Header:                        newHeader(VariantITU, cic, MsgTypeIAM),
+		NatureOfConnectionIndicators:  nci,
+		ForwardCallIndicators:         fci,
+		CallingPartyCategory:          cpc,
+		TransmissionMediumRequirement: tmr,
+		CalledPartyNumber:             cdpn,
+		Optional:                      opts,
+	}
+}
+
+// NewIAMANSI creates a new IAM in ANSI variant.
+func NewIAMANSI(cic uint16, nci uint8, fci uint16, cpc uint8, usi []byte, cdpn *PartyNumber, opts ...*Parameter) *IAM {
+	return &IAM{
+		Header:                       newHeader(VariantANSI, cic, MsgTypeIAM),
+		NatureOfConnectionIndicators: nci,
+		ForwardCallIndicators:        fci,
+		CallingPartyCategory:         cpc,
+		UserServiceInformation:       usi,
+		CalledPartyNumber:            cdpn,
+		Optional:                     opts,
+	}
+}
+
+// layout returns the layout of IAM in the variant of the message.
+func (m *IAM) layout() layout {
+	if m.Header != nil && m.Variant == VariantANSI {
+		return layout{fixed: 4, variable: 2, optional: true}
+	}
+	return layout{fixed: 5, variable: 1, optional: true}
+}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *IAM) parts() ([]byte, [][]byte, error) {
+	if m.CalledPartyNumber == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	cdpn, err := m.CalledPartyNumber.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixed := []byte{m.NatureOfConnectionIndicators, 0, 0, m.CallingPartyCategory}
+	putUint16(fixed[1:3], m.ForwardCallIndicators)
+	if m.layout().variable == 2 {
+		return fixed, [][]byte{m.UserServiceInformation, cdpn}, nil
+	}
+	return append(fixed, m.TransmissionMediumRequirement), [][]byte{cdpn}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from an IAM.
+func (m *IAM) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *IAM) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return m.layout().marshalTo(b, m.Header, MsgTypeIAM, fixed, variable, m.Optional)
+}
+
+// ParseIAM decodes given byte sequence as an IAM in the variant.
+func ParseIAM(b []byte, v Variant) (*IAM, error) {
+	m := &IAM{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an IAM.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *IAM) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	l := m.layout()
+	fixed, variable, opts, err := l.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+
+	m.NatureOfConnectionIndicators = fixed[0]
+	m.ForwardCallIndicators = uint16From(fixed[1:3])
+	m.CallingPartyCategory = fixed[3]
+	m.TransmissionMediumRequirement = 0
+	m.UserServiceInformation = nil
+	if l.variable == 2 {
+		m.UserServiceInformation = variable[0]
+	} else {
+		m.TransmissionMediumRequirement = fixed[4]
+	}
+
+	m.CalledPartyNumber, err = ParsePartyNumber(variable[l.variable-1])
+	if err != nil {
+		return err
+	}
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of IAM.
+func (m *IAM) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return m.layout().marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *IAM) MessageType() MsgType {
+	return MsgTypeIAM
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *IAM) MessageTypeName() string {
+	return MsgTypeIAM.String()
+}
@@ -0,0 +1,62 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestIAM(t *testing.T) {
+	cases := []testCase{
+		{
+			"itu",
+			VariantITU,
+			NewIAM(0x0123, 0x00, 0x6020, 0x0a, 0x00, testCdPN(), testCgPN().Parameter(ParamCallingPartyNumber)),
+			concat(
+				// CIC, Type
+				[]byte{0x23, 0x01, 0x01},
+				// NCI, FCI, CPC, TMR
+				[]byte{0x00, 0x20, 0x60, 0x0a, 0x00},
+				// Pointers
+				[]byte{0x02, 0x0a},
+				[]byte{0x08}, testCdPNBytes,
+				[]byte{0x0a, 0x07}, testCgPNBytes,
+				[]byte{0x00},
+			),
+		}, {
+			"ansi",
+			VariantANSI,
+			NewIAMANSI(0x3123, 0x00, 0x6020, 0x0a, []byte{0x80, 0x90, 0xa2}, testCdPN(), testCgPN().Parameter(ParamCallingPartyNumber)),
+			concat(
+				// CIC, Type
+				[]byte{0x23, 0x31, 0x01},
+				// NCI, FCI, CPC
+				[]byte{0x00, 0x20, 0x60, 0x0a},
+				// Pointers
+				[]byte{0x03, 0x06, 0x0e},
+				[]byte{0x03, 0x80, 0x90, 0xa2},
+				[]byte{0x08}, testCdPNBytes,
+				[]byte{0x0a, 0x07}, testCgPNBytes,
+				[]byte{0x00},
+			),
+		}, {
+			"no-optional",
+			VariantITU,
+			NewIAM(0x0123, 0x00, 0x6020, 0x0a, 0x00, testCdPN()),
+			concat(
+				[]byte{0x23, 0x01, 0x01},
+				[]byte{0x00, 0x20, 0x60, 0x0a, 0x00},
+				[]byte{0x02, 0x00},
+				[]byte{0x08}, testCdPNBytes,
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseIAM(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
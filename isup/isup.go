@@ -0,0 +1,339 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// Variant is the variant of ISUP.
+type Variant uint8
+
+// Variant definitions.
+const (
+	VariantITU Variant = iota
+	VariantANSI
+)
+
+// String returns the name of Variant.
+func (v Variant) String() string {
+	switch v {
+	case VariantITU:
+		return "ITU"
+	case VariantANSI:
+		return "ANSI"
+	default:
+		return fmt.Sprintf("Unknown (%d)", uint8(v))
+	}
+}
+
+// cicMask returns the bits of CIC used in the variant.
+func (v Variant) cicMask() uint16 {
+	if v == VariantANSI {
+		return 0x3fff
+	}
+	return 0x0fff
+}
+
+// MsgType is the Message Type of ISUP.
+type MsgType uint8
+
+// Message Type definitions.
+const (
+	MsgTypeIAM MsgType = 0x01
+	MsgTypeACM MsgType = 0x06
+	MsgTypeANM MsgType = 0x09
+	MsgTypeREL MsgType = 0x0c
+	MsgTypeSUS MsgType = 0x0d
+	MsgTypeRES MsgType = 0x0e
+	MsgTypeRLC MsgType = 0x10
+	MsgTypeGRS MsgType = 0x17
+	MsgTypeCGB MsgType = 0x18
+	MsgTypeCGU MsgType = 0x19
+	MsgTypeGRA MsgType = 0x29
+	MsgTypeCPG MsgType = 0x2c
+)
+
+var msgTypeNames = map[MsgType]string{
+	MsgTypeIAM: "IAM",
+	MsgTypeACM: "ACM",
+	MsgTypeANM: "ANM",
+	MsgTypeREL: "REL",
+	MsgTypeSUS: "SUS",
+	MsgTypeRES: "RES",
+	MsgTypeRLC: "RLC",
+	MsgTypeGRS: "GRS",
+	MsgTypeCGB: "CGB",
+	MsgTypeCGU: "CGU",
+	MsgTypeGRA: "GRA",
+	MsgTypeCPG: "CPG",
+}
+
+// String returns the name of MsgType.
+func (t MsgType) String() string {
+	if name, ok := msgTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", uint8(t))
+}
+
+// Error definitions.
+var (
+	ErrTooShortToMarshalBinary = errors.New("insufficient buffer to serialize ISUP to")
+	ErrTooShortToParse         = errors.New("too short to decode as ISUP")
+	ErrInvalidPointer          = errors.New("message has invalid pointer value")
+	ErrInvalidLength           = errors.New("message has invalid length value")
+	ErrTooLong                 = errors.New("parameter is too long to be pointed")
+	ErrMissingParameter        = errors.New("mandatory parameter is missing")
+	ErrNotISUP                 = errors.New("service indicator is not ISUP")
+)
+
+// UnsupportedTypeError is used if a message with an unsupported Message Type
+// is given to Parse.
+type UnsupportedTypeError struct {
+	Type MsgType
+}
+
+// Error returns error string with the Message Type.
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported message type: %s", e.Type)
+}
+
+// Message is an interface that defines ISUP messages.
+type Message interface {
+	MarshalBinary() ([]byte, error)
+	MarshalTo([]byte) error
+	MarshalLen() int
+	UnmarshalBinary([]byte) error
+	MessageType() MsgType
+	MessageTypeName() string
+	CircuitID() uint16
+}
+
+// Parse decodes the given bytes as an ISUP message in the variant.
+// The values in the message returned refer to b, which should not be
+// modified while the message is in use.
+func Parse(b []byte, v Variant) (Message, error) {
+	if len(b) < 3 {
+		return nil, ErrTooShortToParse
+	}
+
+	var m Message
+	h := &Header{Variant: v}
+	switch MsgType(b[2]) {
+	case MsgTypeIAM:
+		m = &IAM{Header: h}
+	case MsgTypeACM:
+		m = &ACM{Header: h}
+	case MsgTypeANM:
+		m = &ANM{Header: h}
+	case MsgTypeREL:
+		m = &REL{Header: h}
+	case MsgTypeSUS:
+		m = &SUS{Header: h}
+	case MsgTypeRES:
+		m = &RES{Header: h}
+	case MsgTypeRLC:
+		m = &RLC{Header: h}
+	case MsgTypeGRS:
+		m = &GRS{Header: h}
+	case MsgTypeCGB:
+		m = &CGB{Header: h}
+	case MsgTypeCGU:
+		m = &CGU{Header: h}
+	case MsgTypeGRA:
+		m = &GRA{Header: h}
+	case MsgTypeCPG:
+		m = &CPG{Header: h}
+	default:
+		return nil, &UnsupportedTypeError{Type: MsgType(b[2])}
+	}
+
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParsePD decodes the Data in ProtocolDataPayload as an ISUP message.
+// It returns ErrNotISUP if the Service Indicator is not ISUP.
+func ParsePD(pd *params.ProtocolDataPayload, v Variant) (Message, error) {
+	if pd.ServiceIndicator != params.ServiceIndISUP {
+		return nil, ErrNotISUP
+	}
+	return Parse(pd.Data, v)
+}
+
+// Header is the part common in all the ISUP messages.
+type Header struct {
+	// Variant is the variant of the message, which determines the length of
+	// CIC and the parameters in some messages.
+	Variant Variant
+	// CIC is the Circuit Identification Code.
+	CIC  uint16
+	Type MsgType
+}
+
+// newHeader creates a new Header.
+func newHeader(v Variant, cic uint16, t MsgType) *Header {
+	return &Header{Variant: v, CIC: cic, Type: t}
+}
+
+const headerLen = 3
+
+// CircuitID returns the Circuit Identification Code.
+func (h *Header) CircuitID() uint16 {
+	if h == nil {
+		return 0
+	}
+	return h.CIC
+}
+
+func (h *Header) marshalTo(b []byte, t MsgType) {
+	var cic uint16
+	if h != nil {
+		cic = h.CIC & h.Variant.cicMask()
+	}
+	putUint16(b, cic)
+	b[2] = uint8(t)
+}
+
+func (h *Header) unmarshal(b []byte) error {
+	if len(b) < headerLen {
+		return ErrTooShortToParse
+	}
+	h.CIC = uint16From(b) & h.Variant.cicMask()
+	h.Type = MsgType(b[2])
+	return nil
+}
+
+// layout is the structure of the message after the header.
+type layout struct {
+	// fixed is the length of the mandatory fixed part.
+	fixed int
+	// variable is the number of the mandatory variable parameters.
+	variable int
+	// optional is whether the message has the optional part.
+	optional bool
+}
+
+func (l layout) marshalLen(variable [][]byte, opts Parameters) int {
+	n := headerLen + l.fixed + l.variable
+	for _, v := range variable {
+		n += 1 + len(v)
+	}
+	if l.optional {
+		n++
+		if len(opts) > 0 {
+			n += opts.marshalLen() + 1
+		}
+	}
+	return n
+}
+
+// marshalTo puts the header, the mandatory fixed part, the pointers, the
+// mandatory variable parameters and the optional part.
+func (l layout) marshalTo(b []byte, h *Header, t MsgType, fixed []byte, variable [][]byte, opts Parameters) error {
+	if len(b) < l.marshalLen(variable, opts) {
+		return ErrTooShortToMarshalBinary
+	}
+	if len(fixed) != l.fixed || len(variable) != l.variable {
+		return ErrMissingParameter
+	}
+
+	h.marshalTo(b, t)
+	copy(b[headerLen:], fixed)
+
+	ptrs := headerLen + l.fixed
+	offset := ptrs + l.variable
+	if l.optional {
+		offset++
+	}
+	for i, v := range variable {
+		if offset-(ptrs+i) > 0xff || len(v) > 0xff {
+			return ErrTooLong
+		}
+		b[ptrs+i] = uint8(offset - (ptrs + i))
+		b[offset] = uint8(len(v))
+		offset++
+		offset += copy(b[offset:], v)
+	}
+
+	if !l.optional {
+		return nil
+	}
+	i := ptrs + l.variable
+	if len(opts) == 0 {
+		b[i] = 0
+		return nil
+	}
+	if offset-i > 0xff {
+		return ErrTooLong
+	}
+	b[i] = uint8(offset - i)
+	return opts.marshalTo(b[offset:])
+}
+
+// parse decodes the parts after the header. The optional parameters are nil
+// if the optional part is absent.
+func (l layout) parse(b []byte, h *Header) (fixed []byte, variable [][]byte, opts Parameters, err error) {
+	if err := h.unmarshal(b); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ptrs := headerLen + l.fixed
+	nptr := l.variable
+	if l.optional {
+		nptr++
+	}
+	if len(b) < ptrs+nptr {
+		return nil, nil, nil, ErrTooShortToParse
+	}
+	fixed = b[headerLen:ptrs]
+
+	if l.variable > 0 {
+		variable = make([][]byte, l.variable)
+	}
+	for i := range variable {
+		pos := ptrs + i + int(b[ptrs+i])
+		if pos < ptrs+nptr || pos >= len(b) {
+			return nil, nil, nil, ErrInvalidPointer
+		}
+		n := int(b[pos])
+		if pos+1+n > len(b) {
+			return nil, nil, nil, ErrInvalidLength
+		}
+		variable[i] = b[pos+1 : pos+1+n]
+	}
+
+	if !l.optional {
+		return fixed, variable, nil, nil
+	}
+	i := ptrs + l.variable
+	if b[i] == 0 {
+		return fixed, variable, nil, nil
+	}
+	pos := i + int(b[i])
+	if pos < ptrs+nptr || pos >= len(b) {
+		return nil, nil, nil, ErrInvalidPointer
+	}
+	opts, err = parseParameters(b[pos:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return fixed, variable, opts, nil
+}
+
+func putUint16(b []byte, v uint16) {
+	b[0] = uint8(v)
+	b[1] = uint8(v >> 8)
+}
+
+func uint16From(b []byte) uint16 {
+	return uint16(b[0]) | uint16(b[1])<<8
+}
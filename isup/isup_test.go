@@ -0,0 +1,172 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pascaldekloe/goe/verify"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestParsePD(t *testing.T) {
+	data := []byte{0x23, 0x01, 0x0c, 0x02, 0x00, 0x02, 0x82, 0x90}
+
+	pd := params.NewProtocolDataPayload(1, 2, params.ServiceIndISUP, 0, 0, 5, data)
+	m, err := ParsePD(pd, VariantITU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, ok := m.(*REL)
+	if !ok {
+		t.Fatalf("got %T, want *REL", m)
+	}
+	if rel.CIC != 0x0123 || rel.CauseIndicators.CauseValue != CauseNormalCallClearing {
+		t.Errorf("unexpected REL: %+v", rel)
+	}
+
+	pd = params.NewProtocolDataPayload(1, 2, params.ServiceIndSCCP, 0, 0, 5, data)
+	if _, err := ParsePD(pd, VariantITU); !errors.Is(err, ErrNotISUP) {
+		t.Errorf("got %v, want %v", err, ErrNotISUP)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var unsupported *UnsupportedTypeError
+	if _, err := Parse([]byte{0x23, 0x01, 0x02}, VariantITU); !errors.As(err, &unsupported) {
+		t.Errorf("got %v, want UnsupportedTypeError", err)
+	}
+
+	for _, c := range []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{"short", []byte{0x23, 0x01}, ErrTooShortToParse},
+		{"no-pointer", []byte{0x23, 0x01, 0x0c}, ErrTooShortToParse},
+		{"pointer-beyond", []byte{0x23, 0x01, 0x0c, 0x08, 0x00}, ErrInvalidPointer},
+		{"length-beyond", []byte{0x23, 0x01, 0x0c, 0x02, 0x00, 0x03, 0x82, 0x90}, ErrInvalidLength},
+		{"no-end-of-optional", []byte{0x23, 0x01, 0x09, 0x01, 0x29, 0x01, 0x01}, ErrTooShortToParse},
+	} {
+		if _, err := Parse(c.b, VariantITU); !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+
+	if _, err := NewREL(VariantITU, 1, nil).MarshalBinary(); !errors.Is(err, ErrMissingParameter) {
+		t.Errorf("got %v, want %v", err, ErrMissingParameter)
+	}
+}
+
+func TestCIC(t *testing.T) {
+	// the bits beyond the CIC in the variant are spare.
+	b := []byte{0xff, 0xff, 0x09, 0x00}
+	for v, want := range map[Variant]uint16{VariantITU: 0x0fff, VariantANSI: 0x3fff} {
+		m, err := Parse(b, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.CircuitID(); got != want {
+			t.Errorf("%s: got %#x, want %#x", v, got, want)
+		}
+	}
+
+	enc, err := NewANM(VariantITU, 0xffff).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := enc, []byte{0xff, 0x0f, 0x09, 0x00}; !verify.Values(t, "", got, want) {
+		t.Fail()
+	}
+}
+
+func TestParameters(t *testing.T) {
+	cause := NewCauseIndicators(LocationUser, CauseNoAnswer, []byte{0x01})
+	ps := Parameters{testCgPN().Parameter(ParamCallingPartyNumber), cause.Parameter()}
+
+	cgpn, err := ps.CallingPartyNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cgpn.Digits(), "0312345678"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if cgpn.Screening != ScreeningNetworkProvided {
+		t.Errorf("got screening %d", cgpn.Screening)
+	}
+
+	got, err := ps.CauseIndicators()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verify.Values(t, "", got, cause) {
+		t.Fail()
+	}
+
+	if ps.Get(ParamGenericNumber) != nil {
+		t.Error("unexpected parameter found")
+	}
+	if n, err := (Parameters{}).CallingPartyNumber(); n != nil || err != nil {
+		t.Errorf("got %v, %v", n, err)
+	}
+}
+
+func TestPartyNumber(t *testing.T) {
+	if got, want := testCdPN().Digits(), "81312345678"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// ST is encoded as 'f' at the end of the digits.
+	n, err := NewCalledPartyNumber(NatureUnknown, NumberingPlanISDNTelephony, "1234F")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := n.Address, []byte{0x21, 0x43, 0x0f}; !verify.Values(t, "", got, want) {
+		t.Fail()
+	}
+	if !n.Odd || n.Digits() != "1234f" {
+		t.Errorf("got odd %v, digits %s", n.Odd, n.Digits())
+	}
+
+	if _, err := NewCalledPartyNumber(NatureUnknown, NumberingPlanISDNTelephony, "12*"); !errors.Is(err, ErrInvalidDigits) {
+		t.Errorf("got %v, want %v", err, ErrInvalidDigits)
+	}
+}
+
+func TestCauseIndicators(t *testing.T) {
+	b := []byte{0x04, 0x81, 0x9f}
+	c, err := ParseCauseIndicators(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &CauseIndicators{
+		Location:          LocationPublicNetworkRemoteUser,
+		HasRecommendation: true,
+		Recommendation:    1,
+		CauseValue:        CauseNormalUnspecified,
+		Diagnostic:        []byte{},
+	}
+	if !verify.Values(t, "", c, want) {
+		t.Fail()
+	}
+
+	enc, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verify.Values(t, "", enc, b) {
+		t.Fail()
+	}
+}
+
+func TestRangeAndStatus(t *testing.T) {
+	r := NewRangeAndStatus(9, 0, 8, 10)
+	for i, want := range []bool{true, false, false, false, false, false, false, false, true, false, false} {
+		if got := r.Affected(i); got != want {
+			t.Errorf("circuit %d: got %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import (
+	"errors"
+	"strings"
+)
+
+// Nature of Address Indicator definitions.
+const (
+	NatureSubscriber    uint8 = 1
+	NatureUnknown       uint8 = 2
+	NatureNational      uint8 = 3
+	NatureInternational uint8 = 4
+)
+
+// Numbering Plan Indicator definitions.
+const (
+	NumberingPlanISDNTelephony uint8 = 1
+	NumberingPlanData          uint8 = 3
+	NumberingPlanTelex         uint8 = 4
+)
+
+// Address Presentation Restricted Indicator definitions.
+const (
+	PresentationAllowed      uint8 = 0
+	PresentationRestricted   uint8 = 1
+	PresentationNotAvailable uint8 = 2
+)
+
+// Screening Indicator definitions.
+const (
+	ScreeningUserProvidedNotVerified uint8 = 0
+	ScreeningUserProvidedVerified    uint8 = 1
+	ScreeningUserProvidedFailed      uint8 = 2
+	ScreeningNetworkProvided         uint8 = 3
+)
+
+// ErrInvalidDigits is used if the digits cannot be encoded in BCD.
+var ErrInvalidDigits = errors.New("invalid digits in party number")
+
+// bcdDigits is the characters of the address signals. 'b' and 'c' are code 11
+// and 12, and 'f' is ST (end of pulsing).
+const bcdDigits = "0123456789abcdef"
+
+// PartyNumber is the Called Party Number or the Calling Party Number, and the
+// other number parameters in the same format such as Original Called Number.
+//
+// Spec: 3.9 and 3.10, ITU-T Q.763.
+type PartyNumber struct {
+	Odd             bool
+	NatureOfAddress uint8
+	// Indicator is the Internal Network Number indicator in Called Party
+	// Number, or the Number Incomplete indicator in Calling Party Number.
+	Indicator     bool
+	NumberingPlan uint8
+	// Presentation and Screening are used only in Calling Party Number, and
+	// should be zero in Called Party Number.
+	Presentation uint8
+	Screening    uint8
+	// Address is the address signals encoded in BCD.
+	Address []byte
+}
+
+// NewCalledPartyNumber creates a new PartyNumber as the Called Party Number.
+func NewCalledPartyNumber(nai, np uint8, digits string) (*PartyNumber, error) {
+	n := &PartyNumber{NatureOfAddress: nai, NumberingPlan: np}
+	if err := n.SetDigits(digits); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// NewCallingPartyNumber creates a new PartyNumber as the Calling Party Number.
+func NewCallingPartyNumber(nai, np, presentation, screening uint8, digits string) (*PartyNumber, error) {
+	n := &PartyNumber{
+		NatureOfAddress: nai,
+		NumberingPlan:   np,
+		Presentation:    presentation,
+		Screening:       screening,
+	}
+	if err := n.SetDigits(digits); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ParsePartyNumber decodes given byte sequence as a PartyNumber.
+func ParsePartyNumber(b []byte) (*PartyNumber, error) {
+	n := &PartyNumber{}
+	if err := n.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a PartyNumber.
+func (n *PartyNumber) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return ErrTooShortToParse
+	}
+	n.Odd = b[0]&0x80 != 0
+	n.NatureOfAddress = b[0] & 0x7f
+	n.Indicator = b[1]&0x80 != 0
+	n.NumberingPlan = (b[1] >> 4) & 0x07
+	n.Presentation = (b[1] >> 2) & 0x03
+	n.Screening = b[1] & 0x03
+	n.Address = b[2:]
+	return nil
+}
+
+// MarshalBinary returns the byte sequence generated from a PartyNumber.
+func (n *PartyNumber) MarshalBinary() ([]byte, error) {
+	b := make([]byte, n.MarshalLen())
+	if err := n.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (n *PartyNumber) MarshalTo(b []byte) error {
+	if len(b) < n.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	b[0] = n.NatureOfAddress & 0x7f
+	if n.Odd {
+		b[0] |= 0x80
+	}
+	b[1] = (n.NumberingPlan&0x07)<<4 | (n.Presentation&0x03)<<2 | n.Screening&0x03
+	if n.Indicator {
+		b[1] |= 0x80
+	}
+	copy(b[2:], n.Address)
+	return nil
+}
+
+// MarshalLen returns the serial length of PartyNumber.
+func (n *PartyNumber) MarshalLen() int {
+	return 2 + len(n.Address)
+}
+
+// Parameter returns the PartyNumber as the optional Parameter with the code.
+func (n *PartyNumber) Parameter(code uint8) *Parameter {
+	b, _ := n.MarshalBinary()
+	return NewParameter(code, b)
+}
+
+// Digits returns the address signals as a string.
+func (n *PartyNumber) Digits() string {
+	var sb strings.Builder
+	sb.Grow(len(n.Address) * 2)
+	for i, o := range n.Address {
+		sb.WriteByte(bcdDigits[o&0x0f])
+		if i == len(n.Address)-1 && n.Odd {
+			break
+		}
+		sb.WriteByte(bcdDigits[o>>4])
+	}
+	return sb.String()
+}
+
+// SetDigits sets the address signals encoded in BCD, and the odd/even
+// indicator accordingly.
+func (n *PartyNumber) SetDigits(digits string) error {
+	addr := make([]byte, (len(digits)+1)/2)
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if 'A' <= c && c <= 'F' {
+			c += 'a' - 'A'
+		}
+		d := strings.IndexByte(bcdDigits, c)
+		if d < 0 {
+			return ErrInvalidDigits
+		}
+		if i%2 == 0 {
+			addr[i/2] = uint8(d)
+		} else {
+			addr[i/2] |= uint8(d) << 4
+		}
+	}
+
+	n.Address = addr
+	n.Odd = len(digits)%2 == 1
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// Parameter Name Code definitions.
+//
+// Spec: Table 5, ITU-T Q.763.
+const (
+	ParamEndOfOptionalParameters        uint8 = 0x00
+	ParamCallReference                  uint8 = 0x01
+	ParamTransmissionMediumRequirement  uint8 = 0x02
+	ParamAccessTransport                uint8 = 0x03
+	ParamCalledPartyNumber              uint8 = 0x04
+	ParamSubsequentNumber               uint8 = 0x05
+	ParamNatureOfConnectionIndicators   uint8 = 0x06
+	ParamForwardCallIndicators          uint8 = 0x07
+	ParamOptionalForwardCallIndicators  uint8 = 0x08
+	ParamCallingPartyCategory           uint8 = 0x09
+	ParamCallingPartyNumber             uint8 = 0x0a
+	ParamRedirectingNumber              uint8 = 0x0b
+	ParamRedirectionNumber              uint8 = 0x0c
+	ParamBackwardCallIndicators         uint8 = 0x11
+	ParamCauseIndicators                uint8 = 0x12
+	ParamRedirectionInformation         uint8 = 0x13
+	ParamCircuitGroupSupervisionType    uint8 = 0x15
+	ParamRangeAndStatus                 uint8 = 0x16
+	ParamUserServiceInformation         uint8 = 0x1d
+	ParamUserToUserInformation          uint8 = 0x20
+	ParamSuspendResumeIndicators        uint8 = 0x22
+	ParamEventInformation               uint8 = 0x24
+	ParamOriginalCalledNumber           uint8 = 0x28
+	ParamOptionalBackwardCallIndicators uint8 = 0x29
+	ParamAccessDeliveryInformation      uint8 = 0x2e
+	ParamGenericNumber                  uint8 = 0xc0
+)
+
+// Parameter is an optional parameter in ISUP messages.
+type Parameter struct {
+	Code  uint8
+	Value []byte
+}
+
+// NewParameter creates a new Parameter.
+func NewParameter(code uint8, value []byte) *Parameter {
+	return &Parameter{Code: code, Value: value}
+}
+
+// MarshalLen returns the serial length of Parameter.
+func (p *Parameter) MarshalLen() int {
+	return 2 + len(p.Value)
+}
+
+// Parameters is the list of the optional parameters in ISUP messages.
+type Parameters []*Parameter
+
+// Get returns the first Parameter with the code, or nil if not found.
+func (ps Parameters) Get(code uint8) *Parameter {
+	for _, p := range ps {
+		if p.Code == code {
+			return p
+		}
+	}
+	return nil
+}
+
+// CallingPartyNumber returns the Calling Party Number in the parameters, or
+// nil if not found.
+func (ps Parameters) CallingPartyNumber() (*PartyNumber, error) {
+	p := ps.Get(ParamCallingPartyNumber)
+	if p == nil {
+		return nil, nil
+	}
+	return ParsePartyNumber(p.Value)
+}
+
+// CauseIndicators returns the Cause Indicators in the parameters, or nil if
+// not found.
+func (ps Parameters) CauseIndicators() (*CauseIndicators, error) {
+	p := ps.Get(ParamCauseIndicators)
+	if p == nil {
+		return nil, nil
+	}
+	return ParseCauseIndicators(p.Value)
+}
+
+// marshalLen returns the serial length of the parameters, without the End of
+// Optional Parameters.
+func (ps Parameters) marshalLen() int {
+	var n int
+	for _, p := range ps {
+		n += p.MarshalLen()
+	}
+	return n
+}
+
+// marshalTo puts the parameters followed by the End of Optional Parameters.
+func (ps Parameters) marshalTo(b []byte) error {
+	if len(b) < ps.marshalLen()+1 {
+		return ErrTooShortToMarshalBinary
+	}
+
+	var offset int
+	for _, p := range ps {
+		if p.Code == ParamEndOfOptionalParameters {
+			return ErrInvalidLength
+		}
+		if len(p.Value) > 0xff {
+			return ErrTooLong
+		}
+		b[offset] = p.Code
+		b[offset+1] = uint8(len(p.Value))
+		offset += 2 + copy(b[offset+2:], p.Value)
+	}
+	b[offset] = ParamEndOfOptionalParameters
+	return nil
+}
+
+// parseParameters decodes the optional part up to the End of Optional
+// Parameters.
+func parseParameters(b []byte) (Parameters, error) {
+	var ps Parameters
+	for offset := 0; ; {
+		if offset >= len(b) {
+			return nil, ErrTooShortToParse
+		}
+		if b[offset] == ParamEndOfOptionalParameters {
+			return ps, nil
+		}
+		if offset+2 > len(b) {
+			return nil, ErrTooShortToParse
+		}
+		n := int(b[offset+1])
+		if offset+2+n > len(b) {
+			return nil, ErrInvalidLength
+		}
+		ps = append(ps, &Parameter{Code: b[offset], Value: b[offset+2 : offset+2+n]})
+		offset += 2 + n
+	}
+}
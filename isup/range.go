@@ -0,0 +1,90 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// Circuit Group Supervision Message Type definitions.
+const (
+	SupervisionMaintenance uint8 = 0
+	SupervisionHardware    uint8 = 1
+)
+
+// RangeAndStatus is the Range and Status.
+//
+// Spec: ITU-T Q.763.
+type RangeAndStatus struct {
+	// Range is the number of the circuits affected minus one, starting from
+	// the CIC in the message.
+	Range uint8
+	// Status is the bitmap of the circuits, where the first bit is for the CIC
+	// in the message. It is absent in GRS.
+	Status []byte
+}
+
+// NewRangeAndStatus creates a new RangeAndStatus for the circuits as many as
+// Range+1, with the status bits set for the ones in the indices given.
+func NewRangeAndStatus(rng uint8, indices ...int) *RangeAndStatus {
+	r := &RangeAndStatus{Range: rng, Status: make([]byte, int(rng)/8+1)}
+	for _, i := range indices {
+		if i >= 0 && i <= int(rng) {
+			r.Status[i/8] |= 1 << (i % 8)
+		}
+	}
+	return r
+}
+
+// ParseRangeAndStatus decodes given byte sequence as a RangeAndStatus.
+func ParseRangeAndStatus(b []byte) (*RangeAndStatus, error) {
+	r := &RangeAndStatus{}
+	if err := r.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a RangeAndStatus.
+func (r *RangeAndStatus) UnmarshalBinary(b []byte) error {
+	if len(b) < 1 {
+		return ErrTooShortToParse
+	}
+	r.Range = b[0]
+	r.Status = nil
+	if len(b) > 1 {
+		r.Status = b[1:]
+	}
+	return nil
+}
+
+// MarshalBinary returns the byte sequence generated from a RangeAndStatus.
+func (r *RangeAndStatus) MarshalBinary() ([]byte, error) {
+	b := make([]byte, r.MarshalLen())
+	if err := r.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (r *RangeAndStatus) MarshalTo(b []byte) error {
+	if len(b) < r.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+	b[0] = r.Range
+	copy(b[1:], r.Status)
+	return nil
+}
+
+// MarshalLen returns the serial length of RangeAndStatus.
+func (r *RangeAndStatus) MarshalLen() int {
+	return 1 + len(r.Status)
+}
+
+// Affected reports whether the status bit is set for the circuit in the index
+// from the CIC in the message.
+func (r *RangeAndStatus) Affected(i int) bool {
+	if i < 0 || i > int(r.Range) || i/8 >= len(r.Status) {
+		return false
+	}
+	return r.Status[i/8]&(1<<(i%8)) != 0
+}
@@ -0,0 +1,98 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// REL is a Release message.
+//
+// Spec: ITU-T Q.763.
+type REL struct {
+	*Header
+	CauseIndicators *CauseIndicators
+	Optional        Parameters
+}
+
+// NewREL creates a new REL.
+func NewREL(v Variant, cic uint16, cause *CauseIndicators, opts ...*Parameter) *REL {
+	return &REL{
+		Header:          newHeader(v, cic, MsgTypeREL),
+		CauseIndicators: cause,
+		Optional:        opts,
+	}
+}
+
+var relLayout = layout{variable: 1, optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *REL) parts() ([]byte, [][]byte, error) {
+	if m.CauseIndicators == nil {
+		return nil, nil, ErrMissingParameter
+	}
+	cause, err := m.CauseIndicators.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, [][]byte{cause}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a REL.
+func (m *REL) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *REL) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return relLayout.marshalTo(b, m.Header, MsgTypeREL, fixed, variable, m.Optional)
+}
+
+// ParseREL decodes given byte sequence as a REL in the variant.
+func ParseREL(b []byte, v Variant) (*REL, error) {
+	m := &REL{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a REL.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *REL) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	_, variable, opts, err := relLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.CauseIndicators, err = ParseCauseIndicators(variable[0])
+	if err != nil {
+		return err
+	}
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of REL.
+func (m *REL) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return relLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *REL) MessageType() MsgType {
+	return MsgTypeREL
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *REL) MessageTypeName() string {
+	return MsgTypeREL.String()
+}
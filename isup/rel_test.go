@@ -0,0 +1,47 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestREL(t *testing.T) {
+	cases := []testCase{
+		{
+			"normal-clearing",
+			VariantITU,
+			NewREL(VariantITU, 0x0123, NewCauseIndicators(LocationPublicNetworkLocalUser, CauseNormalCallClearing, nil)),
+			[]byte{
+				// CIC, Type
+				0x23, 0x01, 0x0c,
+				// Pointers
+				0x02, 0x00,
+				// Cause Indicators
+				0x02, 0x82, 0x90,
+			},
+		}, {
+			"with-optional",
+			VariantITU,
+			NewREL(
+				VariantITU, 0x0123, NewCauseIndicators(LocationPublicNetworkLocalUser, CauseUserBusy, nil),
+				NewParameter(ParamAccessDeliveryInformation, []byte{0x01}),
+			),
+			[]byte{
+				0x23, 0x01, 0x0c,
+				0x02, 0x04,
+				0x02, 0x82, 0x91,
+				0x2e, 0x01, 0x01,
+				0x00,
+			},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseREL(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// RES is a Resume message.
+//
+// Spec: ITU-T Q.763.
+type RES struct {
+	*Header
+	SuspendResumeIndicators uint8
+	Optional                Parameters
+}
+
+// NewRES creates a new RES.
+func NewRES(v Variant, cic uint16, sri uint8, opts ...*Parameter) *RES {
+	return &RES{
+		Header:                  newHeader(v, cic, MsgTypeRES),
+		SuspendResumeIndicators: sri,
+		Optional:                opts,
+	}
+}
+
+var resLayout = layout{fixed: 1, optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *RES) parts() ([]byte, [][]byte, error) {
+	return []byte{m.SuspendResumeIndicators}, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a RES.
+func (m *RES) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *RES) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return resLayout.marshalTo(b, m.Header, MsgTypeRES, fixed, variable, m.Optional)
+}
+
+// ParseRES decodes given byte sequence as a RES in the variant.
+func ParseRES(b []byte, v Variant) (*RES, error) {
+	m := &RES{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a RES.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *RES) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, _, opts, err := resLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.SuspendResumeIndicators = fixed[0]
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of RES.
+func (m *RES) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return resLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *RES) MessageType() MsgType {
+	return MsgTypeRES
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *RES) MessageTypeName() string {
+	return MsgTypeRES.String()
+}
@@ -0,0 +1,26 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestRES(t *testing.T) {
+	cases := []testCase{
+		{
+			"subscriber-initiated",
+			VariantITU,
+			NewRES(VariantITU, 0x0123, 0x00),
+			[]byte{0x23, 0x01, 0x0e, 0x00, 0x00},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseRES(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// RLC is a Release Complete message.
+//
+// Spec: ITU-T Q.763.
+type RLC struct {
+	*Header
+	Optional Parameters
+}
+
+// NewRLC creates a new RLC.
+func NewRLC(v Variant, cic uint16, opts ...*Parameter) *RLC {
+	return &RLC{
+		Header:   newHeader(v, cic, MsgTypeRLC),
+		Optional: opts,
+	}
+}
+
+var rlcLayout = layout{optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *RLC) parts() ([]byte, [][]byte, error) {
+	return nil, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a RLC.
+func (m *RLC) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *RLC) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return rlcLayout.marshalTo(b, m.Header, MsgTypeRLC, fixed, variable, m.Optional)
+}
+
+// ParseRLC decodes given byte sequence as a RLC in the variant.
+func ParseRLC(b []byte, v Variant) (*RLC, error) {
+	m := &RLC{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a RLC.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *RLC) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	_, _, opts, err := rlcLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of RLC.
+func (m *RLC) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return rlcLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *RLC) MessageType() MsgType {
+	return MsgTypeRLC
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *RLC) MessageTypeName() string {
+	return MsgTypeRLC.String()
+}
@@ -0,0 +1,26 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import "testing"
+
+func TestRLC(t *testing.T) {
+	cases := []testCase{
+		{
+			"itu",
+			VariantITU,
+			NewRLC(VariantITU, 0x0123),
+			[]byte{0x23, 0x01, 0x10, 0x00},
+		},
+	}
+
+	runTests(t, cases, func(b []byte, v Variant) (Message, error) {
+		m, err := ParseRLC(b, v)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+}
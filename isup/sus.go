@@ -0,0 +1,88 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+// SUS is a Suspend message.
+//
+// Spec: ITU-T Q.763.
+type SUS struct {
+	*Header
+	SuspendResumeIndicators uint8
+	Optional                Parameters
+}
+
+// NewSUS creates a new SUS.
+func NewSUS(v Variant, cic uint16, sri uint8, opts ...*Parameter) *SUS {
+	return &SUS{
+		Header:                  newHeader(v, cic, MsgTypeSUS),
+		SuspendResumeIndicators: sri,
+		Optional:                opts,
+	}
+}
+
+var susLayout = layout{fixed: 1, optional: true}
+
+// parts returns the mandatory fixed part and the mandatory variable parameters.
+func (m *SUS) parts() ([]byte, [][]byte, error) {
+	return []byte{m.SuspendResumeIndicators}, nil, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a SUS.
+func (m *SUS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *SUS) MarshalTo(b []byte) error {
+	fixed, variable, err := m.parts()
+	if err != nil {
+		return err
+	}
+	return susLayout.marshalTo(b, m.Header, MsgTypeSUS, fixed, variable, m.Optional)
+}
+
+// ParseSUS decodes given byte sequence as a SUS in the variant.
+func ParseSUS(b []byte, v Variant) (*SUS, error) {
+	m := &SUS{Header: &Header{Variant: v}}
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a SUS.
+// The variant is taken from the Header if set, or ITU-T otherwise.
+func (m *SUS) UnmarshalBinary(b []byte) error {
+	if m.Header == nil {
+		m.Header = &Header{}
+	}
+	fixed, _, opts, err := susLayout.parse(b, m.Header)
+	if err != nil {
+		return err
+	}
+	m.SuspendResumeIndicators = fixed[0]
+	m.Optional = opts
+	return nil
+}
+
+// MarshalLen returns the serial length of SUS.
+func (m *SUS) MarshalLen() int {
+	_, variable, _ := m.parts()
+	return susLayout.marshalLen(variable, m.Optional)
+}
+
+// MessageType returns the Message Type.
+func (m *SUS) MessageType() MsgType {
+	return MsgTypeSUS
+}
+
+// MessageTypeName returns the name of Message Type.
+func (m *SUS) MessageTypeName() string {
+	return MsgTypeSUS.String()
+}
@@ -0,0 +1,105 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package isup
+
+import (
+	"testing"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+type testCase struct {
+	name       string
+	variant    Variant
+	structured Message
+	serialized []byte
+}
+
+type decoderFunc func([]byte, Variant) (Message, error)
+
+func runTests(t *testing.T, cases []testCase, decode decoderFunc) {
+	t.Helper()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Run("decode", func(t *testing.T) {
+				v, err := decode(c.serialized, c.variant)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := v, c.structured; !verify.Values(t, "", got, want) {
+					t.Fail()
+				}
+			})
+
+			t.Run("encode", func(t *testing.T) {
+				b, err := c.structured.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := b, c.serialized; !verify.Values(t, "", got, want) {
+					t.Fail()
+				}
+			})
+
+			t.Run("len", func(t *testing.T) {
+				if got, want := c.structured.MarshalLen(), len(c.serialized); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+			})
+
+			t.Run("interface", func(t *testing.T) {
+				decoded, err := Parse(c.serialized, c.variant)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := decoded.MessageType(), c.structured.MessageType(); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+				if got, want := decoded.MessageTypeName(), c.structured.MessageTypeName(); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+				if got, want := decoded.CircuitID(), c.structured.CircuitID(); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+// testCdPN is the international number 81312345678.
+func testCdPN() *PartyNumber {
+	n, err := NewCalledPartyNumber(NatureInternational, NumberingPlanISDNTelephony, "81312345678")
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+var testCdPNBytes = []byte{0x84, 0x10, 0x18, 0x13, 0x32, 0x54, 0x76, 0x08}
+
+// testCgPN is the national number 0312345678 provided by the network.
+func testCgPN() *PartyNumber {
+	n, err := NewCallingPartyNumber(
+		NatureNational, NumberingPlanISDNTelephony, PresentationAllowed, ScreeningNetworkProvided, "0312345678",
+	)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+var testCgPNBytes = []byte{0x03, 0x13, 0x30, 0x21, 0x43, 0x65, 0x87}
+
+func concat(bs ...[]byte) []byte {
+	var b []byte
+	for _, v := range bs {
+		b = append(b, v...)
+	}
+	return b
+}
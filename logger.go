@@ -5,10 +5,17 @@
 package m3ua
 
 import (
+	"context"
 	"io"
 	"log"
+	"log/slog"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+
+	"github.com/ishidawataru/sctp"
 )
 
 var (
@@ -21,6 +28,9 @@ var (
 // This package prints just informational logs from goroutines working background
 // that might help developers test the program but can be ignored safely. More
 // important ones that needs any action by caller would be returned as errors.
+//
+// The logger set here is used by the connections whose Config.Logger is nil,
+// and only the logs at slog.LevelInfo or higher are printed with it.
 func SetLogger(l *log.Logger) {
 	if l == nil {
 		log.Println("Don't pass nil to SetLogger: use DisableLogging instead.")
@@ -35,9 +45,6 @@ func SetLogger(l *log.Logger) {
 //
 // See also: SetLogger.
 func EnableLogging(l *log.Logger) {
-	logMu.Lock()
-	defer logMu.Unlock()
-
 	setLogger(l)
 }
 
@@ -60,9 +67,142 @@ func setLogger(l *log.Logger) {
 
 	logger = l
 }
+
 func logf(format string, v ...interface{}) {
 	logMu.Lock()
 	defer logMu.Unlock()
 
 	logger.Printf(format, v...)
 }
+
+// legacyHandler is a slog.Handler that prints the records with the package
+// logger set by SetLogger, which is used when Config.Logger is not set.
+type legacyHandler struct {
+	// attrs is the preformatted attributes added by WithAttrs.
+	attrs string
+	// group is the prefix of the keys added by WithGroup.
+	group string
+}
+
+// Enabled reports whether the level is Info or higher.
+func (h *legacyHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= slog.LevelInfo
+}
+
+// Handle prints the record in the form of "LEVEL message key=value ...".
+func (h *legacyHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&b, h.group, a)
+		return true
+	})
+
+	logf("%s", b.String())
+	return nil
+}
+
+// WithAttrs returns a new legacyHandler with the attributes given.
+func (h *legacyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		appendAttr(&b, h.group, a)
+	}
+	return &legacyHandler{attrs: b.String(), group: h.group}
+}
+
+// WithGroup returns a new legacyHandler that qualifies the keys with the name.
+func (h *legacyHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &legacyHandler{attrs: h.attrs, group: h.group + name + "."}
+}
+
+func appendAttr(b *strings.Builder, group string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(b, group, ga)
+		}
+		return
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(group)
+	b.WriteString(a.Key)
+	b.WriteByte('=')
+	v := a.Value.String()
+	if v == "" || strings.ContainsAny(v, " =\"\t\n") {
+		v = strconv.Quote(v)
+	}
+	b.WriteString(v)
+}
+
+var defaultLogger = slog.New(&legacyHandler{})
+
+// newConnLogger returns the logger for the connection, with the attributes
+// to identify the association and the configuration of it.
+func newConnLogger(cfg *Config, laddr, raddr net.Addr, assocID sctp.SCTPAssocID) *slog.Logger {
+	l := cfg.Logger
+	if l == nil {
+		l = defaultLogger
+	}
+
+	attrs := make([]any, 0, 8)
+	if laddr != nil {
+		attrs = append(attrs, slog.String("local_addr", laddr.String()))
+	}
+	if raddr != nil {
+		attrs = append(attrs, slog.String("remote_addr", raddr.String()))
+	}
+	attrs = append(attrs, slog.Int("assoc_id", int(assocID)))
+	if id, err := cfg.AspIdentifier.DecodeAspIdentifier(); err == nil {
+		attrs = append(attrs, slog.Uint64("asp_id", uint64(id)))
+	}
+	if rcs, err := cfg.RoutingContexts.DecodeRoutingContexts(); err == nil {
+		attrs = append(attrs, slog.String("routing_contexts", formatUint32s(rcs)))
+	}
+	return l.With(attrs...)
+}
+
+func formatUint32s(vs []uint32) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return strings.Join(s, ",")
+}
+
+// initLogger sets up the logger of the Conn after the association is established.
+func (c *Conn) initLogger(assocID sctp.SCTPAssocID) {
+	c.logger.Store(newConnLogger(c.config(), c.laddr, c.raddr, assocID))
+}
+
+// log returns the logger of the Conn, or the default one if it is not
+// initialized yet.
+func (c *Conn) log() *slog.Logger {
+	if l := c.logger.Load(); l != nil {
+		return l
+	}
+	if cfg := c.config(); cfg != nil && cfg.Logger != nil {
+		return cfg.Logger
+	}
+	return defaultLogger
+}
+
+// addLogAttrs adds the attributes to the logger of the Conn, e.g., with the
+// identifiers learned from the peer.
+func (c *Conn) addLogAttrs(attrs ...any) {
+	c.logger.Store(c.log().With(attrs...))
+}
@@ -0,0 +1,65 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestConnLogger(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := NewConfig(1, 2, 3, 0, 0, 0).
+		SetAspIdentifier(10).
+		SetRoutingContexts(1, 2).
+		SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	laddr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 2905}
+	raddr := &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 2905}
+
+	c := withConfig(&Conn{}, cfg)
+	c.logger.Store(newConnLogger(cfg, laddr, raddr, 5))
+	c.addLogAttrs("peer_asp_id", uint32(20))
+	c.log().Debug("hello")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"level":            "DEBUG",
+		"msg":              "hello",
+		"local_addr":       "192.0.2.1:2905",
+		"remote_addr":      "192.0.2.2:2905",
+		"assoc_id":         float64(5),
+		"asp_id":           float64(10),
+		"routing_contexts": "1,2",
+		"peer_asp_id":      float64(20),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestLegacyLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	defer EnableLogging(nil)
+
+	c := withConfig(&Conn{}, NewConfig(1, 2, 3, 0, 0, 0))
+	c.logger.Store(newConnLogger(c.config(), nil, nil, 5))
+	c.log().Debug("not printed")
+	c.log().WithGroup("g").Warn("printed", "error", "with space", slog.Group("sub", "k", 1))
+
+	if got, want := strings.TrimSpace(buf.String()), `WARN printed assoc_id=5 g.error="with space" g.sub.k=1`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -4,7 +4,10 @@
 
 package m3ua
 
-import "github.com/wmnsk/go-m3ua/messages"
+import (
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
 
 // XXX - implement!
 func (c *Conn) handleError(e *messages.Error) error {
@@ -13,6 +16,10 @@ func (c *Conn) handleError(e *messages.Error) error {
 		return NewUnexpectedMessageError(e)
 	}
 
+	if code, err := e.ErrorCode.DecodeErrorCode(); err == nil {
+		c.log().Warn("received ERROR", "error_code", params.ErrorCodeName(code))
+	}
+
 	return nil
 }
 
@@ -23,5 +30,9 @@ func (c *Conn) handleNotify(e *messages.Notify) error {
 		return NewUnexpectedMessageError(e)
 	}
 
+	if status, err := e.Status.DecodeStatus(); err == nil {
+		c.log().Info("received NOTIFY", "status", status.String())
+	}
+
 	return nil
 }
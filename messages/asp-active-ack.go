@@ -54,30 +54,29 @@ func (a *AspActiveAck) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := a.TrafficModeType; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.RoutingContext; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspActiveAck decodes given byte sequence as a AspActiveAck.
@@ -97,7 +96,7 @@ func (a *AspActiveAck) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -146,6 +145,12 @@ func (a *AspActiveAck) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspActiveAck is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspActiveAck) Validate() error {
+	return validate(a, a.Header, a.TrafficModeType, a.RoutingContext, a.InfoString)
+}
+
 // String returns the AspActiveAck values in human readable format.
 func (a *AspActiveAck) String() string {
 	return fmt.Sprintf("{Header: %s, TrafficModeType: %s, RoutingContext: %s, InfoString: %s}",
@@ -156,6 +161,27 @@ func (a *AspActiveAck) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspActiveAck.
+func (a *AspActiveAck) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspActiveAck.
+func (a *AspActiveAck) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspActiveAck,
+// which has the same structure as the JSON encoding.
+func (a *AspActiveAck) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspActiveAck.
+func (a *AspActiveAck) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspActiveAck) Version() uint8 {
 	return a.Header.Version
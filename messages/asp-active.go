@@ -54,30 +54,29 @@ func (a *AspActive) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := a.TrafficModeType; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.RoutingContext; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspActive decodes given byte sequence as a AspActive.
@@ -97,7 +96,7 @@ func (a *AspActive) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -146,6 +145,12 @@ func (a *AspActive) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspActive is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspActive) Validate() error {
+	return validate(a, a.Header, a.TrafficModeType, a.RoutingContext, a.InfoString)
+}
+
 // String returns the AspActive values in human readable format.
 func (a *AspActive) String() string {
 	return fmt.Sprintf("{Header: %s, TrafficModeType: %s, RoutingContext: %s, InfoString: %s}",
@@ -156,6 +161,27 @@ func (a *AspActive) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspActive.
+func (a *AspActive) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspActive.
+func (a *AspActive) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspActive,
+// which has the same structure as the JSON encoding.
+func (a *AspActive) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspActive.
+func (a *AspActive) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspActive) Version() uint8 {
 	return a.Header.Version
@@ -51,15 +51,14 @@ func (a *AspDownAck) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload); err != nil {
+		if err := param.MarshalTo(b[8:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspDownAck decodes given byte sequence as a AspDownAck.
@@ -79,7 +78,7 @@ func (a *AspDownAck) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -112,6 +111,12 @@ func (a *AspDownAck) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspDownAck is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspDownAck) Validate() error {
+	return validate(a, a.Header, a.InfoString)
+}
+
 // String returns the AspDownAck values in human readable format.
 func (a *AspDownAck) String() string {
 	return fmt.Sprintf("{Header: %s, InfoString: %s}",
@@ -120,6 +125,27 @@ func (a *AspDownAck) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspDownAck.
+func (a *AspDownAck) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspDownAck.
+func (a *AspDownAck) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspDownAck,
+// which has the same structure as the JSON encoding.
+func (a *AspDownAck) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspDownAck.
+func (a *AspDownAck) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspDownAck) Version() uint8 {
 	return a.Header.Version
@@ -51,15 +51,14 @@ func (a *AspDown) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload); err != nil {
+		if err := param.MarshalTo(b[8:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspDown decodes given byte sequence as a AspDown.
@@ -79,7 +78,7 @@ func (a *AspDown) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -112,6 +111,12 @@ func (a *AspDown) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspDown is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspDown) Validate() error {
+	return validate(a, a.Header, a.InfoString)
+}
+
 // String returns the AspDown values in human readable format.
 func (a *AspDown) String() string {
 	return fmt.Sprintf("{Header: %s, InfoString: %s}",
@@ -120,6 +125,27 @@ func (a *AspDown) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspDown.
+func (a *AspDown) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspDown.
+func (a *AspDown) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspDown,
+// which has the same structure as the JSON encoding.
+func (a *AspDown) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspDown.
+func (a *AspDown) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspDown) Version() uint8 {
 	return a.Header.Version
@@ -52,23 +52,22 @@ func (a *AspInactive) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := a.RoutingContext; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspInactive decodes given byte sequence as a AspInactive.
@@ -88,7 +87,7 @@ func (a *AspInactive) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -129,6 +128,12 @@ func (a *AspInactive) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspInactive is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspInactive) Validate() error {
+	return validate(a, a.Header, a.RoutingContext, a.InfoString)
+}
+
 // String returns the AspInactive values in human readable format.
 func (a *AspInactive) String() string {
 	return fmt.Sprintf("{Header: %s, RoutingContext: %s, InfoString: %s}",
@@ -138,6 +143,27 @@ func (a *AspInactive) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspInactive.
+func (a *AspInactive) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspInactive.
+func (a *AspInactive) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspInactive,
+// which has the same structure as the JSON encoding.
+func (a *AspInactive) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspInactive.
+func (a *AspInactive) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspInactive) Version() uint8 {
 	return a.Header.Version
@@ -52,23 +52,22 @@ func (a *AspUpAck) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	a.Header.Payload = make([]byte, a.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := a.AspIdentifier; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := a.InfoString; param != nil {
-		if err := param.MarshalTo(a.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return a.Header.MarshalTo(b)
+	a.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseAspUpAck decodes given byte sequence as a AspUpAck.
@@ -88,7 +87,7 @@ func (a *AspUpAck) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(a.Header.Payload)
+	prs, err := parseParams(a.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -129,6 +128,12 @@ func (a *AspUpAck) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the AspUpAck is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (a *AspUpAck) Validate() error {
+	return validate(a, a.Header, a.AspIdentifier, a.InfoString)
+}
+
 // String returns the AspUpAck values in human readable format.
 func (a *AspUpAck) String() string {
 	return fmt.Sprintf("{Header: %s, AspIdentifier: %s, InfoString: %s}",
@@ -138,6 +143,27 @@ func (a *AspUpAck) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of AspUpAck.
+func (a *AspUpAck) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of AspUpAck.
+func (a *AspUpAck) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, a)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of AspUpAck,
+// which has the same structure as the JSON encoding.
+func (a *AspUpAck) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(a)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of AspUpAck.
+func (a *AspUpAck) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
 // Version returns the version of M3UA in int.
 func (a *AspUpAck) Version() uint8 {
 	return a.Header.Version
@@ -56,37 +56,36 @@ func (d *Data) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	d.Header.Payload = make([]byte, d.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := d.NetworkAppearance; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := d.RoutingContext; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := d.ProtocolData; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := d.CorrelationID; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return d.Header.MarshalTo(b)
+	d.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseData decodes given byte sequence as a Data.
@@ -106,7 +105,7 @@ func (d *Data) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(d.Header.Payload)
+	prs, err := parseParams(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -127,6 +126,64 @@ func (d *Data) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// ParseDataInto decodes given byte sequence as a Data into d, reusing the
+// Header and parameters in d instead of allocating new ones. This is for the
+// applications that handle a lot of Data messages and want to avoid allocations
+// by reusing a Data, e.g., with sync.Pool.
+//
+// The parameters in d are overwritten in place, so they should not be shared
+// with other messages. The parameters that are not in b are set to nil.
+// Like UnmarshalBinary, the values in d refer to b, which should not be
+// modified while d is in use.
+func ParseDataInto(b []byte, d *Data) error {
+	if d.Header == nil {
+		d.Header = &Header{}
+	}
+	if err := d.Header.UnmarshalBinary(b); err != nil {
+		return err
+	}
+
+	nwApr, rtCtx, pd, corrID := d.NetworkAppearance, d.RoutingContext, d.ProtocolData, d.CorrelationID
+	d.NetworkAppearance, d.RoutingContext, d.ProtocolData, d.CorrelationID = nil, nil, nil, nil
+
+	payload := d.Header.Payload
+	for len(payload) > 0 {
+		var pr params.Param
+		if err := pr.UnmarshalBinary(payload); err != nil {
+			return err
+		}
+		l := pr.MarshalLen()
+		if len(payload) < l {
+			return params.ErrInvalidLength
+		}
+		payload = payload[l:]
+
+		var field, reuse **params.Param
+		switch pr.Tag {
+		case params.NetworkAppearance:
+			field, reuse = &d.NetworkAppearance, &nwApr
+		case params.RoutingContext:
+			field, reuse = &d.RoutingContext, &rtCtx
+		case params.ProtocolData:
+			field, reuse = &d.ProtocolData, &pd
+		case params.CorrelationID:
+			field, reuse = &d.CorrelationID, &corrID
+		default:
+			return ErrInvalidParameter
+		}
+		if *field != nil {
+			return ErrInvalidParameter
+		}
+
+		if *reuse == nil {
+			*reuse = &params.Param{}
+		}
+		**reuse = pr
+		*field = *reuse
+	}
+	return nil
+}
+
 // SetLength sets the length in Length field.
 func (d *Data) SetLength() {
 	if param := d.NetworkAppearance; param != nil {
@@ -163,6 +220,12 @@ func (d *Data) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the Data is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (d *Data) Validate() error {
+	return validate(d, d.Header, d.NetworkAppearance, d.RoutingContext, d.ProtocolData, d.CorrelationID)
+}
+
 // String returns the Data values in human readable format.
 func (d *Data) String() string {
 	return fmt.Sprintf("{Header: %s, NetworkAppearance: %s, RoutingContext: %s, ProtocolData %s, CorrelationID: %s}",
@@ -174,6 +237,27 @@ func (d *Data) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of Data.
+func (d *Data) MarshalJSON() ([]byte, error) {
+	return marshalJSON(d)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Data.
+func (d *Data) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, d)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Data,
+// which has the same structure as the JSON encoding.
+func (d *Data) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(d)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Data.
+func (d *Data) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, d)
+}
+
 // Version returns the version of M3UA in int.
 func (d *Data) Version() uint8 {
 	return d.Header.Version
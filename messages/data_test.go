@@ -5,6 +5,7 @@
 package messages
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/wmnsk/go-m3ua/messages/params"
@@ -159,3 +160,126 @@ func TestData(t *testing.T) {
 		return v, nil
 	})
 }
+
+func TestParseDataInto(t *testing.T) {
+	withRC, err := NewData(
+		nil, params.NewRoutingContext(1),
+		params.NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad, 0xbe, 0xef}),
+		nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	withNA, err := NewData(
+		params.NewNetworkAppearance(1), nil,
+		params.NewProtocolData(4, 5, 3, 1, 0, 1, []byte{0xca, 0xfe}),
+		nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Data{}
+	if err := ParseDataInto(withRC, d); err != nil {
+		t.Fatal(err)
+	}
+	pd := d.ProtocolData
+
+	if err := ParseDataInto(withNA, d); err != nil {
+		t.Fatal(err)
+	}
+	if d.RoutingContext != nil {
+		t.Errorf("RoutingContext is not reset: %s", d.RoutingContext)
+	}
+	if d.NetworkAppearance.NetworkAppearance() != 1 {
+		t.Errorf("got NetworkAppearance %s", d.NetworkAppearance)
+	}
+	if d.ProtocolData != pd {
+		t.Error("ProtocolData is not reused")
+	}
+
+	got, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, withNA) {
+		t.Errorf("got %x, want %x", got, withNA)
+	}
+
+	if err := ParseDataInto(withRC, d); err != nil {
+		t.Fatal(err)
+	}
+	if d.RoutingContext.RoutingContext() != 1 || d.NetworkAppearance != nil {
+		t.Errorf("unexpected parameters: %s", d)
+	}
+
+	dup := append([]byte{}, withRC...)
+	copy(dup[8:16], dup[16:24]) // replace RoutingContext with the head of ProtocolData
+	dup = dup[:len(dup)-4]
+	if err := ParseDataInto(dup, d); err == nil {
+		t.Error("no error with malformed Data")
+	}
+}
+
+var benchData = []byte{
+	// Header
+	0x01, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x2c,
+	// NetworkAppearance
+	0x02, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+	// RoutingContext
+	0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+	// ProtocolData
+	0x02, 0x10, 0x00, 0x14,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02,
+	0x03, 0x01, 0x00, 0x01,
+	0xde, 0xad, 0xbe, 0xef,
+}
+
+func BenchmarkParseData(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseData(benchData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseDataInto(b *testing.B) {
+	d := &Data{}
+	var pd params.ProtocolDataPayload
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ParseDataInto(benchData, d); err != nil {
+			b.Fatal(err)
+		}
+		if err := pd.UnmarshalBinary(d.ProtocolData.Data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDataMarshalTo(b *testing.B) {
+	d, err := ParseData(benchData)
+	if err != nil {
+		b.Fatal(err)
+	}
+	buf := make([]byte, d.MarshalLen())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := d.MarshalTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewDataMarshalBinary(b *testing.B) {
+	nwApr, rtCtx := params.NewNetworkAppearance(1), params.NewRoutingContext(1)
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d := NewData(nwApr, rtCtx, params.NewProtocolData(1, 2, 3, 1, 0, 1, payload), nil)
+		if _, err := d.MarshalBinary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+	"github.com/wmnsk/go-m3ua/pc"
+)
+
+// Dissector prints M3UA messages in human-readable format, as an indented
+// tree of the header fields and the parameters with their names and decoded
+// values, like the M3UA dissector in Wireshark.
+//
+// The zero value is ready to use, which prints the point codes in decimal.
+type Dissector struct {
+	// PointCodeVariant is the variant used to format the point codes, such as
+	// pc.Variant383. The point codes are printed in decimal if not set.
+	PointCodeVariant pc.Variant
+	// Indent is the string used for each level of indentation.
+	// Four spaces are used if not set.
+	Indent string
+}
+
+// Describe returns the human-readable representation of the message with
+// the default Dissector.
+func Describe(m M3UA) string {
+	return (&Dissector{}).Describe(m)
+}
+
+// Describe returns the human-readable representation of the message.
+func (d *Dissector) Describe(m M3UA) string {
+	var sb strings.Builder
+	_ = d.Fprint(&sb, m)
+	return sb.String()
+}
+
+// Fprint writes the human-readable representation of the message to w.
+func (d *Dissector) Fprint(w io.Writer, m M3UA) error {
+	p := &treePrinter{w: w, indent: d.Indent, variant: d.PointCodeVariant}
+	if p.indent == "" {
+		p.indent = "    "
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return err
+	}
+
+	p.println(0, "MTP 3 User Adaptation Layer")
+	p.println(1, "Version: %d", h.Version)
+	p.println(1, "Reserved: %#02x", h.Reserved)
+	className, typeName := messageNames(h.Class, h.Type)
+	p.println(1, "Message class: %s (%d)", className, h.Class)
+	p.println(1, "Message type: %s (%d)", typeName, h.Type)
+	p.println(1, "Message length: %d", h.Length)
+	p.params(1, h.Payload)
+
+	return p.err
+}
+
+var msgClassNames = map[uint8]string{
+	MsgClassManagement: MsgClassNameManagement,
+	MsgClassTransfer:   MsgClassNameTransfer,
+	MsgClassSSNM:       MsgClassNameSSNM,
+	MsgClassASPSM:      MsgClassNameASPSM,
+	MsgClassASPTM:      MsgClassNameASPTM,
+	MsgClassRKM:        MsgClassNameRKM,
+}
+
+// messageNames returns the names of the message class and type in the header,
+// which is independent of the type of M3UA given, e.g., *Generic.
+func messageNames(class, mtype uint8) (string, string) {
+	className, typeName := msgClassNames[class], "Unknown"
+	m := newMessage(class, mtype)
+	if _, generic := m.(*Generic); !generic {
+		typeName = m.MessageTypeName()
+		if className == "" {
+			className = m.MessageClassName()
+		}
+	}
+	if className == "" {
+		className = "Unknown"
+	}
+	return className, typeName
+}
+
+type treePrinter struct {
+	w       io.Writer
+	indent  string
+	variant pc.Variant
+	err     error
+}
+
+func (p *treePrinter) println(depth int, format string, v ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, strings.Repeat(p.indent, depth)+format+"\n", v...)
+}
+
+func (p *treePrinter) params(depth int, b []byte) {
+	prs, err := params.ParseMultiParams(b)
+	if err != nil {
+		p.println(depth, "Malformed parameters: %v (%x)", err, b)
+		return
+	}
+
+	for _, pr := range prs {
+		name := params.TagName(pr.Tag)
+		if name == "" {
+			name = "Unknown parameter"
+		}
+		p.println(depth, "%s (%d bytes)", name, pr.MarshalLen())
+		p.println(depth+1, "Parameter Tag: %s (%d)", name, pr.Tag)
+		p.println(depth+1, "Parameter Length: %d", pr.Length)
+		p.value(depth+1, pr)
+		if n := pr.Padding(); n > 0 {
+			p.println(depth+1, "Padding: %d bytes", n)
+		}
+	}
+}
+
+func (p *treePrinter) value(depth int, pr *params.Param) {
+	data := pr.Data
+	switch pr.Tag {
+	case params.InfoString:
+		if utf8.Valid(data) {
+			p.println(depth, "Info string: %q", data)
+			return
+		}
+	case params.RoutingContext:
+		if len(data) > 0 && len(data)%4 == 0 {
+			for _, rc := range pr.RoutingContexts() {
+				p.println(depth, "Routing context: %d", rc)
+			}
+			return
+		}
+	case params.AffectedPointCode, params.OriginatingPointCodeList:
+		if len(data) > 0 && len(data)%4 == 0 {
+			for i := 0; i < len(data); i += 4 {
+				p.println(depth, "Mask: %d", data[i])
+				p.println(depth, "Point code: %s", p.pointCode(data[i:i+4]))
+			}
+			return
+		}
+	case params.DestinationPointCode, params.ConcernedDestination:
+		if len(data) == 4 {
+			p.println(depth, "Mask: %d", data[0])
+			p.println(depth, "Point code: %s", p.pointCode(data))
+			return
+		}
+	case params.TrafficModeType:
+		if len(data) == 4 {
+			v := binary.BigEndian.Uint32(data)
+			p.println(depth, "Traffic mode type: %s", named(params.TrafficModeTypeName(v), v))
+			return
+		}
+	case params.ErrorCode:
+		if len(data) == 4 {
+			v := binary.BigEndian.Uint32(data)
+			p.println(depth, "Error code: %s", named(params.ErrorCodeName(v), v))
+			return
+		}
+	case params.Status:
+		if len(data) == 4 {
+			v := binary.BigEndian.Uint32(data)
+			p.println(depth, "Status type: %s", named(statusTypeName(uint16(v>>16)), v>>16))
+			p.println(depth, "Status info: %s", named(params.StatusName(v), v&0xffff))
+			return
+		}
+	case params.UserCause:
+		if len(data) == 4 {
+			p.println(depth, "Unavailability cause: %d", binary.BigEndian.Uint16(data[0:2]))
+			user := uint32(binary.BigEndian.Uint16(data[2:4]))
+			var name string
+			if user <= 0x0f {
+				name = params.ServiceIndicator(user).String()
+			}
+			p.println(depth, "User identity: %s", named(name, user))
+			return
+		}
+	case params.CongestionIndications:
+		if len(data) == 4 {
+			p.println(depth, "Congestion level: %d", data[3])
+			return
+		}
+	case params.ServiceIndicators:
+		for _, si := range data {
+			p.println(depth, "Service indicator: %d", si)
+		}
+		return
+	case params.AspIdentifier, params.CorrelationID, params.NetworkAppearance,
+		params.LocalRoutingKeyIdentifier, params.RegistrationStatus, params.DeregistrationStatus:
+		if len(data) == 4 {
+			p.println(depth, "%s: %d", params.TagName(pr.Tag), binary.BigEndian.Uint32(data))
+			return
+		}
+	case params.ProtocolData:
+		if pd, err := params.ParseProtocolDataPayload(data); err == nil {
+			p.println(depth, "OPC: %s", p.pointCode(binary.BigEndian.AppendUint32(nil, pd.OriginatingPointCode)))
+			p.println(depth, "DPC: %s", p.pointCode(binary.BigEndian.AppendUint32(nil, pd.DestinationPointCode)))
+			p.println(depth, "SI: %s (%d)", params.ServiceIndicator(pd.ServiceIndicator), pd.ServiceIndicator)
+			p.println(depth, "NI: %s (%d)", params.NetworkIndicator(pd.NetworkIndicator), pd.NetworkIndicator)
+			p.println(depth, "MP: %d", pd.MessagePriority)
+			p.println(depth, "SLS: %d", pd.SignalingLinkSelection)
+			p.println(depth, "Data: %x", pd.Data)
+			return
+		}
+	case params.RoutingKey, params.RegistrationResult, params.DeregistrationResult:
+		p.params(depth, data)
+		return
+	}
+
+	p.println(depth, "Value: %x", data)
+}
+
+// pointCode formats the lower 24 bits of b as a point code.
+func (p *treePrinter) pointCode(b []byte) string {
+	raw := binary.BigEndian.Uint32(b) & 0xffffff
+	if p.variant == pc.VariantNone {
+		return fmt.Sprintf("%d", raw)
+	}
+
+	// the bits that exceed the variant are dropped in formatting, so the
+	// raw value is always shown together.
+	code := pc.NewPointCode(raw, p.variant)
+	if code == nil {
+		return fmt.Sprintf("%d", raw)
+	}
+	return fmt.Sprintf("%s (%d)", code, raw)
+}
+
+func statusTypeName(t uint16) string {
+	switch t {
+	case params.AsStateChange:
+		return "AS-State-Change"
+	case params.Other:
+		return "Other"
+	default:
+		return ""
+	}
+}
+
+func named(name string, v uint32) string {
+	if name == "" {
+		return fmt.Sprintf("Unknown (%d)", v)
+	}
+	return fmt.Sprintf("%s (%d)", name, v)
+}
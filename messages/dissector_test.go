@@ -0,0 +1,76 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+	"github.com/wmnsk/go-m3ua/pc"
+)
+
+func TestDescribe(t *testing.T) {
+	got := Describe(NewNotify(params.NewStatus(params.AsStateActive), nil, params.NewRoutingContext(1, 2), nil))
+	want := `MTP 3 User Adaptation Layer
+    Version: 1
+    Reserved: 0x00
+    Message class: Management (0)
+    Message type: Notify (1)
+    Message length: 28
+    Status (8 bytes)
+        Parameter Tag: Status (13)
+        Parameter Length: 8
+        Status type: AS-State-Change (1)
+        Status info: AS-Active (3)
+    Routing Context (12 bytes)
+        Parameter Tag: Routing Context (6)
+        Parameter Length: 12
+        Routing context: 1
+        Routing context: 2
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDissector(t *testing.T) {
+	params.RegisterTagName(0x8001, "Vendor Specific")
+	t.Cleanup(func() { params.RegisterTagName(0x8001, "") })
+
+	d := &Dissector{PointCodeVariant: pc.Variant383, Indent: "\t"}
+	got := d.Describe(New(
+		1, MsgClassSSNM, MsgTypeDestinationUnavailable,
+		params.NewAffectedPointCode(0x1234),
+		params.NewInfoString("info"),
+		params.NewParam(0x8001, []byte{0xde, 0xad}),
+	))
+
+	for _, want := range []string{
+		"\tMessage class: SSNM (2)\n",
+		"\tMessage type: Destination Unavailable (1)\n",
+		"\t\tPoint code: 2-70-4 (4660)\n",
+		"\t\tInfo string: \"info\"\n",
+		"\tVendor Specific (8 bytes)\n",
+		"\t\tValue: dead\n",
+		"\t\tPadding: 2 bytes\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q not found in:\n%s", want, got)
+		}
+	}
+}
+
+func TestDissectorUnknownMessage(t *testing.T) {
+	got := Describe(New(1, 0xff, 0xfe, params.NewInfoString("info")))
+	for _, want := range []string{
+		"    Message class: Unknown (255)\n",
+		"    Message type: Unknown (254)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q not found in:\n%s", want, got)
+		}
+	}
+}
@@ -41,6 +41,18 @@ func NewDestinationRestricted(nwApr, rtCtx, apcs, info *params.Param) *Destinati
 	return d
 }
 
+// NewDestinationRestrictedWithMask creates a new DestinationRestricted with the Affected Point Codes
+// given as the point codes with masks. The parameter is omitted if apcs is empty.
+func NewDestinationRestrictedWithMask(nwApr, rtCtx *params.Param, apcs []params.PointCodeWithMask, info *params.Param) *DestinationRestricted {
+	return NewDestinationRestricted(nwApr, rtCtx, newAffectedPointCode(apcs), info)
+}
+
+// AffectedPointCodes returns the point codes with masks in the Affected
+// Point Code parameter of the DestinationRestricted.
+func (d *DestinationRestricted) AffectedPointCodes() (params.PointCodeList, error) {
+	return d.AffectedPointCode.AffectedPointCodesWithMask()
+}
+
 // MarshalBinary returns the byte sequence generated from a DestinationRestricted.
 func (d *DestinationRestricted) MarshalBinary() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -56,33 +68,32 @@ func (d *DestinationRestricted) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	d.Header.Payload = make([]byte, d.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := d.NetworkAppearance; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.RoutingContext; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.AffectedPointCode; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.InfoString; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
-	return d.Header.MarshalTo(b)
+	d.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseDestinationRestricted decodes given byte sequence as a DestinationRestricted.
@@ -102,7 +113,7 @@ func (d *DestinationRestricted) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(d.Header.Payload)
+	prs, err := parseParams(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -159,6 +170,33 @@ func (d *DestinationRestricted) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the DestinationRestricted is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (d *DestinationRestricted) Validate() error {
+	return validate(d, d.Header, d.NetworkAppearance, d.RoutingContext, d.AffectedPointCode, d.InfoString)
+}
+
+// MarshalJSON returns the JSON encoding of DestinationRestricted.
+func (d *DestinationRestricted) MarshalJSON() ([]byte, error) {
+	return marshalJSON(d)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of DestinationRestricted.
+func (d *DestinationRestricted) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, d)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of DestinationRestricted,
+// which has the same structure as the JSON encoding.
+func (d *DestinationRestricted) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(d)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of DestinationRestricted.
+func (d *DestinationRestricted) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, d)
+}
+
 // Version returns the version of M3UA in int.
 func (d *DestinationRestricted) Version() uint8 {
 	return d.Header.Version
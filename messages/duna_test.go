@@ -35,6 +35,23 @@ func TestDestinationUnavailable(t *testing.T) {
 		},
 	}
 
+	cases = append(cases, testCase{
+		"with-mask",
+		NewDestinationUnavailableWithMask(
+			nil, params.NewRoutingContext(2),
+			[]params.PointCodeWithMask{params.NewPointCodeWithMask(3, 0x1230)},
+			nil,
+		),
+		[]byte{
+			// Header
+			0x01, 0x00, 0x02, 0x01, 0x00, 0x00, 0x00, 0x18,
+			// RoutingContext
+			0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02,
+			// AffectedPointCode
+			0x00, 0x12, 0x00, 0x08, 0x03, 0x00, 0x12, 0x30,
+		},
+	})
+
 	runTests(t, cases, func(b []byte) (serializeable, error) {
 		v, err := ParseDestinationUnavailable(b)
 		if err != nil {
@@ -44,3 +61,28 @@ func TestDestinationUnavailable(t *testing.T) {
 		return v, nil
 	})
 }
+
+func TestDestinationUnavailableAffectedPointCodes(t *testing.T) {
+	b := []byte{
+		0x01, 0x00, 0x02, 0x01, 0x00, 0x00, 0x00, 0x14,
+		0x00, 0x12, 0x00, 0x0c, 0x03, 0x00, 0x12, 0x30, 0x00, 0x00, 0x00, 0x04,
+	}
+	d, err := ParseDestinationUnavailable(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apcs, err := d.AffectedPointCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apcs) != 2 || apcs[0] != params.NewPointCodeWithMask(3, 0x1230) || apcs[1] != params.NewPointCodeWithMask(0, 4) {
+		t.Errorf("unexpected point codes: %v", apcs)
+	}
+	if !apcs[0].Contains(0x1237) || apcs[0].Contains(0x1238) {
+		t.Errorf("unexpected containment of %v", apcs[0])
+	}
+
+	if _, err := NewDestinationUnavailable(nil, nil, nil, nil).AffectedPointCodes(); err != params.ErrNotPresent {
+		t.Errorf("got %v, want %v", err, params.ErrNotPresent)
+	}
+}
@@ -42,6 +42,18 @@ func NewDestinationUserPartUnavailable(nwApr, rtCtx, apcs, cause, info *params.P
 	return d
 }
 
+// NewDestinationUserPartUnavailableWithMask creates a new DestinationUserPartUnavailable with the Affected Point Codes
+// given as the point codes with masks. The parameter is omitted if apcs is empty.
+func NewDestinationUserPartUnavailableWithMask(nwApr, rtCtx *params.Param, apcs []params.PointCodeWithMask, cause, info *params.Param) *DestinationUserPartUnavailable {
+	return NewDestinationUserPartUnavailable(nwApr, rtCtx, newAffectedPointCode(apcs), cause, info)
+}
+
+// AffectedPointCodes returns the point codes with masks in the Affected
+// Point Code parameter of the DestinationUserPartUnavailable.
+func (d *DestinationUserPartUnavailable) AffectedPointCodes() (params.PointCodeList, error) {
+	return d.AffectedPointCode.AffectedPointCodesWithMask()
+}
+
 // MarshalBinary returns the byte sequence generated from a DestinationUserPartUnavailable.
 func (d *DestinationUserPartUnavailable) MarshalBinary() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -57,39 +69,38 @@ func (d *DestinationUserPartUnavailable) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	d.Header.Payload = make([]byte, d.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := d.NetworkAppearance; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.RoutingContext; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.AffectedPointCode; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.UserCause; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := d.InfoString; param != nil {
-		if err := param.MarshalTo(d.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
-	return d.Header.MarshalTo(b)
+	d.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseDestinationUserPartUnavailable decodes given byte sequence as a DestinationUserPartUnavailable.
@@ -109,7 +120,7 @@ func (d *DestinationUserPartUnavailable) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(d.Header.Payload)
+	prs, err := parseParams(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -174,6 +185,33 @@ func (d *DestinationUserPartUnavailable) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the DestinationUserPartUnavailable is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (d *DestinationUserPartUnavailable) Validate() error {
+	return validate(d, d.Header, d.NetworkAppearance, d.RoutingContext, d.AffectedPointCode, d.UserCause, d.InfoString)
+}
+
+// MarshalJSON returns the JSON encoding of DestinationUserPartUnavailable.
+func (d *DestinationUserPartUnavailable) MarshalJSON() ([]byte, error) {
+	return marshalJSON(d)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of DestinationUserPartUnavailable.
+func (d *DestinationUserPartUnavailable) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, d)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of DestinationUserPartUnavailable,
+// which has the same structure as the JSON encoding.
+func (d *DestinationUserPartUnavailable) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(d)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of DestinationUserPartUnavailable.
+func (d *DestinationUserPartUnavailable) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, d)
+}
+
 // Version returns the version of M3UA in int.
 func (d *DestinationUserPartUnavailable) Version() uint8 {
 	return d.Header.Version
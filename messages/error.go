@@ -43,6 +43,18 @@ func NewError(code, rtCtx, nwApr, apc, info *params.Param) *Error {
 	return e
 }
 
+// NewErrorWithMask creates a new Error with the Affected Point Codes
+// given as the point codes with masks. The parameter is omitted if apcs is empty.
+func NewErrorWithMask(code, rtCtx, nwApr *params.Param, apcs []params.PointCodeWithMask, info *params.Param) *Error {
+	return NewError(code, rtCtx, nwApr, newAffectedPointCode(apcs), info)
+}
+
+// AffectedPointCodes returns the point codes with masks in the Affected
+// Point Code parameter of the Error.
+func (e *Error) AffectedPointCodes() (params.PointCodeList, error) {
+	return e.AffectedPointCode.AffectedPointCodesWithMask()
+}
+
 // MarshalBinary returns the byte sequence generated from a Error.
 func (e *Error) MarshalBinary() ([]byte, error) {
 	b := make([]byte, e.MarshalLen())
@@ -58,44 +70,43 @@ func (e *Error) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	e.Header.Payload = make([]byte, e.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := e.ErrorCode; param != nil {
-		if err := param.MarshalTo(e.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := e.RoutingContext; param != nil {
-		if err := param.MarshalTo(e.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := e.NetworkAppearance; param != nil {
-		if err := param.MarshalTo(e.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := e.AffectedPointCode; param != nil {
-		if err := param.MarshalTo(e.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := e.DiagnosticInformation; param != nil {
-		if err := param.MarshalTo(e.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return e.Header.MarshalTo(b)
+	e.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseError decodes given byte sequence as a Error.
@@ -115,7 +126,7 @@ func (e *Error) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(e.Header.Payload)
+	prs, err := parseParams(e.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -180,6 +191,34 @@ func (e *Error) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the Error is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+//
+// The parameters that are conditionally required by the Error Code are also checked.
+func (e *Error) Validate() error {
+	if err := validate(e, e.Header, e.ErrorCode, e.RoutingContext, e.NetworkAppearance, e.AffectedPointCode, e.DiagnosticInformation); err != nil {
+		return err
+	}
+
+	var required *params.Param
+	var tag uint16
+	switch e.ErrorCode.ErrorCode() {
+	case params.ErrInvalidRoutingContext:
+		required, tag = e.RoutingContext, params.RoutingContext
+	case params.ErrInvalidNetworkAppearance:
+		required, tag = e.NetworkAppearance, params.NetworkAppearance
+	case params.ErrDestinationStatusUnknown:
+		required, tag = e.AffectedPointCode, params.AffectedPointCode
+	default:
+		return nil
+	}
+	if required == nil {
+		return newValidationError(params.ErrMissingParameter, tag, "missing conditional parameter: %d", tag)
+	}
+
+	return nil
+}
+
 // String returns the Error values in human readable format.
 func (e *Error) String() string {
 	return fmt.Sprintf("{Header: %s, ErrorCode: %s, RoutingContext: %s, NetworkAppearance: %s, AffectedPointCode: %s, DiagnosticInformation: %s}",
@@ -192,6 +231,27 @@ func (e *Error) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of Error.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return marshalJSON(e)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Error.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, e)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Error,
+// which has the same structure as the JSON encoding.
+func (e *Error) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(e)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Error.
+func (e *Error) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, e)
+}
+
 // Version returns the version of M3UA in int.
 func (e *Error) Version() uint8 {
 	return e.Header.Version
@@ -0,0 +1,152 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+var fuzzSeeds = []M3UA{
+	NewAspUp(params.NewAspIdentifier(1), params.NewInfoString("deadbeef")),
+	NewAspUpAck(params.NewAspIdentifier(1), params.NewInfoString("deadbeef")),
+	NewAspDown(params.NewInfoString("deadbeef")),
+	NewAspDownAck(params.NewInfoString("deadbeef")),
+	NewHeartbeat(params.NewHeartbeatData([]byte("deadbeef"))),
+	NewHeartbeatAck(params.NewHeartbeatData([]byte("deadbeef"))),
+	NewAspActive(params.NewTrafficModeType(2), params.NewRoutingContext(1, 2), params.NewInfoString("deadbeef")),
+	NewAspActiveAck(params.NewTrafficModeType(2), params.NewRoutingContext(1, 2), params.NewInfoString("deadbeef")),
+	NewAspInactive(params.NewRoutingContext(1, 2), params.NewInfoString("deadbeef")),
+	NewAspInactiveAck(params.NewRoutingContext(1, 2), params.NewInfoString("deadbeef")),
+	NewData(
+		params.NewNetworkAppearance(1), params.NewRoutingContext(1),
+		params.NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad, 0xbe, 0xef}),
+		params.NewCorrelationID(1),
+	),
+	NewDestinationUnavailable(params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1, 2), params.NewInfoString("deadbeef")),
+	NewDestinationAvailable(params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1, 2), params.NewInfoString("deadbeef")),
+	NewDestinationStateAudit(params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1, 2), params.NewInfoString("deadbeef")),
+	NewDestinationRestricted(params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1, 2), params.NewInfoString("deadbeef")),
+	NewSignallingCongestion(
+		params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1, 2),
+		params.NewConcernedDestination(1), params.NewCongestionIndications(1), params.NewInfoString("deadbeef"),
+	),
+	NewDestinationUserPartUnavailable(
+		params.NewNetworkAppearance(1), params.NewRoutingContext(1), params.NewAffectedPointCode(1),
+		params.NewUserCause(params.UserIdentityUnknown, params.SCCP), params.NewInfoString("deadbeef"),
+	),
+	NewError(
+		params.NewErrorCode(params.ErrInvalidRoutingContext), params.NewRoutingContext(1), params.NewNetworkAppearance(1),
+		params.NewAffectedPointCode(1), params.NewDiagnosticInformation([]byte("deadbeef")),
+	),
+	NewNotify(params.NewStatus(params.AsStateActive), params.NewAspIdentifier(1), params.NewRoutingContext(1), params.NewInfoString("deadbeef")),
+	New(1, 0x0a, 0x01, params.NewParam(0x8001, []byte{0xde, 0xad, 0xbe, 0xef})),
+}
+
+// decoders are all the exported decoders of the message types, which are
+// fed with the same input regardless of the class and type in it.
+var decoders = map[string]func([]byte) (M3UA, error){
+	"AspUp":                          func(b []byte) (M3UA, error) { return ParseAspUp(b) },
+	"AspUpAck":                       func(b []byte) (M3UA, error) { return ParseAspUpAck(b) },
+	"AspDown":                        func(b []byte) (M3UA, error) { return ParseAspDown(b) },
+	"AspDownAck":                     func(b []byte) (M3UA, error) { return ParseAspDownAck(b) },
+	"Heartbeat":                      func(b []byte) (M3UA, error) { return ParseHeartbeat(b) },
+	"HeartbeatAck":                   func(b []byte) (M3UA, error) { return ParseHeartbeatAck(b) },
+	"AspActive":                      func(b []byte) (M3UA, error) { return ParseAspActive(b) },
+	"AspActiveAck":                   func(b []byte) (M3UA, error) { return ParseAspActiveAck(b) },
+	"AspInactive":                    func(b []byte) (M3UA, error) { return ParseAspInactive(b) },
+	"AspInactiveAck":                 func(b []byte) (M3UA, error) { return ParseAspInactiveAck(b) },
+	"Data":                           func(b []byte) (M3UA, error) { return ParseData(b) },
+	"DestinationUnavailable":         func(b []byte) (M3UA, error) { return ParseDestinationUnavailable(b) },
+	"DestinationAvailable":           func(b []byte) (M3UA, error) { return ParseDestinationAvailable(b) },
+	"DestinationStateAudit":          func(b []byte) (M3UA, error) { return ParseDestinationStateAudit(b) },
+	"DestinationRestricted":          func(b []byte) (M3UA, error) { return ParseDestinationRestricted(b) },
+	"SignallingCongestion":           func(b []byte) (M3UA, error) { return ParseSignallingCongestion(b) },
+	"DestinationUserPartUnavailable": func(b []byte) (M3UA, error) { return ParseDestinationUserPartUnavailable(b) },
+	"Error":                          func(b []byte) (M3UA, error) { return ParseError(b) },
+	"Notify":                         func(b []byte) (M3UA, error) { return ParseNotify(b) },
+	"Generic":                        func(b []byte) (M3UA, error) { return ParseGeneric(b) },
+	"Parse":                          Parse,
+	"ParseStrict":                    ParseStrict,
+}
+
+func addFuzzSeeds(f *testing.F) {
+	f.Helper()
+
+	for _, m := range fuzzSeeds {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		for name, decode := range decoders {
+			m, err := decode(b)
+			if err != nil {
+				continue
+			}
+
+			// anything decoded successfully should be encoded and decoded again.
+			encoded, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: failed to encode decoded message: %v", name, err)
+			}
+			if _, err := decode(encoded); err != nil {
+				t.Fatalf("%s: failed to decode encoded message %x: %v", name, encoded, err)
+			}
+
+			// and also through JSON, into the identical bytes.
+			if name == "Parse" {
+				j, err := json.Marshal(m)
+				if err != nil {
+					t.Fatalf("failed to encode decoded message into JSON: %v", err)
+				}
+				parsed, err := ParseJSON(j)
+				if err != nil {
+					t.Fatalf("failed to decode JSON %s: %v", j, err)
+				}
+				if b, err := parsed.MarshalBinary(); err != nil || !bytes.Equal(b, encoded) {
+					t.Fatalf("got %x, want %x, json: %s", b, encoded, j)
+				}
+			}
+
+			_ = m.MarshalLen()
+			if s, ok := m.(interface{ String() string }); ok {
+				_ = s.String()
+			}
+			if v, ok := m.(interface{ Validate() error }); ok {
+				_ = v.Validate()
+			}
+		}
+	})
+}
+
+func FuzzParseHeader(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		h, err := ParseHeader(b)
+		if err != nil {
+			return
+		}
+
+		encoded, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode decoded header: %v", err)
+		}
+		if _, err := ParseHeader(encoded); err != nil {
+			t.Fatalf("failed to decode encoded header %x: %v", encoded, err)
+		}
+	})
+}
@@ -5,6 +5,7 @@
 package messages
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -51,17 +52,16 @@ func (g *Generic) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	g.Header.Payload = make([]byte, g.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	for _, pr := range g.Params {
-		if err := pr.MarshalTo(g.Header.Payload[offset:]); err != nil {
+		if err := pr.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += pr.MarshalLen()
 	}
 
-	return g.Header.MarshalTo(b)
+	g.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseGeneric decodes given byte sequence as a M3UA Generic message.
@@ -98,6 +98,17 @@ func (g *Generic) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the Generic is valid in terms of the length of the
+// parameters and the Length field in the header. If the class and type in the
+// header are the known ones, the presence and order of the parameters are
+// also checked.
+func (g *Generic) Validate() error {
+	if g.Header == nil {
+		return newValidationError(params.ErrProtocolError, 0, "no header")
+	}
+	return validateParams(g.Header, g.MarshalLen(), g.Params)
+}
+
 // SetLength sets the length in Length field.
 func (g *Generic) SetLength() {
 	for _, pr := range g.Params {
@@ -120,6 +131,45 @@ func (g *Generic) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of Generic.
+func (g *Generic) MarshalJSON() ([]byte, error) {
+	return marshalJSON(g)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Generic.
+// Unlike the other messages, the Message Class and Message Type are required.
+func (g *Generic) UnmarshalJSON(b []byte) error {
+	var j messageJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	raw, err := j.marshalBinary()
+	if err != nil {
+		return err
+	}
+	return g.UnmarshalBinary(raw)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Generic,
+// which has the same structure as the JSON encoding.
+func (g *Generic) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(g)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Generic.
+// Unlike the other messages, the Message Class and Message Type are required.
+func (g *Generic) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var j messageJSON
+	if err := unmarshal(&j); err != nil {
+		return err
+	}
+	raw, err := j.marshalBinary()
+	if err != nil {
+		return err
+	}
+	return g.UnmarshalBinary(raw)
+}
+
 // Version returns the version of M3UA in int.
 func (g *Generic) Version() uint8 {
 	return g.Header.Version
@@ -50,14 +50,21 @@ func (h *Header) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
+	h.marshalFieldsTo(b)
+	copy(b[8:h.MarshalLen()], h.Payload)
+
+	return nil
+}
+
+// marshalFieldsTo puts the fixed-length fields of the header in b, without
+// Payload. This is used by the messages that put their parameters directly
+// in b, so that they don't need to allocate Payload in every marshal.
+func (h *Header) marshalFieldsTo(b []byte) {
 	b[0] = h.Version
 	b[1] = h.Reserved
 	b[2] = h.Class
 	b[3] = h.Type
 	binary.BigEndian.PutUint32(b[4:8], h.Length)
-	copy(b[8:h.MarshalLen()], h.Payload)
-
-	return nil
 }
 
 // ParseHeader decodes given byte sequence as a M3UA common header.
@@ -81,7 +88,10 @@ func (h *Header) UnmarshalBinary(b []byte) error {
 	h.Class = b[2]
 	h.Type = b[3]
 	h.Length = binary.BigEndian.Uint32(b[4:8])
-	h.Payload = b[8:l]
+	if h.Length < 8 || int(h.Length) > l {
+		return ErrInvalidLength
+	}
+	h.Payload = b[8:h.Length]
 
 	return nil
 }
@@ -51,15 +51,14 @@ func (h *HeartbeatAck) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	h.Header.Payload = make([]byte, h.MarshalLen()-8)
-
 	if param := h.HeartbeatData; param != nil {
-		if err := param.MarshalTo(h.Header.Payload); err != nil {
+		if err := param.MarshalTo(b[8:]); err != nil {
 			return err
 		}
 	}
 
-	return h.Header.MarshalTo(b)
+	h.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseHeartbeatAck decodes given byte sequence as a HeartbeatAck.
@@ -79,7 +78,7 @@ func (h *HeartbeatAck) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(h.Header.Payload)
+	prs, err := parseParams(h.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -112,6 +111,12 @@ func (h *HeartbeatAck) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the HeartbeatAck is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (h *HeartbeatAck) Validate() error {
+	return validate(h, h.Header, h.HeartbeatData)
+}
+
 // String returns the HeartbeatAck values in human readable format.
 func (h *HeartbeatAck) String() string {
 	return fmt.Sprintf("{Header: %s, HeartbeatData: %s}",
@@ -120,6 +125,27 @@ func (h *HeartbeatAck) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of HeartbeatAck.
+func (h *HeartbeatAck) MarshalJSON() ([]byte, error) {
+	return marshalJSON(h)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of HeartbeatAck.
+func (h *HeartbeatAck) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, h)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of HeartbeatAck,
+// which has the same structure as the JSON encoding.
+func (h *HeartbeatAck) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(h)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of HeartbeatAck.
+func (h *HeartbeatAck) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, h)
+}
+
 // Version returns the version of M3UA in int.
 func (h *HeartbeatAck) Version() uint8 {
 	return h.Header.Version
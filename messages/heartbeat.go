@@ -51,15 +51,14 @@ func (h *Heartbeat) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	h.Header.Payload = make([]byte, h.MarshalLen()-8)
-
 	if param := h.HeartbeatData; param != nil {
-		if err := param.MarshalTo(h.Header.Payload); err != nil {
+		if err := param.MarshalTo(b[8:]); err != nil {
 			return err
 		}
 	}
 
-	return h.Header.MarshalTo(b)
+	h.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseHeartbeat decodes given byte sequence as a Heartbeat.
@@ -79,7 +78,7 @@ func (h *Heartbeat) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(h.Header.Payload)
+	prs, err := parseParams(h.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -112,6 +111,12 @@ func (h *Heartbeat) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the Heartbeat is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (h *Heartbeat) Validate() error {
+	return validate(h, h.Header, h.HeartbeatData)
+}
+
 // String returns the Heartbeat values in human readable format.
 func (h *Heartbeat) String() string {
 	return fmt.Sprintf("{Header: %s, HeartbeatData: %s}",
@@ -120,6 +125,27 @@ func (h *Heartbeat) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of Heartbeat.
+func (h *Heartbeat) MarshalJSON() ([]byte, error) {
+	return marshalJSON(h)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Heartbeat.
+func (h *Heartbeat) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, h)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Heartbeat,
+// which has the same structure as the JSON encoding.
+func (h *Heartbeat) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(h)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Heartbeat.
+func (h *Heartbeat) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, h)
+}
+
 // Version returns the version of M3UA in int.
 func (h *Heartbeat) Version() uint8 {
 	return h.Header.Version
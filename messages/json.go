@@ -0,0 +1,141 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// messageJSON is the structure of the messages in JSON and YAML.
+//
+// The parameters are in the same order as they are on the wire, and the Length
+// in the header is omitted as it is always calculated from the parameters.
+type messageJSON struct {
+	Version   *uint8          `json:"version,omitempty" yaml:"version,omitempty"`
+	Reserved  uint8           `json:"reserved,omitempty" yaml:"reserved,omitempty"`
+	Class     *uint8          `json:"class" yaml:"class"`
+	Type      *uint8          `json:"type" yaml:"type"`
+	ClassName string          `json:"class_name,omitempty" yaml:"class_name,omitempty"`
+	TypeName  string          `json:"type_name,omitempty" yaml:"type_name,omitempty"`
+	Params    []*params.Param `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+func newMessageJSON(m M3UA) (*messageJSON, error) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := params.ParseMultiParams(h.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messageJSON{
+		Version:   &h.Version,
+		Reserved:  h.Reserved,
+		Class:     &h.Class,
+		Type:      &h.Type,
+		ClassName: m.MessageClassName(),
+		TypeName:  m.MessageTypeName(),
+		Params:    prs,
+	}, nil
+}
+
+// marshalBinary returns the byte sequence of the message. The Version is 1
+// if omitted.
+func (j *messageJSON) marshalBinary() ([]byte, error) {
+	if j.Class == nil || j.Type == nil {
+		return nil, errors.New("class and type are required")
+	}
+
+	payload, err := params.MarshalMultiParams(j.Params)
+	if err != nil {
+		return nil, err
+	}
+	h := &Header{
+		Version:  1,
+		Reserved: j.Reserved,
+		Class:    *j.Class,
+		Type:     *j.Type,
+		Payload:  payload,
+	}
+	if j.Version != nil {
+		h.Version = *j.Version
+	}
+	h.SetLength()
+
+	return h.MarshalBinary()
+}
+
+// decodeInto decodes the message into m. The Class and Type can be omitted
+// as they are implied by the type of m.
+func (j *messageJSON) decodeInto(m M3UA) error {
+	class, mtype := m.MessageClass(), m.MessageType()
+	if j.Class == nil {
+		j.Class = &class
+	}
+	if j.Type == nil {
+		j.Type = &mtype
+	}
+	if *j.Class != class || *j.Type != mtype {
+		return fmt.Errorf("class/type %d/%d does not match %s", *j.Class, *j.Type, m.MessageTypeName())
+	}
+
+	b, err := j.marshalBinary()
+	if err != nil {
+		return err
+	}
+	return m.UnmarshalBinary(b)
+}
+
+func marshalJSON(m M3UA) ([]byte, error) {
+	j, err := newMessageJSON(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+func unmarshalJSON(b []byte, m M3UA) error {
+	var j messageJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	return j.decodeInto(m)
+}
+
+func unmarshalYAML(unmarshal func(interface{}) error, m M3UA) error {
+	var j messageJSON
+	if err := unmarshal(&j); err != nil {
+		return err
+	}
+	return j.decodeInto(m)
+}
+
+// ParseJSON decodes the JSON encoding of any M3UA message, which is generated
+// by MarshalJSON of the messages.
+//
+// Like Parse, this function checks the Message Class and Message Type and
+// chooses the appropriate type.
+func ParseJSON(b []byte) (M3UA, error) {
+	var j messageJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+
+	raw, err := j.marshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw)
+}
@@ -0,0 +1,174 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+	"gopkg.in/yaml.v3"
+)
+
+// roundTripSeeds are the messages encoded and decoded in the round trip tests,
+// including the ones whose parameters can't be decoded into values.
+func roundTripSeeds() []M3UA {
+	return append(append([]M3UA{}, fuzzSeeds...),
+		NewAspUp(params.NewParam(int(params.AspIdentifier), []byte{0x01}), nil),
+		NewNotify(params.NewStatus(0x00030001), nil, nil, params.NewInfoString("\xff\xfe")),
+	)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, m := range roundTripSeeds() {
+		t.Run(m.MessageTypeName(), func(t *testing.T) {
+			want, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			j, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := ParseJSON(j)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", j, err)
+			}
+			if reflect.TypeOf(parsed) != reflect.TypeOf(m) {
+				t.Errorf("got %T, want %T", parsed, m)
+			}
+			got, err := parsed.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("ParseJSON: got %x, want %x, json: %s", got, want, j)
+			}
+
+			typed := reflect.New(reflect.TypeOf(m).Elem()).Interface().(M3UA)
+			if err := json.Unmarshal(j, typed); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", j, err)
+			}
+			got, err = typed.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("UnmarshalJSON: got %x, want %x, json: %s", got, want, j)
+			}
+		})
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	for _, m := range roundTripSeeds() {
+		t.Run(m.MessageTypeName(), func(t *testing.T) {
+			want, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			y, err := yaml.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			typed := reflect.New(reflect.TypeOf(m).Elem()).Interface().(M3UA)
+			if err := yaml.Unmarshal(y, typed); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", y, err)
+			}
+			got, err := typed.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("UnmarshalYAML: got %x, want %x, yaml: %s", got, want, y)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	j, err := json.Marshal(NewNotify(params.NewStatus(params.AsStateActive), nil, params.NewRoutingContext(1, 2), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"version":1,"class":0,"type":1,"class_name":"Management","type_name":"Notify","params":[` +
+		`{"tag":13,"name":"Status","value":"AS-Active"},` +
+		`{"tag":6,"name":"Routing Context","value":[1,2]}]}`
+	if string(j) != want {
+		t.Errorf("got %s, want %s", j, want)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	// hand-written one, with names instead of tags and class/type omitted.
+	j := `{"params": [
+		{"name": "Routing Context", "value": [1]},
+		{"name": "Protocol Data", "value": {"opc": 1, "dpc": 2, "si": 3, "ni": 1, "mp": 0, "sls": 1, "data": "deadbeef"}}
+	]}`
+	want, err := NewData(
+		nil, params.NewRoutingContext(1),
+		params.NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad, 0xbe, 0xef}),
+		nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Data{}
+	if err := json.Unmarshal([]byte(j), d); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %x, want %x", got, want)
+	}
+
+	err = json.Unmarshal([]byte(`{"class": 3, "type": 1}`), d)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("unexpected error with mismatching class/type: %v", err)
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	// hand-written one, with names instead of tags and class/type omitted.
+	y := `
+params:
+  - name: Routing Context
+    value: [1]
+  - name: Protocol Data
+    value: {opc: 1, dpc: 2, si: 3, ni: 1, mp: 0, sls: 1, data: deadbeef}
+`
+	want, err := NewData(
+		nil, params.NewRoutingContext(1),
+		params.NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad, 0xbe, 0xef}),
+		nil,
+	).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Data{}
+	if err := yaml.Unmarshal([]byte(y), d); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %x, want %x", got, want)
+	}
+}
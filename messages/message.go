@@ -7,6 +7,8 @@ package messages
 import (
 	"errors"
 	"log"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
 )
 
 // Message Class definitions.
@@ -110,72 +112,37 @@ func MarshalBinary(m M3UA) ([]byte, error) {
 }
 
 // Parse decodes the given bytes.
-// This function checks the Message Class and Message Type and chooses the appropriate type.
+// This function checks the Message Class and Message Type and chooses the appropriate type,
+// including the ones registered with Register.
 func Parse(b []byte) (M3UA, error) {
 	if len(b) < 4 {
 		return nil, ErrTooShortToParse
 	}
-	var m M3UA
-	combine := func(c, t uint8) uint16 {
-		return uint16(c<<4 | t)
-	}
-	t := combine(b[2], b[3])
-
-	switch t {
-	// Transfer Messages
-	case combine(MsgClassTransfer, MsgTypePayloadData):
-		m = &Data{}
-		// SSNM Messages
-	case combine(MsgClassSSNM, MsgTypeDestinationUnavailable):
-		m = &DestinationUnavailable{}
-	case combine(MsgClassSSNM, MsgTypeDestinationAvailable):
-		m = &DestinationAvailable{}
-	case combine(MsgClassSSNM, MsgTypeDestinationStateAudit):
-		m = &DestinationStateAudit{}
-	case combine(MsgClassSSNM, MsgTypeSignallingCongestion):
-		m = &SignallingCongestion{}
-	case combine(MsgClassSSNM, MsgTypeDestinationUserPartUnavailable):
-		m = &DestinationUserPartUnavailable{}
-	case combine(MsgClassSSNM, MsgTypeDestinationRestricted):
-		m = &DestinationRestricted{}
-		// ASPSM Messages
-	case combine(MsgClassASPSM, MsgTypeAspUp):
-		m = &AspUp{}
-	case combine(MsgClassASPSM, MsgTypeAspDown):
-		m = &AspDown{}
-	case combine(MsgClassASPSM, MsgTypeHeartbeat):
-		m = &Heartbeat{}
-	case combine(MsgClassASPSM, MsgTypeAspUpAck):
-		m = &AspUpAck{}
-	case combine(MsgClassASPSM, MsgTypeAspDownAck):
-		m = &AspDownAck{}
-	case combine(MsgClassASPSM, MsgTypeHeartbeatAck):
-		m = &HeartbeatAck{}
-	// ASPTM Messages
-	case combine(MsgClassASPTM, MsgTypeAspActive):
-		m = &AspActive{}
-	case combine(MsgClassASPTM, MsgTypeAspActiveAck):
-		m = &AspActiveAck{}
-	case combine(MsgClassASPTM, MsgTypeAspInactive):
-		m = &AspInactive{}
-	case combine(MsgClassASPTM, MsgTypeAspInactiveAck):
-		m = &AspInactiveAck{}
-	// Management Messages
-	case combine(MsgClassManagement, MsgTypeError):
-		m = &Error{}
-	case combine(MsgClassManagement, MsgTypeNotify):
-		m = &Notify{}
-	default:
-		// If the combination of class and type is unknown or not supported, *Generic is used.
-		m = &Generic{}
-	}
-
+	m := newMessage(b[2], b[3])
 	if err := m.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+// parseParams decodes the parameters in a message. Unlike Generic, the specific
+// message types can contain each parameter only once.
+func parseParams(b []byte) ([]*params.Param, error) {
+	prs, err := params.ParseMultiParams(b)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range prs {
+		for _, q := range prs[:i] {
+			if p.Tag == q.Tag {
+				return nil, ErrInvalidParameter
+			}
+		}
+	}
+	return prs, nil
+}
+
 // Decode decodes the given bytes.
 // This function checks the Message Class and Message Type and chooses the appropriate type.
 //
@@ -190,4 +157,14 @@ var (
 	ErrTooShortToMarshalBinary = errors.New("insufficient buffer to serialize M3UA to")
 	ErrTooShortToParse         = errors.New("too short to decode as M3UA")
 	ErrInvalidParameter        = errors.New("got invalid parameter inside a message")
+	ErrInvalidLength           = errors.New("message has invalid length value")
 )
+
+// newAffectedPointCode returns the Affected Point Code parameter created from
+// apcs, or nil if apcs is empty as the parameter is optional in some messages.
+func newAffectedPointCode(apcs []params.PointCodeWithMask) *params.Param {
+	if len(apcs) == 0 {
+		return nil
+	}
+	return params.NewAffectedPointCodeWithMask(apcs...)
+}
@@ -17,6 +17,16 @@ func TestParseMalformed(t *testing.T) {
 		{[]byte{0x00, 0x00}, ErrTooShortToParse},
 		{[]byte{0x00, 0x00, 0x00}, ErrTooShortToParse},
 		{[]byte{0x00, 0x00, 0x00, 0x00}, ErrTooShortToParse},
+		// Length is shorter than the header
+		{[]byte{0x01, 0x00, 0x03, 0x01, 0x00, 0x00, 0x00, 0x04}, ErrInvalidLength},
+		// Length is longer than the actual message
+		{[]byte{0x01, 0x00, 0x03, 0x01, 0x00, 0x00, 0x00, 0x10}, ErrInvalidLength},
+		// duplicated Info String
+		{[]byte{
+			0x01, 0x00, 0x03, 0x01, 0x00, 0x00, 0x00, 0x18,
+			0x00, 0x04, 0x00, 0x08, 0x61, 0x62, 0x63, 0x64,
+			0x00, 0x04, 0x00, 0x08, 0x61, 0x62, 0x63, 0x64,
+		}, ErrInvalidParameter},
 	}
 
 	for _, c := range cases {
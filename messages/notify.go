@@ -56,45 +56,45 @@ func (n *Notify) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	n.Header.Payload = make([]byte, n.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 
 	// NOTE:
 	// Precisely, it should validate whether the `Status` parameter exists or not because
 	// the `Status` parameter has to be contained in the `Notify` message.
 	// (ref: https://tools.ietf.org/html/rfc4666#section-3.8.2)
 	// However, this library aims to be flexible using and/or verifying,
-	// so it doesn't check the existence of the parameter for now.
+	// so it doesn't check the existence of the parameter here.
 	// Discussion: https://github.com/wmnsk/go-m3ua/pull/10#discussion_r304225571
+	// Use Validate() explicitly to check it.
 	if param := n.Status; param != nil {
-		if err := param.MarshalTo(n.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := n.AspIdentifier; param != nil {
-		if err := param.MarshalTo(n.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := n.RoutingContext; param != nil {
-		if err := param.MarshalTo(n.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 
 	if param := n.InfoString; param != nil {
-		if err := param.MarshalTo(n.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return n.Header.MarshalTo(b)
+	n.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseNotify decodes given byte sequence as a Notify.
@@ -114,7 +114,7 @@ func (n *Notify) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(n.Header.Payload)
+	prs, err := parseParams(n.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -172,6 +172,12 @@ func (n *Notify) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the Notify is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (n *Notify) Validate() error {
+	return validate(n, n.Header, n.Status, n.AspIdentifier, n.RoutingContext, n.InfoString)
+}
+
 // String returns the Notify values in human readable format.
 func (n *Notify) String() string {
 	return fmt.Sprintf("{Header: %s, Status: %s, AspIdentifier: %s, RoutingContext: %s, InfoString: %s}",
@@ -183,6 +189,27 @@ func (n *Notify) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of Notify.
+func (n *Notify) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Notify.
+func (n *Notify) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, n)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Notify,
+// which has the same structure as the JSON encoding.
+func (n *Notify) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(n)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Notify.
+func (n *Notify) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, n)
+}
+
 // Version returns the version of M3UA in int.
 func (n *Notify) Version() uint8 {
 	return n.Header.Version
@@ -4,24 +4,36 @@
 
 package params
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 // NewAffectedPointCode creates the AffectedPointCode Parameter.
 // Multiple number of AffectedPointCode will be accepted, but
 // the mask for each point code should be included inside arguments.
-// TODO: Handle masks and point codes separately.
+// Use NewAffectedPointCodeWithMask to handle masks and point codes separately.
 // Note that this returns *Param, as no specific structure in this parameter.
 func NewAffectedPointCode(apcs ...uint32) *Param {
 	return newMultiUint32ValParam(AffectedPointCode, apcs...)
 }
 
 // AffectedPointCode returns single AffectedPointCode from Param.
+// The mask is included in the most significant 8 bits.
 func (p *Param) AffectedPointCode() uint32 {
 	if p.Tag != AffectedPointCode {
 		return 0
 	}
-	return p.AffectedPointCodes()[0]
+	vs := p.AffectedPointCodes()
+	if len(vs) == 0 {
+		return 0
+	}
+	return vs[0]
 }
 
 // AffectedPointCodes returns multiple AffectedPointCode from Param.
+// The mask is included in the most significant 8 bits of each value, and
+// AffectedPointCodesWithMask should be used to handle them separately.
 func (p *Param) AffectedPointCodes() []uint32 {
 	if p.Tag != AffectedPointCode {
 		return nil
@@ -29,28 +41,157 @@ func (p *Param) AffectedPointCodes() []uint32 {
 	return p.decodeMultiUint32ValData()
 }
 
-/* TODO: Might be implemented in the following way?
-// PointCodeWithMask is a set of Mask and Point Code.
+// PointCodeWithMask is a set of Mask and Point Code, which is used in
+// the Affected Point Code parameter.
+//
+// The Mask is the number of the least significant bits in the Point Code
+// that are wildcarded, which means the PointCodeWithMask represents 2^Mask
+// point codes. E.g., the Mask 3 with the Point Code 0x1230 stands for the
+// point codes from 0x1230 to 0x1237, and the Mask 0 stands for the Point Code
+// only.
 type PointCodeWithMask struct {
 	Mask      uint8
 	PointCode uint32
 }
 
+// NewPointCodeWithMask creates a new PointCodeWithMask.
+// The Point Code is truncated to 24 bits.
+func NewPointCodeWithMask(mask uint8, pc uint32) PointCodeWithMask {
+	return PointCodeWithMask{Mask: mask, PointCode: pc & 0xffffff}
+}
+
 // MarshalBinary creates the 32bit-sized []byte from PointCodeWithMask.
 func (p *PointCodeWithMask) MarshalBinary() ([]byte, error) {
-	b := make([]byte, 4)
-	// to be written?
+	b := make([]byte, p.MarshalLen())
+	if err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (p *PointCodeWithMask) MarshalTo(b []byte) error {
+	if len(b) < p.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+	binary.BigEndian.PutUint32(b, p.Uint32())
+	return nil
+}
+
+// ParsePointCodeWithMask decodes given byte sequence as a PointCodeWithMask.
+func ParsePointCodeWithMask(b []byte) (*PointCodeWithMask, error) {
+	p := &PointCodeWithMask{}
+	if err := p.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a PointCodeWithMask.
+func (p *PointCodeWithMask) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return ErrTooShortToParse
+	}
+	p.Mask = b[0]
+	p.PointCode = binary.BigEndian.Uint32(b) & 0xffffff
+	return nil
+}
+
+// MarshalLen returns the serial length of PointCodeWithMask.
+func (p *PointCodeWithMask) MarshalLen() int {
+	return 4
+}
+
+// Uint32 returns the PointCodeWithMask in the format used in the parameter,
+// with the Mask in the most significant 8 bits.
+func (p *PointCodeWithMask) Uint32() uint32 {
+	return uint32(p.Mask)<<24 | p.PointCode&0xffffff
+}
+
+// Expand returns all the point codes represented by the PointCodeWithMask,
+// in ascending order. The Mask larger than 24 is treated as 24, and be aware
+// that it results in 2^24 point codes.
+func (p *PointCodeWithMask) Expand() []uint32 {
+	first, last := p.bounds()
+	pcs := make([]uint32, 0, last-first+1)
+	for pc := first; pc <= last; pc++ {
+		pcs = append(pcs, pc)
+	}
+	return pcs
+}
+
+// Contains reports whether the point code given is one of the point codes
+// represented by the PointCodeWithMask.
+func (p *PointCodeWithMask) Contains(pc uint32) bool {
+	first, last := p.bounds()
+	pc &= 0xffffff
+	return pc >= first && pc <= last
+}
+
+// String returns the PointCodeWithMask in human readable format.
+func (p *PointCodeWithMask) String() string {
+	return fmt.Sprintf("{Mask: %d, PointCode: %d}", p.Mask, p.PointCode)
+}
+
+func (p *PointCodeWithMask) bounds() (uint32, uint32) {
+	mask := p.Mask
+	if mask > 24 {
+		mask = 24
+	}
+	wildcard := uint32(1)<<mask - 1
+	first := p.PointCode & 0xffffff &^ wildcard
+	return first, first | wildcard
+}
+
+// PointCodeList is the list of the point codes with masks, which is used in
+// the Affected Point Code and Originating Point Code List parameters.
+type PointCodeList []PointCodeWithMask
+
+// Contains reports whether the point code given is represented by any of
+// the point codes with masks in the list.
+func (l PointCodeList) Contains(pc uint32) bool {
+	for i := range l {
+		if l[i].Contains(pc) {
+			return true
+		}
+	}
+	return false
+}
+
+func newPointCodeListParam(tag uint16, pcs []PointCodeWithMask) *Param {
+	vs := make([]uint32, len(pcs))
+	for i := range pcs {
+		vs[i] = pcs[i].Uint32()
+	}
+	return newMultiUint32ValParam(tag, vs...)
+}
+
+func (p *Param) decodePointCodeList(tag uint16) (PointCodeList, error) {
+	vs, err := p.decodeUint32List(tag)
+	if err != nil {
+		return nil, err
+	}
+	pcs := make(PointCodeList, len(vs))
+	for i, v := range vs {
+		pcs[i] = PointCodeWithMask{Mask: uint8(v >> 24), PointCode: v & 0xffffff}
+	}
+	return pcs, nil
 }
 
-func (p *PointCodeWithMask) MarshalTo(b []bytes) error {
-	// to be written?
+// NewAffectedPointCodeWithMask creates the AffectedPointCode Parameter from
+// the point codes with masks.
+func NewAffectedPointCodeWithMask(apcs ...PointCodeWithMask) *Param {
+	return newPointCodeListParam(AffectedPointCode, apcs)
 }
 
-func (p *PointCodeWithMask) Parse(b []bytes) (*PointCodeWithMask, error) {
-	// to be written?
+// AffectedPointCodesWithMask returns the point codes with masks in the Param,
+// with the error if the Param is nil, of the other type, or malformed.
+func (p *Param) AffectedPointCodesWithMask() (PointCodeList, error) {
+	return p.decodePointCodeList(AffectedPointCode)
 }
 
-func (p *PointCodeWithMask) UnmarshalBinary(b []bytes) error {
-	// to be written?
+// DecodeAffectedPointCodes returns the Affected Point Codes in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeAffectedPointCodes() ([]uint32, error) {
+	return p.decodeUint32List(AffectedPointCode)
 }
-*/
@@ -17,3 +17,9 @@ func (p *Param) AspIdentifier() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeAspIdentifier returns the ASP Identifier in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeAspIdentifier() (uint32, error) {
+	return p.decodeUint32(AspIdentifier)
+}
@@ -17,3 +17,10 @@ func (p *Param) ConcernedDestination() uint32 {
 	}
 	return p.decodeUint32ValData() & 0xffffff
 }
+
+// DecodeConcernedDestination returns the Concerned Destination in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeConcernedDestination() (uint32, error) {
+	v, err := p.decodeUint32(ConcernedDestination)
+	return v & 0xffffff, err
+}
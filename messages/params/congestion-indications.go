@@ -17,3 +17,10 @@ func (p *Param) CongestionLevel() uint32 {
 	}
 	return p.decodeUint32ValData() & 0xff
 }
+
+// DecodeCongestionLevel returns the Congestion Level in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeCongestionLevel() (uint8, error) {
+	v, err := p.decodeUint32(CongestionIndications)
+	return uint8(v), err
+}
@@ -17,3 +17,9 @@ func (p *Param) CorrelationID() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeCorrelationID returns the Correlation ID in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeCorrelationID() (uint32, error) {
+	return p.decodeUint32(CorrelationID)
+}
@@ -31,8 +31,8 @@ func NewDeregistrationResult(dr *DeregResultPayload) *Param {
 
 // DeregistrationResult returns DeregResultPayload.
 func (p *Param) DeregistrationResult() (*DeregResultPayload, error) {
-	if p.Tag != DeregistrationResult {
-		return nil, ErrInvalidType
+	if err := p.checkTag(DeregistrationResult); err != nil {
+		return nil, err
 	}
 
 	d, err := ParseDeregResultPayload(p.Data)
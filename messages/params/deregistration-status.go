@@ -27,3 +27,9 @@ func (p *Param) DeregistrationStatus() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeDeregistrationStatus returns the Deregistration Status in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeDeregistrationStatus() (uint32, error) {
+	return p.decodeUint32(DeregistrationStatus)
+}
@@ -17,3 +17,10 @@ func (p *Param) DestinationPointCode() uint32 {
 	}
 	return p.decodeUint32ValData() & 0xffffff
 }
+
+// DecodeDestinationPointCode returns the Destination Point Code in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeDestinationPointCode() (uint32, error) {
+	v, err := p.decodeUint32(DestinationPointCode)
+	return v & 0xffffff, err
+}
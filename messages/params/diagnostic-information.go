@@ -17,3 +17,9 @@ func (p *Param) DiagnosticInformation() []byte {
 	}
 	return p.Data
 }
+
+// DecodeDiagnosticInformation returns the Diagnostic Information in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeDiagnosticInformation() ([]byte, error) {
+	return p.decodeBytes(DiagnosticInformation)
+}
@@ -48,3 +48,9 @@ func (p *Param) ErrorCode() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeErrorCode returns the Error Code in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeErrorCode() (uint32, error) {
+	return p.decodeUint32(ErrorCode)
+}
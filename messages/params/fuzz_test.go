@@ -0,0 +1,199 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"testing"
+)
+
+var fuzzSeeds = []*Param{
+	NewAspIdentifier(1),
+	NewTrafficModeType(TrafficModeLoadshare),
+	NewNetworkAppearance(1),
+	NewRoutingContext(1, 2, 3),
+	NewHeartbeatData([]byte("some information")),
+	NewErrorCode(InvalidVersionError),
+	NewUserCause(UserIdentityUnknown, SCCP),
+	NewStatus(AsStateActive),
+	NewAffectedPointCode(1, 2, 3),
+	NewConcernedDestination(1),
+	NewCorrelationID(1),
+	NewInfoString("some information"),
+	NewDiagnosticInformation([]byte("some information")),
+	NewCongestionIndications(1),
+	NewLocalRoutingKeyIdentifier(1),
+	NewDestinationPointCode(1),
+	NewOriginatingPointCodeList(1, 2, 3),
+	NewServiceIndicators(1, 2, 3, 4, 5),
+	NewRegistrationStatus(1),
+	NewDeregistrationStatus(1),
+	NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad, 0xbe, 0xef}),
+	NewRoutingKey(NewRoutingKeyPayload(
+		NewLocalRoutingKeyIdentifier(1), NewRoutingContext(1), NewTrafficModeType(TrafficModeLoadshare),
+		NewDestinationPointCode(1), NewNetworkAppearance(1), NewServiceIndicators(3), NewOriginatingPointCodeList(1),
+	)),
+	NewRegistrationResult(NewRegistrationResultPayload(
+		NewLocalRoutingKeyIdentifier(1), NewRegistrationStatus(1), NewRoutingContext(1),
+	)),
+	NewDeregistrationResult(NewDeregResultPayload(NewRoutingContext(1), NewDeregistrationStatus(1))),
+}
+
+var knownTags = []uint16{
+	InfoString, RoutingContext, DiagnosticInformation, HeartbeatData, TrafficModeType,
+	ErrorCode, Status, AspIdentifier, AffectedPointCode, CorrelationID,
+	NetworkAppearance, UserCause, CongestionIndications, ConcernedDestination, RoutingKey,
+	RegistrationResult, DeregistrationResult, LocalRoutingKeyIdentifier, DestinationPointCode,
+	ServiceIndicators, OriginatingPointCodeList, ProtocolData, RegistrationStatus, DeregistrationStatus,
+}
+
+func addFuzzSeeds(f *testing.F) {
+	f.Helper()
+
+	for _, p := range fuzzSeeds {
+		b, err := p.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+}
+
+// callAccessors calls all the accessors of Param with any tag, so that
+// none of them panics on any value.
+func callAccessors(p *Param) {
+	tag := p.Tag
+	defer func() { p.Tag = tag }()
+
+	for _, t := range knownTags {
+		p.Tag = t
+
+		_ = p.AffectedPointCode()
+		_ = p.AffectedPointCodes()
+		_ = p.AspIdentifier()
+		_ = p.ConcernedDestination()
+		_ = p.CongestionLevel()
+		_ = p.CorrelationID()
+		_, _ = p.DeregistrationResult()
+		_ = p.DeregistrationStatus()
+		_ = p.DestinationPointCode()
+		_ = p.DiagnosticInformation()
+		_ = p.ErrorCode()
+		_ = p.HeartbeatData()
+		_ = p.InfoString()
+		_ = p.LocalRoutingKeyIdentifier()
+		_ = p.NetworkAppearance()
+		_ = p.OriginatingPointCodeList()
+		_, _ = p.ProtocolData()
+		_, _ = p.RegistrationResult()
+		_ = p.RegistrationStatus()
+		_ = p.RoutingContext()
+		_ = p.RoutingContexts()
+		_, _ = p.RoutingKey()
+		_ = p.ServiceIndicators()
+		_ = p.Status()
+		_ = p.StatusInfo()
+		_ = p.StatusType()
+		_ = p.TrafficModeType()
+		_ = p.UnavailabilityCause()
+		_ = p.UserCause()
+		_ = p.UserIdentity()
+		_ = p.String()
+
+		_, _ = p.AffectedPointCodesWithMask()
+		_, _ = p.DecodeRoutingContexts()
+		_, _ = p.DecodeStatus()
+		_, _ = p.DecodeUserCause()
+		_, _ = p.OriginatingPointCodesWithMask()
+		_, _ = p.ServiceIndicatorList()
+		_, _ = p.Value()
+		if rk, err := p.RoutingKey(); err == nil {
+			_, _ = rk.Matches(1, 3, 2)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		p, err := Parse(b)
+		if err != nil {
+			return
+		}
+		callAccessors(p)
+
+		encoded, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode decoded param: %v", err)
+		}
+		if _, err := Parse(encoded); err != nil {
+			t.Fatalf("failed to decode encoded param %x: %v", encoded, err)
+		}
+
+		// JSON should be decoded back into the identical bytes.
+		j, err := p.MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to encode decoded param into JSON: %v", err)
+		}
+		q := &Param{}
+		if err := q.UnmarshalJSON(j); err != nil {
+			t.Fatalf("failed to decode JSON %s: %v", j, err)
+		}
+		if qb, err := q.MarshalBinary(); err != nil || !bytes.Equal(qb, encoded) {
+			t.Fatalf("got %x, want %x, json: %s", qb, encoded, j)
+		}
+
+		// MarshalTo should fail gracefully on the short buffer.
+		if err := p.MarshalTo(make([]byte, p.MarshalLen()-1)); err == nil {
+			t.Fatal("MarshalTo succeeded with short buffer")
+		}
+	})
+}
+
+func FuzzParseMultiParams(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		ps, err := ParseMultiParams(b)
+		if err != nil {
+			return
+		}
+		for _, p := range ps {
+			callAccessors(p)
+		}
+
+		encoded, err := MarshalMultiParams(ps)
+		if err != nil {
+			t.Fatalf("failed to encode decoded params: %v", err)
+		}
+		if _, err := ParseMultiParams(encoded); err != nil {
+			t.Fatalf("failed to decode encoded params %x: %v", encoded, err)
+		}
+	})
+}
+
+func FuzzParsePayloads(f *testing.F) {
+	for _, p := range fuzzSeeds {
+		f.Add(p.Data)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if pd, err := ParseProtocolDataPayload(b); err == nil {
+			encoded, err := pd.MarshalBinary()
+			if err != nil {
+				t.Fatalf("failed to encode decoded ProtocolDataPayload: %v", err)
+			}
+			if _, err := ParseProtocolDataPayload(encoded); err != nil {
+				t.Fatalf("failed to decode encoded ProtocolDataPayload %x: %v", encoded, err)
+			}
+			_ = pd.String()
+		}
+
+		_, _ = ParseRoutingKeyPayload(b)
+		_, _ = ParseRegistrationResultPayload(b)
+		_, _ = ParseDeregResultPayload(b)
+	})
+}
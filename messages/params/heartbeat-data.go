@@ -17,3 +17,9 @@ func (p *Param) HeartbeatData() []byte {
 	}
 	return p.Data
 }
+
+// DecodeHeartbeatData returns the Heartbeat Data in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeHeartbeatData() ([]byte, error) {
+	return p.decodeBytes(HeartbeatData)
+}
@@ -17,3 +17,10 @@ func (p *Param) InfoString() string {
 	}
 	return string(p.Data)
 }
+
+// DecodeInfoString returns the INFO String in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeInfoString() (string, error) {
+	b, err := p.decodeBytes(InfoString)
+	return string(b), err
+}
@@ -0,0 +1,422 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// paramJSON is the structure of Param in JSON and YAML.
+type paramJSON struct {
+	Tag    uint16          `json:"tag"`
+	Name   string          `json:"name,omitempty"`
+	Length *uint16         `json:"length,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Data   string          `json:"data,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of Param.
+//
+// The known parameters have their decoded values in "value", e.g., Status
+// as "AS-Active" and Routing Context as a list of numbers. The others, and the
+// ones that cannot be decoded without losing any information, have the raw
+// value in "data" as a hex string instead. The Length is omitted unless it is
+// inconsistent with the value.
+func (p *Param) MarshalJSON() ([]byte, error) {
+	j := &paramJSON{
+		Tag:  p.Tag,
+		Name: TagName(p.Tag),
+	}
+	if int(p.Length) != 4+len(p.Data) {
+		l := p.Length
+		j.Length = &l
+	}
+
+	if v, ok := p.jsonValue(); ok {
+		j.Value = v
+	} else {
+		j.Data = hex.EncodeToString(p.Data)
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of Param.
+//
+// The tag can be given by "name" instead of "tag", and the value can be given
+// either by "value" or "data" in the same form as MarshalJSON. The Length is
+// calculated from the value if omitted.
+func (p *Param) UnmarshalJSON(b []byte) error {
+	var j paramJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	tag := j.Tag
+	if tag == 0 && j.Name != "" {
+		t, ok := lookupTag(j.Name)
+		if !ok {
+			return fmt.Errorf("unknown parameter name: %s", j.Name)
+		}
+		tag = t
+	}
+
+	var data []byte
+	if len(j.Value) != 0 && string(j.Value) != "null" {
+		c, ok := jsonCodecFor(tag)
+		if !ok {
+			return fmt.Errorf("value is not supported in parameter with tag %d, use data instead", tag)
+		}
+
+		var err error
+		data, err = c.encode(j.Value)
+		if err != nil {
+			return fmt.Errorf("invalid value in parameter with tag %d: %w", tag, err)
+		}
+	} else {
+		var err error
+		data, err = hex.DecodeString(j.Data)
+		if err != nil {
+			return fmt.Errorf("invalid data in parameter with tag %d: %w", tag, err)
+		}
+	}
+
+	p.Tag = tag
+	p.Data = data
+	if j.Length != nil {
+		p.Length = *j.Length
+	} else {
+		p.SetLength()
+	}
+	return nil
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of Param,
+// which has the same structure as the JSON encoding.
+func (p *Param) MarshalYAML() (interface{}, error) {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return fromJSONValue(v), nil
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of Param,
+// which has the same structure as the JSON encoding.
+func (p *Param) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(toJSONValue(v))
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalJSON(b)
+}
+
+// fromJSONValue converts the numbers decoded from JSON into integers if possible,
+// so that they are not encoded in YAML in exponential notation.
+func fromJSONValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i
+		}
+		f, _ := x.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = fromJSONValue(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = fromJSONValue(e)
+		}
+	}
+	return v
+}
+
+// toJSONValue converts the maps decoded from YAML, which may have non-string
+// keys, into the ones that can be encoded in JSON.
+func toJSONValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[fmt.Sprint(k)] = toJSONValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = toJSONValue(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = toJSONValue(e)
+		}
+	}
+	return v
+}
+
+// jsonValue returns the decoded value of the parameter in JSON, only if it
+// can be encoded back into the identical bytes.
+func (p *Param) jsonValue() (json.RawMessage, bool) {
+	c, ok := jsonCodecFor(p.Tag)
+	if !ok {
+		return nil, false
+	}
+	v, ok := c.decode(p.Data)
+	if !ok {
+		return nil, false
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	if b, err := c.encode(raw); err != nil || !bytes.Equal(b, p.Data) {
+		return nil, false
+	}
+	return raw, true
+}
+
+// jsonCodec converts the value of a parameter from/to the decoded value in JSON.
+type jsonCodec struct {
+	decode func(b []byte) (interface{}, bool)
+	encode func(raw json.RawMessage) ([]byte, error)
+}
+
+func jsonCodecFor(tag uint16) (jsonCodec, bool) {
+	switch tag {
+	case InfoString:
+		return stringCodec, true
+	case RoutingContext, AffectedPointCode, OriginatingPointCodeList:
+		return uint32ListCodec, true
+	case TrafficModeType:
+		return namedUint32Codec(trafficModeTypeNames), true
+	case ErrorCode:
+		return namedUint32Codec(errorCodeNames), true
+	case Status:
+		return namedUint32Codec(statusNames), true
+	case AspIdentifier, CorrelationID, NetworkAppearance, CongestionIndications,
+		ConcernedDestination, LocalRoutingKeyIdentifier, DestinationPointCode,
+		RegistrationStatus, DeregistrationStatus:
+		return uint32Codec, true
+	case UserCause:
+		return userCauseCodec, true
+	case ServiceIndicators:
+		return uint8ListCodec, true
+	case ProtocolData:
+		return protocolDataCodec, true
+	case RoutingKey, RegistrationResult, DeregistrationResult:
+		return nestedCodec, true
+	default:
+		return jsonCodec{}, false
+	}
+}
+
+var stringCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		return string(b), utf8.Valid(b)
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	},
+}
+
+var uint32Codec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		if len(b) != 4 {
+			return nil, false
+		}
+		return binary.BigEndian.Uint32(b), true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var u uint32
+		if err := json.Unmarshal(raw, &u); err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint32(nil, u), nil
+	},
+}
+
+// namedUint32Codec is the codec for the uint32 values that have names,
+// which are rendered in names if known, or in numbers otherwise.
+func namedUint32Codec(names map[uint32]string) jsonCodec {
+	return jsonCodec{
+		decode: func(b []byte) (interface{}, bool) {
+			if len(b) != 4 {
+				return nil, false
+			}
+			u := binary.BigEndian.Uint32(b)
+			if name, ok := names[u]; ok {
+				return name, true
+			}
+			return u, true
+		},
+		encode: func(raw json.RawMessage) ([]byte, error) {
+			var name string
+			if err := json.Unmarshal(raw, &name); err == nil {
+				u, ok := lookupName(names, name)
+				if !ok {
+					return nil, fmt.Errorf("unknown name: %s", name)
+				}
+				return binary.BigEndian.AppendUint32(nil, u), nil
+			}
+			return uint32Codec.encode(raw)
+		},
+	}
+}
+
+var uint32ListCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		if len(b)%4 != 0 {
+			return nil, false
+		}
+		us := make([]uint32, len(b)/4)
+		for i := range us {
+			us[i] = binary.BigEndian.Uint32(b[i*4:])
+		}
+		return us, true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var us []uint32
+		if err := json.Unmarshal(raw, &us); err != nil {
+			return nil, err
+		}
+		b := make([]byte, 0, len(us)*4)
+		for _, u := range us {
+			b = binary.BigEndian.AppendUint32(b, u)
+		}
+		return b, nil
+	},
+}
+
+var uint8ListCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		// not []uint8, which is encoded in base64.
+		us := make([]uint16, len(b))
+		for i, u := range b {
+			us[i] = uint16(u)
+		}
+		return us, true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var us []uint16
+		if err := json.Unmarshal(raw, &us); err != nil {
+			return nil, err
+		}
+		b := make([]byte, len(us))
+		for i, u := range us {
+			if u > 0xff {
+				return nil, fmt.Errorf("out of range: %d", u)
+			}
+			b[i] = uint8(u)
+		}
+		return b, nil
+	},
+}
+
+type userCauseJSON struct {
+	Cause uint16 `json:"cause"`
+	User  uint16 `json:"user"`
+}
+
+var userCauseCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		if len(b) != 4 {
+			return nil, false
+		}
+		return &userCauseJSON{
+			Cause: binary.BigEndian.Uint16(b[0:2]),
+			User:  binary.BigEndian.Uint16(b[2:4]),
+		}, true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var uc userCauseJSON
+		if err := json.Unmarshal(raw, &uc); err != nil {
+			return nil, err
+		}
+		b := binary.BigEndian.AppendUint16(nil, uc.Cause)
+		return binary.BigEndian.AppendUint16(b, uc.User), nil
+	},
+}
+
+type protocolDataJSON struct {
+	OriginatingPointCode   uint32 `json:"opc"`
+	DestinationPointCode   uint32 `json:"dpc"`
+	ServiceIndicator       uint8  `json:"si"`
+	NetworkIndicator       uint8  `json:"ni"`
+	MessagePriority        uint8  `json:"mp"`
+	SignalingLinkSelection uint8  `json:"sls"`
+	Data                   string `json:"data"`
+}
+
+var protocolDataCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		pd, err := ParseProtocolDataPayload(b)
+		if err != nil {
+			return nil, false
+		}
+		return &protocolDataJSON{
+			OriginatingPointCode:   pd.OriginatingPointCode,
+			DestinationPointCode:   pd.DestinationPointCode,
+			ServiceIndicator:       pd.ServiceIndicator,
+			NetworkIndicator:       pd.NetworkIndicator,
+			MessagePriority:        pd.MessagePriority,
+			SignalingLinkSelection: pd.SignalingLinkSelection,
+			Data:                   hex.EncodeToString(pd.Data),
+		}, true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var j protocolDataJSON
+		if err := json.Unmarshal(raw, &j); err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(j.Data)
+		if err != nil {
+			return nil, err
+		}
+		return NewProtocolDataPayload(
+			j.OriginatingPointCode, j.DestinationPointCode, j.ServiceIndicator,
+			j.NetworkIndicator, j.MessagePriority, j.SignalingLinkSelection, data,
+		).MarshalBinary()
+	},
+}
+
+var nestedCodec = jsonCodec{
+	decode: func(b []byte) (interface{}, bool) {
+		prs, err := ParseMultiParams(b)
+		if err != nil {
+			return nil, false
+		}
+		return prs, true
+	},
+	encode: func(raw json.RawMessage) ([]byte, error) {
+		var prs []*Param
+		if err := json.Unmarshal(raw, &prs); err != nil {
+			return nil, err
+		}
+		return MarshalMultiParams(prs)
+	},
+}
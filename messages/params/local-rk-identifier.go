@@ -17,3 +17,9 @@ func (p *Param) LocalRoutingKeyIdentifier() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeLocalRoutingKeyIdentifier returns the Local Routing Key Identifier in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeLocalRoutingKeyIdentifier() (uint32, error) {
+	return p.decodeUint32(LocalRoutingKeyIdentifier)
+}
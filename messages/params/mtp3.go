@@ -0,0 +1,163 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package params
+
+import "fmt"
+
+// ServiceIndicator is the Service Indicator (SI) in the Protocol Data, which
+// identifies the MTP3-User. The constants with the prefix ServiceInd can be
+// converted into ServiceIndicator.
+type ServiceIndicator uint8
+
+var serviceIndicatorNames = map[ServiceIndicator]string{
+	ServiceIndicator(ServiceIndSNM):                    "SNM",
+	ServiceIndicator(ServiceIndSNT):                    "SNT",
+	ServiceIndicator(ServiceIndSNTSpecial):             "SNT Special",
+	ServiceIndicator(ServiceIndSCCP):                   "SCCP",
+	ServiceIndicator(ServiceIndTUP):                    "TUP",
+	ServiceIndicator(ServiceIndISUP):                   "ISUP",
+	ServiceIndicator(ServiceIndDUPCall):                "DUP (call and circuit related)",
+	ServiceIndicator(ServiceIndDUPFacility):            "DUP (facility registration and cancellation)",
+	ServiceIndicator(ServiceIndMTPTesting):             "MTP Testing User Part",
+	ServiceIndicator(ServiceIndBroadbandISUP):          "Broadband ISUP",
+	ServiceIndicator(ServiceIndSatelliteISUP):          "Satellite ISUP",
+	ServiceIndicator(ServiceIndAALType2Signalling):     "AAL type 2 Signalling",
+	ServiceIndicator(ServiceIndBICC):                   "BICC",
+	ServiceIndicator(ServiceIndGatewayControlProtocol): "Gateway Control Protocol",
+}
+
+// Valid reports whether the ServiceIndicator fits in 4 bits.
+func (s ServiceIndicator) Valid() bool {
+	return s <= 0x0f
+}
+
+// String returns the name of the ServiceIndicator.
+func (s ServiceIndicator) String() string {
+	if name, ok := serviceIndicatorNames[s]; ok {
+		return name
+	}
+	if s.Valid() {
+		return "Spare"
+	}
+	return fmt.Sprintf("Unknown(%d)", uint8(s))
+}
+
+// NetworkIndicator is the Network Indicator (NI) in the Protocol Data.
+type NetworkIndicator uint8
+
+// NetworkIndicator definitions.
+const (
+	NetworkIndInternational NetworkIndicator = iota
+	NetworkIndSpare
+	NetworkIndNational
+	NetworkIndReservedNational
+)
+
+// Valid reports whether the NetworkIndicator fits in 2 bits.
+func (n NetworkIndicator) Valid() bool {
+	return n <= NetworkIndReservedNational
+}
+
+// String returns the name of the NetworkIndicator.
+func (n NetworkIndicator) String() string {
+	switch n {
+	case NetworkIndInternational:
+		return "International"
+	case NetworkIndSpare:
+		return "Spare"
+	case NetworkIndNational:
+		return "National"
+	case NetworkIndReservedNational:
+		return "Reserved for national use"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(n))
+	}
+}
+
+// MessagePriority is the Message Priority (MP) in the Protocol Data.
+type MessagePriority uint8
+
+// MessagePriority definitions, from the lowest to the highest.
+const (
+	MessagePriority0 MessagePriority = iota
+	MessagePriority1
+	MessagePriority2
+	MessagePriority3
+)
+
+// String returns the MessagePriority in human readable format.
+func (m MessagePriority) String() string {
+	if m > MessagePriority3 {
+		return fmt.Sprintf("Unknown(%d)", uint8(m))
+	}
+	return fmt.Sprintf("Priority %d", uint8(m))
+}
+
+// MTP3Variant is the variant of MTP3, which determines the valid ranges of
+// the values in the Protocol Data.
+type MTP3Variant uint8
+
+// MTP3Variant definitions.
+const (
+	MTP3VariantITU MTP3Variant = iota
+	MTP3VariantANSI
+	MTP3VariantChina
+	MTP3VariantJapan
+)
+
+// String returns the name of the MTP3Variant.
+func (v MTP3Variant) String() string {
+	switch v {
+	case MTP3VariantITU:
+		return "ITU"
+	case MTP3VariantANSI:
+		return "ANSI"
+	case MTP3VariantChina:
+		return "China"
+	case MTP3VariantJapan:
+		return "Japan"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(v))
+	}
+}
+
+// PointCodeBits returns the bit length of the point codes in the MTP3Variant,
+// or 0 if the MTP3Variant is unknown.
+func (v MTP3Variant) PointCodeBits() int {
+	switch v {
+	case MTP3VariantITU:
+		return 14
+	case MTP3VariantANSI, MTP3VariantChina:
+		return 24
+	case MTP3VariantJapan:
+		return 16
+	default:
+		return 0
+	}
+}
+
+// MaxSLS returns the maximum value of the Signalling Link Selection in the
+// MTP3Variant. ANSI allows 8-bit SLS, while the others use 4 bits.
+func (v MTP3Variant) MaxSLS() uint8 {
+	if v == MTP3VariantANSI {
+		return 0xff
+	}
+	return 0x0f
+}
+
+// ValidateMessagePriority checks if the MessagePriority is valid in the
+// MTP3Variant with the NetworkIndicator given.
+//
+// ITU uses the priority only in the national networks, so it must be 0 with
+// NetworkIndInternational. The other variants accept 0 to 3 in any network.
+func (v MTP3Variant) ValidateMessagePriority(mp MessagePriority, ni NetworkIndicator) error {
+	if mp > MessagePriority3 {
+		return fmt.Errorf("%w: message priority %d exceeds 3", ErrInvalidValue, mp)
+	}
+	if v == MTP3VariantITU && ni == NetworkIndInternational && mp != MessagePriority0 {
+		return fmt.Errorf("%w: message priority %d is not allowed in ITU international network", ErrInvalidValue, mp)
+	}
+	return nil
+}
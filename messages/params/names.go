@@ -0,0 +1,143 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package params
+
+import (
+	"maps"
+	"sync"
+)
+
+var (
+	muTagNames sync.RWMutex
+	tagNames   = maps.Clone(standardTagNames)
+
+	standardTagNames = map[uint16]string{
+		InfoString:                "INFO String",
+		RoutingContext:            "Routing Context",
+		DiagnosticInformation:     "Diagnostic Information",
+		HeartbeatData:             "Heartbeat Data",
+		TrafficModeType:           "Traffic Mode Type",
+		ErrorCode:                 "Error Code",
+		Status:                    "Status",
+		AspIdentifier:             "ASP Identifier",
+		AffectedPointCode:         "Affected Point Code",
+		CorrelationID:             "Correlation ID",
+		NetworkAppearance:         "Network Appearance",
+		UserCause:                 "User/Cause",
+		CongestionIndications:     "Congestion Indications",
+		ConcernedDestination:      "Concerned Destination",
+		RoutingKey:                "Routing Key",
+		RegistrationResult:        "Registration Result",
+		DeregistrationResult:      "Deregistration Result",
+		LocalRoutingKeyIdentifier: "Local Routing Key Identifier",
+		DestinationPointCode:      "Destination Point Code",
+		ServiceIndicators:         "Service Indicators",
+		OriginatingPointCodeList:  "Originating Point Code List",
+		ProtocolData:              "Protocol Data",
+		RegistrationStatus:        "Registration Status",
+		DeregistrationStatus:      "Deregistration Status",
+	}
+)
+
+var statusNames = map[uint32]string{
+	AsStateInactive:          "AS-Inactive",
+	AsStateActive:            "AS-Active",
+	AsStatePending:           "AS-Pending",
+	InsufficientAspResources: "Insufficient ASP Resources Active in AS",
+	AlternateAspActive:       "Alternate ASP Active",
+	AspFailure:               "ASP Failure",
+}
+
+var errorCodeNames = map[uint32]string{
+	InvalidVersionError:           "Invalid Version",
+	UnsupportedMessageErrorClass:  "Unsupported Message Class",
+	UnsupportedMessageErrorType:   "Unsupported Message Type",
+	ErrUnsupportedTrafficModeType: "Unsupported Traffic Mode Type",
+	UnexpectedMessageError:        "Unexpected Message",
+	ErrProtocolError:              "Protocol Error",
+	ErrInvalidStreamIdentifier:    "Invalid Stream Identifier",
+	ErrRefusedManagementBlocking:  "Refused - Management Blocking",
+	ErrAspIdentifierRequired:      "ASP Identifier Required",
+	ErrInvalidAspIdentifier:       "Invalid ASP Identifier",
+	ErrInvalidParameterValue:      "Invalid Parameter Value",
+	ErrParameterFieldError:        "Parameter Field Error",
+	ErrUnexpectedParameter:        "Unexpected Parameter",
+	ErrDestinationStatusUnknown:   "Destination Status Unknown",
+	ErrInvalidNetworkAppearance:   "Invalid Network Appearance",
+	ErrMissingParameter:           "Missing Parameter",
+	ErrInvalidRoutingContext:      "Invalid Routing Context",
+	ErrNoConfiguredAsForAsp:       "No Configured AS for ASP",
+}
+
+var trafficModeTypeNames = map[uint32]string{
+	TrafficModeOverride:  "Override",
+	TrafficModeLoadshare: "Loadshare",
+	TrafficModeBroadcast: "Broadcast",
+}
+
+// TagName returns the name of the parameter tag defined in RFC4666 or
+// registered with RegisterTagName, or empty string if the tag is unknown.
+func TagName(tag uint16) string {
+	muTagNames.RLock()
+	defer muTagNames.RUnlock()
+	return tagNames[tag]
+}
+
+// RegisterTagName registers the name of the parameter tag, which is used
+// in the JSON encoding and the human-readable output of the messages.
+// This is for the vendor-specific parameters, and the names of the ones
+// defined in RFC4666 can also be overridden. The empty name removes the one
+// registered, which restores the name defined in RFC4666 if any.
+func RegisterTagName(tag uint16, name string) {
+	muTagNames.Lock()
+	defer muTagNames.Unlock()
+	if name != "" {
+		tagNames[tag] = name
+		return
+	}
+	if std, ok := standardTagNames[tag]; ok {
+		tagNames[tag] = std
+		return
+	}
+	delete(tagNames, tag)
+}
+
+// StatusName returns the name of the Status (the combination of Status Type
+// and Status Information), or empty string if it is unknown.
+func StatusName(status uint32) string {
+	return statusNames[status]
+}
+
+// ErrorCodeName returns the name of the Error Code, or empty string if
+// it is unknown.
+func ErrorCodeName(code uint32) string {
+	return errorCodeNames[code]
+}
+
+// TrafficModeTypeName returns the name of the Traffic Mode Type, or empty
+// string if it is unknown.
+func TrafficModeTypeName(mode uint32) string {
+	return trafficModeTypeNames[mode]
+}
+
+func lookupTag(name string) (uint16, bool) {
+	muTagNames.RLock()
+	defer muTagNames.RUnlock()
+	for tag, n := range tagNames {
+		if n == name {
+			return tag, true
+		}
+	}
+	return 0, false
+}
+
+func lookupName(names map[uint32]string, name string) (uint32, bool) {
+	for v, n := range names {
+		if n == name {
+			return v, true
+		}
+	}
+	return 0, false
+}
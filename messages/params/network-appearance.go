@@ -17,3 +17,9 @@ func (p *Param) NetworkAppearance() uint32 {
 	}
 	return p.decodeUint32ValData()
 }
+
+// DecodeNetworkAppearance returns the Network Appearance in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeNetworkAppearance() (uint32, error) {
+	return p.decodeUint32(NetworkAppearance)
+}
@@ -7,7 +7,7 @@ package params
 // NewOriginatingPointCodeList creates the OriginatingPointCodeList Parameter.
 // Multiple number of OriginatingPointCodeList will be accepted, but
 // the mask for each point code should be included inside arguments.
-// TODO: Handle masks and point codes separately.
+// Use NewOriginatingPointCodeListWithMask to handle masks and point codes separately.
 // Note that this returns *Param, as no specific structure in this parameter.
 func NewOriginatingPointCodeList(opcs ...uint32) *Param {
 	return newMultiUint32ValParam(OriginatingPointCodeList, opcs...)
@@ -21,28 +21,20 @@ func (p *Param) OriginatingPointCodeList() []uint32 {
 	return p.decodeMultiUint32ValData()
 }
 
-/* XXX - Might be implemented in the following way?
-// PointCodeWithMask is a set of Mask and Point Code.
-type PointCodeWithMask struct {
-	Mask      uint8
-	PointCode uint32
+// NewOriginatingPointCodeListWithMask creates the OriginatingPointCodeList
+// Parameter from the point codes with masks.
+func NewOriginatingPointCodeListWithMask(opcs ...PointCodeWithMask) *Param {
+	return newPointCodeListParam(OriginatingPointCodeList, opcs)
 }
 
-// MarshalBinary creates the 32bit-sized []byte from PointCodeWithMask.
-func (p *PointCodeWithMask) MarshalBinary() ([]byte, error) {
-	b := make([]byte, 4)
-	// to be written?
+// OriginatingPointCodesWithMask returns the point codes with masks in the Param,
+// with the error if the Param is nil, of the other type, or malformed.
+func (p *Param) OriginatingPointCodesWithMask() (PointCodeList, error) {
+	return p.decodePointCodeList(OriginatingPointCodeList)
 }
 
-func (p *PointCodeWithMask) MarshalTo(b []bytes) error {
-	// to be written?
+// DecodeOriginatingPointCodeList returns the Originating Point Code List in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeOriginatingPointCodeList() ([]uint32, error) {
+	return p.decodeUint32List(OriginatingPointCodeList)
 }
-
-func (p *PointCodeWithMask) Parse(b []bytes) (*PointCodeWithMask, error) {
-	// to be written?
-}
-
-func (p *PointCodeWithMask) UnmarshalBinary(b []bytes) error {
-	// to be written?
-}
-*/
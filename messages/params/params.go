@@ -5,6 +5,7 @@
 package params
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -65,6 +66,8 @@ var (
 	ErrInvalidLength           = errors.New("parameter has invalid length value")
 	ErrTooShortToMarshalBinary = errors.New("insufficient buffer to serialize parameter to")
 	ErrTooShortToParse         = errors.New("too short to decode as parameter")
+	ErrNotPresent              = errors.New("parameter is not present")
+	ErrInvalidValue            = errors.New("parameter has invalid value")
 )
 
 // Param is a M3UA Param.
@@ -120,7 +123,10 @@ func newMultiUint32ValParam(t uint16, ux ...uint32) *Param {
 }
 
 func newMultiUint8ValParam(t uint16, ux ...uint8) *Param {
-	l := len(ux) + (4 - len(ux)%4)
+	l := len(ux)
+	if x := l % 4; x != 0 {
+		l += 4 - x
+	}
 	p := &Param{
 		Tag:  t,
 		Data: make([]byte, l),
@@ -176,6 +182,45 @@ func (p *Param) decodeMultiUint32ValData() []uint32 {
 	return us
 }
 
+// checkTag returns ErrNotPresent if the Param is nil, or ErrInvalidType if
+// the Param does not have the tag given.
+func (p *Param) checkTag(tag uint16) error {
+	if p == nil {
+		return ErrNotPresent
+	}
+	if p.Tag != tag {
+		return ErrInvalidType
+	}
+	return nil
+}
+
+func (p *Param) decodeUint32(tag uint16) (uint32, error) {
+	if err := p.checkTag(tag); err != nil {
+		return 0, err
+	}
+	if len(p.Data) != 4 {
+		return 0, ErrInvalidLength
+	}
+	return binary.BigEndian.Uint32(p.Data), nil
+}
+
+func (p *Param) decodeUint32List(tag uint16) ([]uint32, error) {
+	if err := p.checkTag(tag); err != nil {
+		return nil, err
+	}
+	if len(p.Data) == 0 || len(p.Data)%4 != 0 {
+		return nil, ErrInvalidLength
+	}
+	return p.decodeMultiUint32ValData(), nil
+}
+
+func (p *Param) decodeBytes(tag uint16) ([]byte, error) {
+	if err := p.checkTag(tag); err != nil {
+		return nil, err
+	}
+	return p.Data, nil
+}
+
 // NewParam creates a new Param.
 // This is for generic use. NewXXX(ParamName) functions are available to create the parameters defined in RFC4666.
 func NewParam(tag int, data []byte) *Param {
@@ -198,6 +243,10 @@ func (p *Param) MarshalBinary() ([]byte, error) {
 
 // MarshalTo puts the byte sequence in the byte array given as b.
 func (p *Param) MarshalTo(b []byte) error {
+	if len(b) < p.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
 	binary.BigEndian.PutUint16(b[0:2], p.Tag)
 	binary.BigEndian.PutUint16(b[2:4], p.Length)
 	copy(b[4:p.MarshalLen()], p.Data)
@@ -278,7 +327,9 @@ func MarshalMultiParams(params []*Param) ([]byte, error) {
 //
 // This is easy and useful but slower than decoding one by one.
 // When you don't know the number of Params, this is the only way to decode them.
-// See benchmarks in diameter_test.go for the detail.
+//
+// All the Params including the last one should be padded to a multiple of
+// four bytes, otherwise ErrInvalidLength is returned.
 func ParseMultiParams(b []byte) ([]*Param, error) {
 	var prms []*Param
 	for {
@@ -290,16 +341,25 @@ func ParseMultiParams(b []byte) ([]*Param, error) {
 		if err != nil {
 			return nil, err
 		}
-		prms = append(prms, p)
-		if len(b) < int(p.Length)+p.Padding() {
-			return prms, nil
+		l := int(p.Length) + p.Padding()
+		if len(b) < l {
+			return nil, ErrInvalidLength
 		}
 
-		b = b[int(p.Length)+p.Padding():]
+		prms = append(prms, p)
+		b = b[l:]
 	}
 	return prms, nil
 }
 
+// Clone returns a deep copy of the Param, or nil if p is nil.
+func (p *Param) Clone() *Param {
+	if p == nil {
+		return nil
+	}
+	return &Param{Tag: p.Tag, Length: p.Length, Data: bytes.Clone(p.Data)}
+}
+
 // Serialize returns the byte sequence generated from a Param.
 //
 // DEPRECATED: use MarshalBinary instead.
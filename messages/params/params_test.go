@@ -5,9 +5,12 @@
 package params
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
 )
 
 func TestParams(t *testing.T) {
@@ -132,6 +135,11 @@ func TestParams(t *testing.T) {
 			NewServiceIndicators(1, 2, 3),
 			[]byte{0x02, 0x0c, 0x00, 0x08, 0x01, 0x02, 0x03, 0x00},
 		},
+		{
+			"ServiceIndicators-aligned",
+			NewServiceIndicators(1, 2, 3, 4),
+			[]byte{0x02, 0x0c, 0x00, 0x08, 0x01, 0x02, 0x03, 0x04},
+		},
 		{
 			"RegistrationStatus",
 			NewRegistrationStatus(1),
@@ -283,6 +291,7 @@ func TestParseMalformed(t *testing.T) {
 		{[]byte{0x00, 0x00}, ErrTooShortToParse},
 		{[]byte{0x00, 0x00, 0x00}, ErrTooShortToParse},
 		{[]byte{0x00, 0x00, 0x00, 0x00}, ErrInvalidLength},
+		{[]byte{0x00, 0x06, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x01}, ErrInvalidLength},
 	}
 
 	for _, c := range cases {
@@ -294,3 +303,501 @@ func TestParseMalformed(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMultiParamsWithoutPadding(t *testing.T) {
+	b := []byte{
+		// Routing Context
+		0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+		// Info String without padding
+		0x00, 0x04, 0x00, 0x05, 0x61,
+	}
+	if _, err := ParseMultiParams(b); err != ErrInvalidLength {
+		t.Errorf("unexpected error: got: %v, want: %v", err, ErrInvalidLength)
+	}
+}
+
+func TestMarshalToShortBuffer(t *testing.T) {
+	p := NewRoutingContext(1, 2)
+	if err := p.MarshalTo(make([]byte, p.MarshalLen()-1)); err != ErrTooShortToMarshalBinary {
+		t.Errorf("unexpected error: got: %v, want: %v", err, ErrTooShortToMarshalBinary)
+	}
+}
+
+func TestEmptyMultiValue(t *testing.T) {
+	if got := NewAffectedPointCode().AffectedPointCode(); got != 0 {
+		t.Errorf("AffectedPointCode: got: %d, want: 0", got)
+	}
+	if got := NewRoutingContext().RoutingContext(); got != 0 {
+		t.Errorf("RoutingContext: got: %d, want: 0", got)
+	}
+}
+
+// paramJSONCases are the Params and their JSON encoding, which are also used
+// to test the YAML encoding of the same structure.
+var paramJSONCases = []struct {
+	name  string
+	param *Param
+	json  string
+}{
+	{
+		"Status",
+		NewStatus(AsStateActive),
+		`{"tag":13,"name":"Status","value":"AS-Active"}`,
+	},
+	{
+		"Status-unknown",
+		NewStatus(0x00030001),
+		`{"tag":13,"name":"Status","value":196609}`,
+	},
+	{
+		"ErrorCode",
+		NewErrorCode(ErrInvalidRoutingContext),
+		`{"tag":12,"name":"Error Code","value":"Invalid Routing Context"}`,
+	},
+	{
+		"TrafficModeType",
+		NewTrafficModeType(TrafficModeLoadshare),
+		`{"tag":11,"name":"Traffic Mode Type","value":"Loadshare"}`,
+	},
+	{
+		"RoutingContext",
+		NewRoutingContext(1, 2),
+		`{"tag":6,"name":"Routing Context","value":[1,2]}`,
+	},
+	{
+		"ServiceIndicators",
+		NewServiceIndicators(3, 5),
+		`{"tag":524,"name":"Service Indicators","value":[3,5,0,0]}`,
+	},
+	{
+		"UserCause",
+		NewUserCause(1, 3),
+		`{"tag":516,"name":"User/Cause","value":{"cause":3,"user":1}}`,
+	},
+	{
+		"ProtocolData",
+		NewProtocolData(1, 2, 3, 1, 0, 1, []byte{0xde, 0xad}),
+		`{"tag":528,"name":"Protocol Data","value":{"opc":1,"dpc":2,"si":3,"ni":1,"mp":0,"sls":1,"data":"dead"}}`,
+	},
+	{
+		"RoutingKey",
+		NewRoutingKey(NewRoutingKeyPayload(NewLocalRoutingKeyIdentifier(1), NewRoutingContext(1), nil, nil, nil, nil, nil)),
+		`{"tag":519,"name":"Routing Key","value":[` +
+			`{"tag":522,"name":"Local Routing Key Identifier","value":1},` +
+			`{"tag":6,"name":"Routing Context","value":[1]}]}`,
+	},
+	{
+		"HeartbeatData",
+		NewHeartbeatData([]byte{0xde, 0xad, 0xbe, 0xef}),
+		`{"tag":9,"name":"Heartbeat Data","data":"deadbeef"}`,
+	},
+	{
+		"InfoString-invalid-utf8",
+		NewInfoString("\xff"),
+		`{"tag":4,"name":"INFO String","data":"ff"}`,
+	},
+	{
+		"AspIdentifier-invalid-length",
+		NewParam(int(AspIdentifier), []byte{0x01}),
+		`{"tag":17,"name":"ASP Identifier","data":"01"}`,
+	},
+	{
+		"Length-mismatch",
+		&Param{Tag: AspIdentifier, Length: 4, Data: []byte{0, 0, 0, 1}},
+		`{"tag":17,"name":"ASP Identifier","length":4,"value":1}`,
+	},
+	{
+		"Unknown",
+		NewParam(0x8001, []byte{0xde, 0xad}),
+		`{"tag":32769,"data":"dead"}`,
+	},
+}
+
+func TestParamJSON(t *testing.T) {
+	for _, c := range paramJSONCases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.param.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(got), c.json); diff != "" {
+				t.Error(diff)
+			}
+
+			p := &Param{}
+			if err := p.UnmarshalJSON([]byte(c.json)); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(p, c.param, cmp.Comparer(bytes.Equal)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParamJSONByName(t *testing.T) {
+	p := &Param{}
+	if err := p.UnmarshalJSON([]byte(`{"name":"Status","value":"AS-Pending"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(p, NewStatus(AsStatePending)); diff != "" {
+		t.Error(diff)
+	}
+
+	for _, j := range []string{
+		`{"name":"No Such Param","data":"00"}`,
+		`{"name":"Status","value":"No Such Status"}`,
+		`{"tag":32769,"value":1}`,
+		`{"tag":17,"data":"zz"}`,
+	} {
+		if err := p.UnmarshalJSON([]byte(j)); err == nil {
+			t.Errorf("no error with %s", j)
+		}
+	}
+}
+
+func TestParamYAMLRoundTrip(t *testing.T) {
+	for _, c := range paramJSONCases {
+		t.Run(c.name, func(t *testing.T) {
+			y, err := yaml.Marshal(c.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := &Param{}
+			if err := yaml.Unmarshal(y, p); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", y, err)
+			}
+			if diff := cmp.Diff(p, c.param, cmp.Comparer(bytes.Equal)); diff != "" {
+				t.Errorf("%s\nyaml: %s", diff, y)
+			}
+		})
+	}
+}
+
+func TestParamYAML(t *testing.T) {
+	want := NewRoutingContext(0xffffffff)
+
+	v, err := want.MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the numbers should not be float64, which may be encoded in exponential notation.
+	if diff := cmp.Diff(v, map[string]interface{}{
+		"tag": int64(6), "name": "Routing Context", "value": []interface{}{int64(0xffffffff)},
+	}); diff != "" {
+		t.Error(diff)
+	}
+
+	// YAML decoders may give the maps with interface{} keys.
+	got := &Param{}
+	err = got.UnmarshalYAML(func(v interface{}) error {
+		*(v.(*interface{})) = map[interface{}]interface{}{
+			"tag": 6, "value": []interface{}{0xffffffff},
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	rc, err := NewRoutingContext(1, 2).DecodeRoutingContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(rc, []uint32{1, 2}); diff != "" {
+		t.Error(diff)
+	}
+
+	st, err := NewStatus(AsStateActive).DecodeStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(st, StatusParam{Type: AsStateChange, Info: 3}); diff != "" {
+		t.Error(diff)
+	}
+	if got := st.String(); got != "AS-Active" {
+		t.Errorf("got %s, want AS-Active", got)
+	}
+
+	uc, err := NewUserCause(5, 2).DecodeUserCause()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(uc, UserCauseParam{Cause: 2, User: 5}); diff != "" {
+		t.Error(diff)
+	}
+
+	// the zero value is distinguishable from the absence or malformation.
+	ec, err := NewErrorCode(0).DecodeErrorCode()
+	if ec != 0 || err != nil {
+		t.Errorf("got %d, %v, want 0, nil", ec, err)
+	}
+
+	var nilParam *Param
+	for _, c := range []struct {
+		description string
+		err         error
+		got         error
+	}{
+		{"nil", ErrNotPresent, func() error { _, err := nilParam.DecodeErrorCode(); return err }()},
+		{"nil-payload", ErrNotPresent, func() error { _, err := nilParam.ProtocolData(); return err }()},
+		{"other-type", ErrInvalidType, func() error { _, err := NewTrafficModeType(1).DecodeErrorCode(); return err }()},
+		{"short", ErrInvalidLength, func() error { _, err := NewParam(int(ErrorCode), []byte{0}).DecodeErrorCode(); return err }()},
+		{"empty-list", ErrInvalidLength, func() error { _, err := NewParam(int(RoutingContext), nil).DecodeRoutingContexts(); return err }()},
+		{"odd-list", ErrInvalidLength, func() error {
+			_, err := NewParam(int(AffectedPointCode), []byte{0, 0, 0, 1, 0}).DecodeAffectedPointCodes()
+			return err
+		}()},
+	} {
+		if !errors.Is(c.got, c.err) {
+			t.Errorf("%s: got %v, want %v", c.description, c.got, c.err)
+		}
+	}
+}
+
+func TestPointCodeWithMask(t *testing.T) {
+	p := NewPointCodeWithMask(2, 0x1235)
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(b, []byte{0x02, 0x00, 0x12, 0x35}); diff != "" {
+		t.Error(diff)
+	}
+
+	parsed, err := ParsePointCodeWithMask(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(*parsed, p); diff != "" {
+		t.Error(diff)
+	}
+
+	// the wildcarded bits in the point code are ignored.
+	if diff := cmp.Diff(p.Expand(), []uint32{0x1234, 0x1235, 0x1236, 0x1237}); diff != "" {
+		t.Error(diff)
+	}
+	for pc, want := range map[uint32]bool{0x1233: false, 0x1234: true, 0x1237: true, 0x1238: false} {
+		if got := p.Contains(pc); got != want {
+			t.Errorf("Contains(%#x): got %v, want %v", pc, got, want)
+		}
+	}
+
+	single := NewPointCodeWithMask(0, 0x1234)
+	if diff := cmp.Diff(single.Expand(), []uint32{0x1234}); diff != "" {
+		t.Error(diff)
+	}
+	all := NewPointCodeWithMask(0xff, 0x1234)
+	if !all.Contains(0) || !all.Contains(0xffffff) {
+		t.Error("mask over 24 bits should contain all point codes")
+	}
+
+	apcs, err := NewAffectedPointCodeWithMask(p, single).AffectedPointCodesWithMask()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(apcs, PointCodeList{p, single}); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := ParsePointCodeWithMask([]byte{0x00}); err != ErrTooShortToParse {
+		t.Errorf("got %v, want %v", err, ErrTooShortToParse)
+	}
+}
+
+func TestServiceIndicatorList(t *testing.T) {
+	// SNM (0) at the end of the list whose length is a multiple of 4.
+	snmLast, err := Parse([]byte{0x02, 0x0c, 0x00, 0x08, 0x05, 0x03, 0x00, 0x00})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		description string
+		param       *Param
+		want        ServiceIndicatorList
+	}{
+		{"aligned", NewServiceIndicators(1, 2, 3, 4), ServiceIndicatorList{1, 2, 3, 4}},
+		{"unpadded", NewParam(int(ServiceIndicators), []byte{3, 5, 0}), ServiceIndicatorList{3, 5, 0}},
+		{"snm-last", NewServiceIndicators(5, 3, 0, 0), ServiceIndicatorList{5, 3, 0, 0}},
+		{"snm-last-decoded", snmLast, ServiceIndicatorList{5, 3, 0, 0}},
+	} {
+		got, err := c.param.ServiceIndicatorList()
+		if err != nil {
+			t.Fatalf("%s: %v", c.description, err)
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("%s: %s", c.description, diff)
+		}
+	}
+
+	sis := ServiceIndicatorList{3, 5}
+	if !sis.Matches(5) || sis.Matches(4) {
+		t.Errorf("unexpected matching with %v", sis)
+	}
+
+	if _, err := NewServiceIndicators(3, 16).ServiceIndicatorList(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, want %v", err, ErrInvalidValue)
+	}
+	if _, err := NewParam(int(ServiceIndicators), nil).ServiceIndicatorList(); err != ErrInvalidLength {
+		t.Errorf("got %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestRoutingKeyMatches(t *testing.T) {
+	rk := NewRoutingKeyPayload(
+		NewLocalRoutingKeyIdentifier(1), nil, nil,
+		NewDestinationPointCode(0x1234), nil,
+		NewServiceIndicators(3, 5),
+		NewOriginatingPointCodeListWithMask(NewPointCodeWithMask(4, 0x100), NewPointCodeWithMask(0, 0x200)),
+	)
+	for _, c := range []struct {
+		dpc  uint32
+		si   uint8
+		opc  uint32
+		want bool
+	}{
+		{0x1234, 3, 0x10f, true},
+		{0x1234, 5, 0x200, true},
+		{0x1234, 5, 0x201, false},
+		{0x1234, 4, 0x100, false},
+		{0x1235, 3, 0x100, false},
+	} {
+		got, err := rk.Matches(c.dpc, c.si, c.opc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("Matches(%#x, %d, %#x): got %v, want %v", c.dpc, c.si, c.opc, got, c.want)
+		}
+	}
+
+	// the absent parameters match any value.
+	rk.ServiceIndicators, rk.OriginatingPointCodeList = nil, nil
+	if got, err := rk.Matches(0x1234, 14, 0xffff); !got || err != nil {
+		t.Errorf("got %v, %v, want true, nil", got, err)
+	}
+}
+
+func TestMTP3Values(t *testing.T) {
+	for _, c := range []struct {
+		got, want string
+	}{
+		{ServiceIndicator(ServiceIndSCCP).String(), "SCCP"},
+		{ServiceIndicator(ServiceIndBroadbandISUP).String(), "Broadband ISUP"},
+		{ServiceIndicator(11).String(), "Spare"},
+		{ServiceIndicator(16).String(), "Unknown(16)"},
+		{NetworkIndNational.String(), "National"},
+		{MessagePriority2.String(), "Priority 2"},
+		{MTP3VariantJapan.String(), "Japan"},
+	} {
+		if c.got != c.want {
+			t.Errorf("got %s, want %s", c.got, c.want)
+		}
+	}
+
+	// the values should match the ones defined in Q.704.
+	if ServiceIndBroadbandISUP != 9 || ServiceIndGatewayControlProtocol != 14 {
+		t.Errorf("unexpected ServiceIndicator values: %d, %d", ServiceIndBroadbandISUP, ServiceIndGatewayControlProtocol)
+	}
+}
+
+func TestNewProtocolDataPayloadFor(t *testing.T) {
+	pd, err := NewProtocolDataPayloadFor(
+		MTP3VariantITU, 0x3fff, 1, ServiceIndicator(ServiceIndSCCP), NetworkIndNational, MessagePriority1, 15, []byte{0xde},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(pd, NewProtocolDataPayload(0x3fff, 1, ServiceIndSCCP, 2, 1, 15, []byte{0xde})); diff != "" {
+		t.Error(diff)
+	}
+
+	for _, c := range []struct {
+		description string
+		variant     MTP3Variant
+		opc, dpc    uint32
+		si          ServiceIndicator
+		ni          NetworkIndicator
+		mp          MessagePriority
+		sls         uint8
+	}{
+		{"itu-opc", MTP3VariantITU, 0x4000, 1, 3, NetworkIndNational, 0, 0},
+		{"japan-dpc", MTP3VariantJapan, 1, 0x10000, 3, NetworkIndNational, 0, 0},
+		{"si", MTP3VariantANSI, 1, 1, 16, NetworkIndNational, 0, 0},
+		{"ni", MTP3VariantANSI, 1, 1, 3, 4, 0, 0},
+		{"mp", MTP3VariantANSI, 1, 1, 3, NetworkIndNational, 4, 0},
+		{"itu-international-mp", MTP3VariantITU, 1, 1, 3, NetworkIndInternational, 1, 0},
+		{"china-sls", MTP3VariantChina, 1, 1, 3, NetworkIndNational, 0, 16},
+		{"unknown-variant", 10, 1, 1, 3, NetworkIndNational, 0, 0},
+	} {
+		_, err := NewProtocolDataPayloadFor(c.variant, c.opc, c.dpc, c.si, c.ni, c.mp, c.sls, nil)
+		if !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("%s: got %v, want %v", c.description, err, ErrInvalidValue)
+		}
+	}
+
+	// ANSI allows 24-bit point codes and 8-bit SLS.
+	if _, err := NewProtocolDataPayloadFor(MTP3VariantANSI, 0xffffff, 1, 5, NetworkIndNational, 3, 255, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	v, err := NewStatus(AsStateActive).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(v, StatusParam{Type: AsStateChange, Info: 3}); diff != "" {
+		t.Error(diff)
+	}
+
+	vendor := NewParam(0x8002, []byte{0x00, 0x2a})
+	if v, err := vendor.Value(); err != nil || !bytes.Equal(v.([]byte), []byte{0x00, 0x2a}) {
+		t.Errorf("got %v, %v", v, err)
+	}
+
+	type vendorValue struct{ N int }
+	RegisterDecoder(0x8002, func(p *Param) (interface{}, error) {
+		if len(p.Data) != 2 {
+			return nil, ErrInvalidLength
+		}
+		return vendorValue{int(p.Data[1])}, nil
+	})
+	defer RegisterDecoder(0x8002, nil)
+
+	v, err = vendor.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(v, vendorValue{42}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRegisterTagName(t *testing.T) {
+	RegisterTagName(0x8001, "Vendor Specific")
+	RegisterTagName(InfoString, "Vendor Info")
+	if got := TagName(0x8001); got != "Vendor Specific" {
+		t.Errorf("got %q, want %q", got, "Vendor Specific")
+	}
+	if got := TagName(InfoString); got != "Vendor Info" {
+		t.Errorf("got %q, want %q", got, "Vendor Info")
+	}
+
+	// the empty name removes the registration.
+	RegisterTagName(0x8001, "")
+	RegisterTagName(InfoString, "")
+	if got := TagName(0x8001); got != "" {
+		t.Errorf("got %q, want empty", got)
+	}
+	if got := TagName(InfoString); got != "INFO String" {
+		t.Errorf("got %q, want %q", got, "INFO String")
+	}
+}
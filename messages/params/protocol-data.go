@@ -11,14 +11,24 @@ import (
 )
 
 // ServiceIndicator definitions.
+//
+// See ITU-T Q.704 14.2.1 for the details.
+//
+// Breaking change: ServiceIndBroadbandISUP, ServiceIndSatelliteISUP,
+// ServiceIndAALType2Signalling, ServiceIndBICC and ServiceIndGatewayControlProtocol
+// were 7, 8, 10, 11 and 12 in the earlier versions, which did not match Q.704.
+// They are 9, 10, 12, 13 and 14 now, and the callers that used the raw values
+// or stored the constants should be updated accordingly.
 const (
-	ServiceIndUnused uint8 = iota
-	_
-	_
+	ServiceIndUnused uint8 = iota // Signalling network management messages
+	ServiceIndSNT
+	ServiceIndSNTSpecial
 	ServiceIndSCCP
 	ServiceIndTUP
 	ServiceIndISUP
-	_
+	ServiceIndDUPCall
+	ServiceIndDUPFacility
+	ServiceIndMTPTesting
 	ServiceIndBroadbandISUP
 	ServiceIndSatelliteISUP
 	_
@@ -28,6 +38,10 @@ const (
 	_
 )
 
+// ServiceIndSNM is the Service Indicator of the signalling network management
+// messages, which is the same as ServiceIndUnused.
+const ServiceIndSNM = ServiceIndUnused
+
 // ProtocolDataPayload is a M3UA ProtocolDataPayload.
 type ProtocolDataPayload struct {
 	OriginatingPointCode   uint32
@@ -54,15 +68,35 @@ func NewProtocolDataPayload(opc, dpc uint32, si, ni, mp, sls uint8, data []byte)
 	}
 }
 
+// NewProtocolDataPayloadFor creates a new ProtocolDataPayload with the typed
+// values, validating them for the MTP3 variant given. This is preferred to
+// NewProtocolDataPayload, which accepts any value that fits in the fields.
+func NewProtocolDataPayloadFor(variant MTP3Variant, opc, dpc uint32, si ServiceIndicator, ni NetworkIndicator, mp MessagePriority, sls uint8, data []byte) (*ProtocolDataPayload, error) {
+	p := NewProtocolDataPayload(opc, dpc, uint8(si), uint8(ni), uint8(mp), sls, data)
+	if err := p.Validate(variant); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 // NewProtocolData creates a new ProtocolData.
 // Note that this returns *Param, as no specific structure in this parameter.
 // Also, Payload will be serialized and not accessible until calling ProtocolData() func.
 func NewProtocolData(opc, dpc uint32, si, ni, mp, sls uint8, data []byte) *Param {
-	pd, _ := NewProtocolDataPayload(opc, dpc, si, ni, mp, sls, data).MarshalBinary()
+	pd := ProtocolDataPayload{
+		OriginatingPointCode:   opc,
+		DestinationPointCode:   dpc,
+		ServiceIndicator:       si,
+		NetworkIndicator:       ni,
+		MessagePriority:        mp,
+		SignalingLinkSelection: sls,
+		Data:                   data,
+	}
 	p := &Param{
 		Tag:  ProtocolData,
-		Data: pd,
+		Data: make([]byte, pd.MarshalLen()),
 	}
+	_ = pd.MarshalTo(p.Data)
 	p.SetLength()
 
 	return p
@@ -70,13 +104,43 @@ func NewProtocolData(opc, dpc uint32, si, ni, mp, sls uint8, data []byte) *Param
 
 // ProtocolData returns ProtocolDataPayload
 func (p *Param) ProtocolData() (*ProtocolDataPayload, error) {
-	if p.Tag != ProtocolData {
-		return nil, ErrInvalidType
+	if err := p.checkTag(ProtocolData); err != nil {
+		return nil, err
 	}
 
 	return ParseProtocolDataPayload(p.Data)
 }
 
+// Validate checks if the values in the ProtocolDataPayload are in the valid
+// ranges for the MTP3 variant given, and returns the error wrapping
+// ErrInvalidValue if not.
+func (p *ProtocolDataPayload) Validate(variant MTP3Variant) error {
+	bits := variant.PointCodeBits()
+	if bits == 0 {
+		return fmt.Errorf("%w: unknown MTP3 variant %d", ErrInvalidValue, variant)
+	}
+	if p.OriginatingPointCode>>bits != 0 {
+		return fmt.Errorf("%w: OPC %#x exceeds %d bits in %s", ErrInvalidValue, p.OriginatingPointCode, bits, variant)
+	}
+	if p.DestinationPointCode>>bits != 0 {
+		return fmt.Errorf("%w: DPC %#x exceeds %d bits in %s", ErrInvalidValue, p.DestinationPointCode, bits, variant)
+	}
+	if !ServiceIndicator(p.ServiceIndicator).Valid() {
+		return fmt.Errorf("%w: service indicator %d", ErrInvalidValue, p.ServiceIndicator)
+	}
+	ni := NetworkIndicator(p.NetworkIndicator)
+	if !ni.Valid() {
+		return fmt.Errorf("%w: network indicator %d", ErrInvalidValue, p.NetworkIndicator)
+	}
+	if err := variant.ValidateMessagePriority(MessagePriority(p.MessagePriority), ni); err != nil {
+		return err
+	}
+	if p.SignalingLinkSelection > variant.MaxSLS() {
+		return fmt.Errorf("%w: SLS %d exceeds %d in %s", ErrInvalidValue, p.SignalingLinkSelection, variant.MaxSLS(), variant)
+	}
+	return nil
+}
+
 // MarshalBinary returns the byte sequence generated from a M3UA ProtocolDataPayload instance.
 func (p *ProtocolDataPayload) MarshalBinary() ([]byte, error) {
 	b := make([]byte, p.MarshalLen())
@@ -33,8 +33,8 @@ func NewRegistrationResult(rr *RegistrationResultPayload) *Param {
 
 // RegistrationResult returns RegistrationResultPayload.
 func (p *Param) RegistrationResult() (*RegistrationResultPayload, error) {
-	if p.Tag != RegistrationResult {
-		return nil, ErrInvalidType
+	if err := p.checkTag(RegistrationResult); err != nil {
+		return nil, err
 	}
 
 	d, err := ParseRegistrationResultPayload(p.Data)
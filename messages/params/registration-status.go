@@ -35,3 +35,9 @@ func (p *Param) RegistrationStatus() uint32 {
 
 	return p.decodeUint32ValData()
 }
+
+// DecodeRegistrationStatus returns the Registration Status in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeRegistrationStatus() (uint32, error) {
+	return p.decodeUint32(RegistrationStatus)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package params
+
+import "sync"
+
+// DecodeFunc decodes the value of the Param into the type of the application.
+type DecodeFunc func(p *Param) (interface{}, error)
+
+var (
+	muDecoders sync.RWMutex
+	decoders   = map[uint16]DecodeFunc{}
+)
+
+// RegisterDecoder registers the function that decodes the value of the
+// parameter with the tag given, which is used in Value. This is for the
+// vendor-specific parameters, and also for the proprietary usage of the ones
+// defined in RFC4666 like INFO String, which doesn't affect the encoding nor
+// the other accessors. nil dec removes the registered one.
+//
+// Use RegisterTagName together to give the name to the parameter.
+func RegisterDecoder(tag uint16, dec DecodeFunc) {
+	muDecoders.Lock()
+	defer muDecoders.Unlock()
+	if dec == nil {
+		delete(decoders, tag)
+		return
+	}
+	decoders[tag] = dec
+}
+
+// Value returns the decoded value of the Param.
+//
+// The function registered with RegisterDecoder is used if any. Otherwise, the
+// parameters defined in RFC4666 are decoded into the same types as the ones
+// returned by the typed accessors, e.g., []uint32 for Routing Context and
+// StatusParam for Status, and the raw bytes are returned for the others.
+func (p *Param) Value() (interface{}, error) {
+	if p == nil {
+		return nil, ErrNotPresent
+	}
+
+	muDecoders.RLock()
+	dec, ok := decoders[p.Tag]
+	muDecoders.RUnlock()
+	if ok {
+		return dec(p)
+	}
+
+	switch p.Tag {
+	case InfoString:
+		return p.DecodeInfoString()
+	case RoutingContext:
+		return p.DecodeRoutingContexts()
+	case DiagnosticInformation:
+		return p.DecodeDiagnosticInformation()
+	case HeartbeatData:
+		return p.DecodeHeartbeatData()
+	case TrafficModeType:
+		return p.DecodeTrafficModeType()
+	case ErrorCode:
+		return p.DecodeErrorCode()
+	case Status:
+		return p.DecodeStatus()
+	case AspIdentifier:
+		return p.DecodeAspIdentifier()
+	case AffectedPointCode:
+		return p.AffectedPointCodesWithMask()
+	case CorrelationID:
+		return p.DecodeCorrelationID()
+	case NetworkAppearance:
+		return p.DecodeNetworkAppearance()
+	case UserCause:
+		return p.DecodeUserCause()
+	case CongestionIndications:
+		return p.DecodeCongestionLevel()
+	case ConcernedDestination:
+		return p.DecodeConcernedDestination()
+	case RoutingKey:
+		return p.RoutingKey()
+	case RegistrationResult:
+		return p.RegistrationResult()
+	case DeregistrationResult:
+		return p.DeregistrationResult()
+	case LocalRoutingKeyIdentifier:
+		return p.DecodeLocalRoutingKeyIdentifier()
+	case DestinationPointCode:
+		return p.DecodeDestinationPointCode()
+	case ServiceIndicators:
+		return p.ServiceIndicatorList()
+	case OriginatingPointCodeList:
+		return p.OriginatingPointCodesWithMask()
+	case ProtocolData:
+		return p.ProtocolData()
+	case RegistrationStatus:
+		return p.DecodeRegistrationStatus()
+	case DeregistrationStatus:
+		return p.DecodeDeregistrationStatus()
+	default:
+		return p.Data, nil
+	}
+}
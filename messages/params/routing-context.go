@@ -17,7 +17,11 @@ func (p *Param) RoutingContext() uint32 {
 		return 0
 	}
 
-	return p.RoutingContexts()[0]
+	vs := p.RoutingContexts()
+	if len(vs) == 0 {
+		return 0
+	}
+	return vs[0]
 }
 
 // RoutingContexts returns multiple RoutingContexts from Param.
@@ -28,3 +32,9 @@ func (p *Param) RoutingContexts() []uint32 {
 
 	return p.decodeMultiUint32ValData()
 }
+
+// DecodeRoutingContexts returns the Routing Contexts in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeRoutingContexts() ([]uint32, error) {
+	return p.decodeUint32List(RoutingContext)
+}
@@ -24,6 +24,22 @@ func NewRoutingKeyPayload(rkID, rtCtx, tmType, dpc, nwApr, si, opcs *Param) *Rou
 	}
 }
 
+// Clone returns a deep copy of the RoutingKeyPayload, or nil if r is nil.
+func (r *RoutingKeyPayload) Clone() *RoutingKeyPayload {
+	if r == nil {
+		return nil
+	}
+	return &RoutingKeyPayload{
+		LocalRoutingKeyIdentifier: r.LocalRoutingKeyIdentifier.Clone(),
+		RoutingContext:            r.RoutingContext.Clone(),
+		TrafficModeType:           r.TrafficModeType.Clone(),
+		DestinationPointCode:      r.DestinationPointCode.Clone(),
+		NetworkAppearance:         r.NetworkAppearance.Clone(),
+		ServiceIndicators:         r.ServiceIndicators.Clone(),
+		OriginatingPointCodeList:  r.OriginatingPointCodeList.Clone(),
+	}
+}
+
 // Note that this parameter contains some optional parameters inside.
 
 // NewRoutingKey creates a new RoutingKey.
@@ -43,8 +59,8 @@ func NewRoutingKey(rk *RoutingKeyPayload) *Param {
 
 // RoutingKey returns RoutingKeyPayload.
 func (p *Param) RoutingKey() (*RoutingKeyPayload, error) {
-	if p.Tag != RoutingKey {
-		return nil, ErrInvalidType
+	if err := p.checkTag(RoutingKey); err != nil {
+		return nil, err
 	}
 
 	r, err := ParseRoutingKeyPayload(p.Data)
@@ -96,6 +112,42 @@ func (r *RoutingKeyPayload) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// Matches reports whether the message with the DPC, SI and OPC given matches
+// the RoutingKeyPayload, with the error if any of the parameters is malformed.
+// The parameters that are absent in the RoutingKeyPayload match any value.
+func (r *RoutingKeyPayload) Matches(dpc uint32, si uint8, opc uint32) (bool, error) {
+	if r.DestinationPointCode != nil {
+		v, err := r.DestinationPointCode.DecodeDestinationPointCode()
+		if err != nil {
+			return false, err
+		}
+		if v != dpc&0xffffff {
+			return false, nil
+		}
+	}
+
+	if r.ServiceIndicators != nil {
+		sis, err := r.ServiceIndicators.ServiceIndicatorList()
+		if err != nil {
+			return false, err
+		}
+		if !sis.Matches(si) {
+			return false, nil
+		}
+	}
+
+	if r.OriginatingPointCodeList != nil {
+		opcs, err := r.OriginatingPointCodeList.OriginatingPointCodesWithMask()
+		if err != nil {
+			return false, err
+		}
+		if !opcs.Contains(opc) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // DecodeRoutingKeyPayload decodes given byte sequence as a RoutingKeyPayload.
 //
 // DEPRECATED: use ParseRoutingKeyPayload instead.
@@ -4,6 +4,8 @@
 
 package params
 
+import "fmt"
+
 // NewServiceIndicators creates the ServiceIndicators Parameter.
 // Note that this returns *Param, as no specific structure in this parameter.
 func NewServiceIndicators(si ...uint8) *Param {
@@ -18,3 +20,58 @@ func (p *Param) ServiceIndicators() []uint8 {
 
 	return p.Data
 }
+
+// DecodeServiceIndicators returns the Service Indicators in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeServiceIndicators() ([]uint8, error) {
+	return p.decodeBytes(ServiceIndicators)
+}
+
+// ServiceIndicatorList is the list of the Service Indicators.
+type ServiceIndicatorList []uint8
+
+// ServiceIndicatorList returns the Service Indicators in the Param as
+// ServiceIndicatorList, with the error if the Param is nil, of the other type,
+// or has the invalid values.
+//
+// All the octets within the Length are returned, including the zero ones at
+// the end, as they cannot be told apart from SNM (0).
+func (p *Param) ServiceIndicatorList() (ServiceIndicatorList, error) {
+	b, err := p.decodeBytes(ServiceIndicators)
+	if err != nil {
+		return nil, err
+	}
+
+	sis := ServiceIndicatorList(b)
+	if err := sis.Validate(); err != nil {
+		return nil, err
+	}
+	return sis, nil
+}
+
+// Validate checks if the ServiceIndicatorList is not empty and all the
+// Service Indicators fit in 4 bits.
+func (s ServiceIndicatorList) Validate() error {
+	if len(s) == 0 {
+		return ErrInvalidLength
+	}
+	for _, si := range s {
+		if si > 0x0f {
+			return fmt.Errorf("%w: service indicator %d", ErrInvalidValue, si)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the Service Indicator given is in the list.
+// Note that the absence of the parameter in a Routing Key means any Service
+// Indicator, which should be handled by the caller as an empty list does
+// not match anything.
+func (s ServiceIndicatorList) Matches(si uint8) bool {
+	for _, v := range s {
+		if v == si {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@
 
 package params
 
+import "fmt"
+
 // Status Type definitions.
 const (
 	AsStateChange uint16 = iota + 1
@@ -60,3 +62,34 @@ func (p *Param) StatusInfo() uint16 {
 
 	return uint16(p.decodeUint32ValData() & 0xffff)
 }
+
+// StatusParam is the decoded value of Status, which consists of the
+// Status Type and Status Information.
+type StatusParam struct {
+	Type uint16
+	Info uint16
+}
+
+// Uint32 returns the combination of Status Type and Status Information,
+// which can be compared with the constants like AsStateActive.
+func (s StatusParam) Uint32() uint32 {
+	return uint32(s.Type)<<16 | uint32(s.Info)
+}
+
+// String returns the name of the Status, or the raw values if unknown.
+func (s StatusParam) String() string {
+	if name := StatusName(s.Uint32()); name != "" {
+		return name
+	}
+	return fmt.Sprintf("{Type: %d, Info: %d}", s.Type, s.Info)
+}
+
+// DecodeStatus returns the Status Type and Status Information in the Param,
+// with the error if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeStatus() (StatusParam, error) {
+	v, err := p.decodeUint32(Status)
+	if err != nil {
+		return StatusParam{}, err
+	}
+	return StatusParam{Type: uint16(v >> 16), Info: uint16(v)}, nil
+}
@@ -26,3 +26,9 @@ func (p *Param) TrafficModeType() uint32 {
 
 	return p.decodeUint32ValData()
 }
+
+// DecodeTrafficModeType returns the Traffic Mode Type in the Param, with the error
+// if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeTrafficModeType() (uint32, error) {
+	return p.decodeUint32(TrafficModeType)
+}
@@ -60,3 +60,20 @@ func (p *Param) UnavailabilityCause() uint16 {
 
 	return uint16(p.decodeUint32ValData() >> 16)
 }
+
+// UserCauseParam is the decoded value of User/Cause, which consists of the
+// Unavailability Cause and the MTP3-User Identity.
+type UserCauseParam struct {
+	Cause uint16
+	User  uint16
+}
+
+// DecodeUserCause returns the Unavailability Cause and MTP3-User Identity in
+// the Param, with the error if the Param is nil, of the other type, or malformed.
+func (p *Param) DecodeUserCause() (UserCauseParam, error) {
+	v, err := p.decodeUint32(UserCause)
+	if err != nil {
+		return UserCauseParam{}, err
+	}
+	return UserCauseParam{Cause: uint16(v >> 16), User: uint16(v)}, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrStandardMessage is returned when registering the message type that is
+// defined in RFC4666 and implemented in this package.
+var ErrStandardMessage = errors.New("cannot override standard message type")
+
+var standardMessages = map[uint16]func() M3UA{
+	// Transfer Messages
+	msgKey(MsgClassTransfer, MsgTypePayloadData): func() M3UA { return &Data{} },
+	// SSNM Messages
+	msgKey(MsgClassSSNM, MsgTypeDestinationUnavailable):         func() M3UA { return &DestinationUnavailable{} },
+	msgKey(MsgClassSSNM, MsgTypeDestinationAvailable):           func() M3UA { return &DestinationAvailable{} },
+	msgKey(MsgClassSSNM, MsgTypeDestinationStateAudit):          func() M3UA { return &DestinationStateAudit{} },
+	msgKey(MsgClassSSNM, MsgTypeSignallingCongestion):           func() M3UA { return &SignallingCongestion{} },
+	msgKey(MsgClassSSNM, MsgTypeDestinationUserPartUnavailable): func() M3UA { return &DestinationUserPartUnavailable{} },
+	msgKey(MsgClassSSNM, MsgTypeDestinationRestricted):          func() M3UA { return &DestinationRestricted{} },
+	// ASPSM Messages
+	msgKey(MsgClassASPSM, MsgTypeAspUp):        func() M3UA { return &AspUp{} },
+	msgKey(MsgClassASPSM, MsgTypeAspDown):      func() M3UA { return &AspDown{} },
+	msgKey(MsgClassASPSM, MsgTypeHeartbeat):    func() M3UA { return &Heartbeat{} },
+	msgKey(MsgClassASPSM, MsgTypeAspUpAck):     func() M3UA { return &AspUpAck{} },
+	msgKey(MsgClassASPSM, MsgTypeAspDownAck):   func() M3UA { return &AspDownAck{} },
+	msgKey(MsgClassASPSM, MsgTypeHeartbeatAck): func() M3UA { return &HeartbeatAck{} },
+	// ASPTM Messages
+	msgKey(MsgClassASPTM, MsgTypeAspActive):      func() M3UA { return &AspActive{} },
+	msgKey(MsgClassASPTM, MsgTypeAspActiveAck):   func() M3UA { return &AspActiveAck{} },
+	msgKey(MsgClassASPTM, MsgTypeAspInactive):    func() M3UA { return &AspInactive{} },
+	msgKey(MsgClassASPTM, MsgTypeAspInactiveAck): func() M3UA { return &AspInactiveAck{} },
+	// Management Messages
+	msgKey(MsgClassManagement, MsgTypeError):  func() M3UA { return &Error{} },
+	msgKey(MsgClassManagement, MsgTypeNotify): func() M3UA { return &Notify{} },
+}
+
+var (
+	muCustomMessages sync.RWMutex
+	customMessages   = map[uint16]func() M3UA{}
+)
+
+// Register registers the function that creates the message of the combination
+// of class and type given, so that Parse decodes such messages into the type
+// created by newFunc instead of *Generic. This is for the vendor-specific
+// messages, and the UnmarshalBinary of the type is called to decode them.
+//
+// The message types implemented in this package cannot be overridden, and
+// ErrStandardMessage is returned for them. Registering the same combination
+// again replaces the previous one, and nil newFunc removes it.
+func Register(class, mtype uint8, newFunc func() M3UA) error {
+	key := msgKey(class, mtype)
+	if _, ok := standardMessages[key]; ok {
+		return ErrStandardMessage
+	}
+
+	muCustomMessages.Lock()
+	defer muCustomMessages.Unlock()
+	if newFunc == nil {
+		delete(customMessages, key)
+		return nil
+	}
+	customMessages[key] = newFunc
+	return nil
+}
+
+// newMessage returns the empty message of the combination of class and type
+// given, or *Generic if it is unknown.
+func newMessage(class, mtype uint8) M3UA {
+	key := msgKey(class, mtype)
+	if newFunc, ok := standardMessages[key]; ok {
+		return newFunc()
+	}
+
+	muCustomMessages.RLock()
+	newFunc, ok := customMessages[key]
+	muCustomMessages.RUnlock()
+	if ok {
+		return newFunc()
+	}
+
+	// If the combination of class and type is unknown or not supported, *Generic is used.
+	return &Generic{}
+}
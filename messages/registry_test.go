@@ -0,0 +1,63 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// vendorMessage is a vendor-specific message used in the tests.
+type vendorMessage struct {
+	Generic
+}
+
+func (v *vendorMessage) MessageClassName() string { return "Vendor" }
+
+func (v *vendorMessage) MessageTypeName() string { return "Vendor Message" }
+
+func TestRegister(t *testing.T) {
+	if err := Register(0x0a, 1, func() M3UA { return &vendorMessage{} }); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = Register(0x0a, 1, nil) }()
+
+	b, err := New(1, 0x0a, 1, params.NewInfoString("vendor")).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok := m.(*vendorMessage)
+	if !ok {
+		t.Fatalf("got %T, want *vendorMessage", m)
+	}
+	if got := v.Params[0].InfoString(); got != "vendor" {
+		t.Errorf("got %s, want vendor", got)
+	}
+
+	// the other types in the same class are still Generic.
+	b[3] = 2
+	if m, err := Parse(b); err != nil {
+		t.Fatal(err)
+	} else if _, ok := m.(*Generic); !ok {
+		t.Errorf("got %T, want *Generic", m)
+	}
+
+	if err := Register(MsgClassTransfer, MsgTypePayloadData, func() M3UA { return &vendorMessage{} }); err != ErrStandardMessage {
+		t.Errorf("got %v, want %v", err, ErrStandardMessage)
+	}
+
+	_ = Register(0x0a, 1, nil)
+	b[3] = 1
+	if m, err := Parse(b); err != nil {
+		t.Fatal(err)
+	} else if _, ok := m.(*Generic); !ok {
+		t.Errorf("got %T after unregistering, want *Generic", m)
+	}
+}
@@ -44,6 +44,18 @@ func NewSignallingCongestion(nwApr, rtCtx, apc, cdst, ind, info *params.Param) *
 	return s
 }
 
+// NewSignallingCongestionWithMask creates a new SignallingCongestion with the Affected Point Codes
+// given as the point codes with masks. The parameter is omitted if apcs is empty.
+func NewSignallingCongestionWithMask(nwApr, rtCtx *params.Param, apcs []params.PointCodeWithMask, cdst, ind, info *params.Param) *SignallingCongestion {
+	return NewSignallingCongestion(nwApr, rtCtx, newAffectedPointCode(apcs), cdst, ind, info)
+}
+
+// AffectedPointCodes returns the point codes with masks in the Affected
+// Point Code parameter of the SignallingCongestion.
+func (s *SignallingCongestion) AffectedPointCodes() (params.PointCodeList, error) {
+	return s.AffectedPointCode.AffectedPointCodesWithMask()
+}
+
 // MarshalBinary returns the byte sequence generated from a SignallingCongestion.
 func (s *SignallingCongestion) MarshalBinary() ([]byte, error) {
 	b := make([]byte, s.MarshalLen())
@@ -59,46 +71,45 @@ func (s *SignallingCongestion) MarshalTo(b []byte) error {
 		return ErrTooShortToMarshalBinary
 	}
 
-	s.Header.Payload = make([]byte, s.MarshalLen()-8)
-
-	var offset = 0
+	var offset = 8
 	if param := s.NetworkAppearance; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := s.RoutingContext; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := s.AffectedPointCode; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := s.ConcernedDestination; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := s.CongestionIndications; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 		offset += param.MarshalLen()
 	}
 	if param := s.InfoString; param != nil {
-		if err := param.MarshalTo(s.Header.Payload[offset:]); err != nil {
+		if err := param.MarshalTo(b[offset:]); err != nil {
 			return err
 		}
 	}
 
-	return s.Header.MarshalTo(b)
+	s.Header.marshalFieldsTo(b)
+	return nil
 }
 
 // ParseSignallingCongestion decodes given byte sequence as a SignallingCongestion.
@@ -118,7 +129,7 @@ func (s *SignallingCongestion) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
-	prs, err := params.ParseMultiParams(s.Header.Payload)
+	prs, err := parseParams(s.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -191,6 +202,12 @@ func (s *SignallingCongestion) MarshalLen() int {
 	return l
 }
 
+// Validate checks if the SignallingCongestion is valid in terms of the presence, order, and
+// length of the parameters, and the Length field in the header.
+func (s *SignallingCongestion) Validate() error {
+	return validate(s, s.Header, s.NetworkAppearance, s.RoutingContext, s.AffectedPointCode, s.ConcernedDestination, s.CongestionIndications, s.InfoString)
+}
+
 // String returns the SignallingCongestion values in human readable format.
 func (s *SignallingCongestion) String() string {
 	return fmt.Sprintf("{Header: %s, NetworkAppearance: %s, RoutingContext: %s, AffectedPointCode: %s, ConcernedDestination: %s, CongestionIndications: %s, InfoString: %s}",
@@ -204,6 +221,27 @@ func (s *SignallingCongestion) String() string {
 	)
 }
 
+// MarshalJSON returns the JSON encoding of SignallingCongestion.
+func (s *SignallingCongestion) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of SignallingCongestion.
+func (s *SignallingCongestion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, s)
+}
+
+// MarshalYAML returns the value to be encoded in YAML instead of SignallingCongestion,
+// which has the same structure as the JSON encoding.
+func (s *SignallingCongestion) MarshalYAML() (interface{}, error) {
+	return newMessageJSON(s)
+}
+
+// UnmarshalYAML sets the values retrieved from the YAML encoding of SignallingCongestion.
+func (s *SignallingCongestion) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
 // Version returns the version of M3UA in int.
 func (s *SignallingCongestion) Version() uint8 {
 	return s.Header.Version
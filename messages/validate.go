@@ -0,0 +1,316 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"fmt"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// ValidationError is an error returned by Validate and ParseStrict.
+//
+// Code is the Error Code defined in RFC4666 that corresponds to the violation,
+// which can be used as it is in the Error message to be sent back to the peer.
+type ValidationError struct {
+	Code   uint32
+	Tag    uint16
+	Reason string
+}
+
+func newValidationError(code uint32, tag uint16, format string, v ...interface{}) *ValidationError {
+	return &ValidationError{Code: code, Tag: tag, Reason: fmt.Sprintf(format, v...)}
+}
+
+// Error returns error string with the reason and Error Code.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed: %s (error code: %d)", e.Reason, e.Code)
+}
+
+type presence uint8
+
+const (
+	mandatory presence = iota
+	conditional
+	optional
+)
+
+type paramRule struct {
+	tag      uint16
+	presence presence
+}
+
+func msgKey(class, mtype uint8) uint16 {
+	return uint16(class)<<8 | uint16(mtype)
+}
+
+// paramRules defines the parameters allowed in each message, in the order
+// that they appear in the message format in RFC4666.
+var paramRules = map[uint16][]paramRule{
+	// Management Messages
+	msgKey(MsgClassManagement, MsgTypeError): {
+		{params.ErrorCode, mandatory},
+		{params.RoutingContext, conditional},
+		{params.NetworkAppearance, conditional},
+		{params.AffectedPointCode, conditional},
+		{params.DiagnosticInformation, conditional},
+	},
+	msgKey(MsgClassManagement, MsgTypeNotify): {
+		{params.Status, mandatory},
+		{params.AspIdentifier, conditional},
+		{params.RoutingContext, conditional},
+		{params.InfoString, optional},
+	},
+	// Transfer Messages
+	msgKey(MsgClassTransfer, MsgTypePayloadData): {
+		{params.NetworkAppearance, optional},
+		{params.RoutingContext, conditional},
+		{params.ProtocolData, mandatory},
+		{params.CorrelationID, optional},
+	},
+	// SSNM Messages
+	msgKey(MsgClassSSNM, MsgTypeDestinationUnavailable): ssnmRules,
+	msgKey(MsgClassSSNM, MsgTypeDestinationAvailable):   ssnmRules,
+	msgKey(MsgClassSSNM, MsgTypeDestinationStateAudit):  ssnmRules,
+	msgKey(MsgClassSSNM, MsgTypeDestinationRestricted):  ssnmRules,
+	msgKey(MsgClassSSNM, MsgTypeSignallingCongestion): {
+		{params.NetworkAppearance, optional},
+		{params.RoutingContext, conditional},
+		{params.AffectedPointCode, mandatory},
+		{params.ConcernedDestination, optional},
+		{params.CongestionIndications, optional},
+		{params.InfoString, optional},
+	},
+	msgKey(MsgClassSSNM, MsgTypeDestinationUserPartUnavailable): {
+		{params.NetworkAppearance, optional},
+		{params.RoutingContext, conditional},
+		{params.AffectedPointCode, mandatory},
+		{params.UserCause, mandatory},
+		{params.InfoString, optional},
+	},
+	// ASPSM Messages
+	msgKey(MsgClassASPSM, MsgTypeAspUp):        aspUpRules,
+	msgKey(MsgClassASPSM, MsgTypeAspUpAck):     aspUpRules,
+	msgKey(MsgClassASPSM, MsgTypeAspDown):      aspDownRules,
+	msgKey(MsgClassASPSM, MsgTypeAspDownAck):   aspDownRules,
+	msgKey(MsgClassASPSM, MsgTypeHeartbeat):    heartbeatRules,
+	msgKey(MsgClassASPSM, MsgTypeHeartbeatAck): heartbeatRules,
+	// ASPTM Messages
+	msgKey(MsgClassASPTM, MsgTypeAspActive):      aspActiveRules,
+	msgKey(MsgClassASPTM, MsgTypeAspActiveAck):   aspActiveRules,
+	msgKey(MsgClassASPTM, MsgTypeAspInactive):    aspInactiveRules,
+	msgKey(MsgClassASPTM, MsgTypeAspInactiveAck): aspInactiveRules,
+}
+
+var (
+	ssnmRules = []paramRule{
+		{params.NetworkAppearance, optional},
+		{params.RoutingContext, conditional},
+		{params.AffectedPointCode, mandatory},
+		{params.InfoString, optional},
+	}
+	aspUpRules = []paramRule{
+		{params.AspIdentifier, optional},
+		{params.InfoString, optional},
+	}
+	aspDownRules = []paramRule{
+		{params.InfoString, optional},
+	}
+	heartbeatRules = []paramRule{
+		{params.HeartbeatData, optional},
+	}
+	aspActiveRules = []paramRule{
+		{params.TrafficModeType, optional},
+		{params.RoutingContext, optional},
+		{params.InfoString, optional},
+	}
+	aspInactiveRules = []paramRule{
+		{params.RoutingContext, optional},
+		{params.InfoString, optional},
+	}
+)
+
+// fixedParamLen is the length of the Parameter Value of the parameters that
+// have fixed length.
+var fixedParamLen = map[uint16]int{
+	params.TrafficModeType:           4,
+	params.ErrorCode:                 4,
+	params.Status:                    4,
+	params.AspIdentifier:             4,
+	params.CorrelationID:             4,
+	params.NetworkAppearance:         4,
+	params.UserCause:                 4,
+	params.CongestionIndications:     4,
+	params.ConcernedDestination:      4,
+	params.LocalRoutingKeyIdentifier: 4,
+	params.DestinationPointCode:      4,
+	params.RegistrationStatus:        4,
+	params.DeregistrationStatus:      4,
+}
+
+// listParamLen is the length of each entry in the parameters that contain
+// one or more fixed-length values.
+var listParamLen = map[uint16]int{
+	params.RoutingContext:           4,
+	params.AffectedPointCode:        4,
+	params.OriginatingPointCodeList: 4,
+}
+
+// validateParam checks if the length of a parameter is consistent with its
+// Length field and its definition.
+func validateParam(p *params.Param) error {
+	if int(p.Length) != 4+len(p.Data) {
+		return newValidationError(
+			params.ErrParameterFieldError, p.Tag,
+			"length field %d does not match the actual length %d", p.Length, 4+len(p.Data),
+		)
+	}
+
+	l := len(p.Data)
+	if n, ok := fixedParamLen[p.Tag]; ok && l != n {
+		return newValidationError(
+			params.ErrParameterFieldError, p.Tag, "length of value should be %d, got %d", n, l,
+		)
+	}
+	if n, ok := listParamLen[p.Tag]; ok && (l == 0 || l%n != 0) {
+		return newValidationError(
+			params.ErrParameterFieldError, p.Tag, "length of value should be a multiple of %d, got %d", n, l,
+		)
+	}
+	if p.Tag == params.ProtocolData && l < 12 {
+		return newValidationError(
+			params.ErrParameterFieldError, p.Tag, "too short to contain Protocol Data: %d", l,
+		)
+	}
+
+	return nil
+}
+
+// validateParams checks the header and the parameters in a message.
+//
+// The given parameters should be in the same order as they are (or will be)
+// on the wire, and l should be the actual length of the message.
+func validateParams(h *Header, l int, prs []*params.Param) error {
+	if h.Version != 1 {
+		return newValidationError(params.InvalidVersionError, 0, "unsupported version: %d", h.Version)
+	}
+	if int(h.Length) != l {
+		return newValidationError(
+			params.ErrProtocolError, 0, "length field %d does not match the actual length %d", h.Length, l,
+		)
+	}
+
+	for _, p := range prs {
+		if err := validateParam(p); err != nil {
+			return err
+		}
+	}
+
+	rules, ok := paramRules[msgKey(h.Class, h.Type)]
+	if !ok {
+		return nil
+	}
+
+	next := 0
+	for i, p := range prs {
+		for _, q := range prs[:i] {
+			if q.Tag == p.Tag {
+				return newValidationError(params.ErrUnexpectedParameter, p.Tag, "duplicated parameter: %d", p.Tag)
+			}
+		}
+
+		idx := -1
+		for j, r := range rules {
+			if r.tag == p.Tag {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return newValidationError(params.ErrUnexpectedParameter, p.Tag, "unexpected parameter: %d", p.Tag)
+		}
+		if idx < next {
+			return newValidationError(params.ErrProtocolError, p.Tag, "parameter out of order: %d", p.Tag)
+		}
+		next = idx + 1
+	}
+
+	for _, r := range rules {
+		if r.presence != mandatory {
+			continue
+		}
+		if !hasParam(prs, r.tag) {
+			return newValidationError(params.ErrMissingParameter, r.tag, "missing mandatory parameter: %d", r.tag)
+		}
+	}
+
+	return nil
+}
+
+func hasParam(prs []*params.Param, tag uint16) bool {
+	for _, p := range prs {
+		if p.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the header and the parameters in a message structure.
+// The parameters are given in the order of the fields, and nil ones are
+// treated as absent.
+func validate(m M3UA, h *Header, fields ...*params.Param) error {
+	if h == nil {
+		return newValidationError(params.ErrProtocolError, 0, "no header")
+	}
+	if h.Class != m.MessageClass() || h.Type != m.MessageType() {
+		return newValidationError(
+			params.ErrProtocolError, 0, "class/type %d/%d in header does not match %s",
+			h.Class, h.Type, m.MessageTypeName(),
+		)
+	}
+
+	prs := make([]*params.Param, 0, len(fields))
+	for _, f := range fields {
+		if f != nil {
+			prs = append(prs, f)
+		}
+	}
+
+	return validateParams(h, m.MarshalLen(), prs)
+}
+
+// ParseStrict decodes the given bytes like Parse, and additionally validates the
+// message: the Length field in the header, the presence and order of the
+// parameters, and the length of each parameter.
+//
+// The returned error is *ValidationError if the message is decodable but invalid,
+// which contains the Error Code to be used in the Error message.
+func ParseStrict(b []byte) (M3UA, error) {
+	h, err := ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := params.ParseMultiParams(h.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateParams(h, len(b), prs); err != nil {
+		return nil, err
+	}
+
+	m, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := m.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
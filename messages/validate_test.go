@@ -0,0 +1,176 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package messages
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  interface{ Validate() error }
+		code uint32 // 0 if valid
+	}{
+		{
+			"notify/valid",
+			NewNotify(params.NewStatus(params.AsStateActive), nil, params.NewRoutingContext(1), nil),
+			0,
+		},
+		{
+			"notify/no-status",
+			NewNotify(nil, nil, params.NewRoutingContext(1), nil),
+			params.ErrMissingParameter,
+		},
+		{
+			"data/no-protocol-data",
+			NewData(nil, params.NewRoutingContext(1), nil, nil),
+			params.ErrMissingParameter,
+		},
+		{
+			"dupu/no-user-cause",
+			NewDestinationUserPartUnavailable(nil, nil, params.NewAffectedPointCode(1), nil, nil),
+			params.ErrMissingParameter,
+		},
+		{
+			"error/invalid-rc-without-rc",
+			NewError(params.NewErrorCode(params.ErrInvalidRoutingContext), nil, nil, nil, nil),
+			params.ErrMissingParameter,
+		},
+		{
+			"error/invalid-rc-with-rc",
+			NewError(params.NewErrorCode(params.ErrInvalidRoutingContext), params.NewRoutingContext(1), nil, nil, nil),
+			0,
+		},
+		{
+			"asp-active/malformed-tmt",
+			NewAspActive(params.NewParam(int(params.TrafficModeType), []byte{0x01, 0x02}), nil, nil),
+			params.ErrParameterFieldError,
+		},
+		{
+			"generic/unexpected-param",
+			New(1, MsgClassASPSM, MsgTypeAspUp, params.NewTrafficModeType(1)),
+			params.ErrUnexpectedParameter,
+		},
+		{
+			"generic/unknown-class",
+			New(1, 0x0a, 1, params.NewTrafficModeType(1)),
+			0,
+		},
+		{
+			"generic/invalid-version",
+			New(2, MsgClassASPSM, MsgTypeAspUp),
+			params.InvalidVersionError,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.msg.Validate()
+			if c.code == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got %v, want *ValidationError", err)
+			}
+			if verr.Code != c.code {
+				t.Errorf("got code %d, want %d", verr.Code, c.code)
+			}
+		})
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	cases := []struct {
+		name       string
+		serialized []byte
+		code       uint32 // 0 if valid
+	}{
+		{
+			"valid",
+			[]byte{
+				// Header
+				0x01, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x18,
+				// TrafficModeType
+				0x00, 0x0b, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02,
+				// RoutingContext
+				0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+			},
+			0,
+		},
+		{
+			"out-of-order",
+			[]byte{
+				// Header
+				0x01, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x18,
+				// RoutingContext
+				0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+				// TrafficModeType
+				0x00, 0x0b, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02,
+			},
+			params.ErrProtocolError,
+		},
+		{
+			"duplicated",
+			[]byte{
+				// Header
+				0x01, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x18,
+				// RoutingContext
+				0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+				// RoutingContext
+				0x00, 0x06, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02,
+			},
+			params.ErrUnexpectedParameter,
+		},
+		{
+			"unexpected",
+			[]byte{
+				// Header
+				0x01, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x10,
+				// Status
+				0x00, 0x0d, 0x00, 0x08, 0x00, 0x01, 0x00, 0x03,
+			},
+			params.ErrUnexpectedParameter,
+		},
+		{
+			"length-mismatch",
+			[]byte{
+				// Header
+				0x01, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x08,
+				// TrafficModeType
+				0x00, 0x0b, 0x00, 0x08, 0x00, 0x00, 0x00, 0x02,
+			},
+			params.ErrProtocolError,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseStrict(c.serialized)
+			if c.code == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got %v, want *ValidationError", err)
+			}
+			if verr.Code != c.code {
+				t.Errorf("got code %d, want %d", verr.Code, c.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"time"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// ConnInfo identifies the Conn in Metrics.
+type ConnInfo struct {
+	// LocalAddr and RemoteAddr are the primary addresses of the SCTP
+	// association, which are retrieved once when the Conn is established.
+	LocalAddr, RemoteAddr string
+	// AssocID is the SCTP association ID.
+	AssocID int32
+}
+
+// Metrics is the interface to collect the metrics of the Conns, which is set
+// in Config. The package metrics provides the implementation that exposes them
+// in the Prometheus text format.
+//
+// The methods are called synchronously in the goroutines that send or receive
+// the messages, so the implementation should be safe for concurrent use and
+// return quickly.
+type Metrics interface {
+	// CountMessage is called with every M3UA message sent or received.
+	// The message should not be retained.
+	CountMessage(conn ConnInfo, dir Direction, m messages.M3UA)
+	// CountData is called with every DATA sent or received, with the Routing
+	// Context and the Service Indicator and the size of the user data in the
+	// Protocol Data. The rc is zero if the DATA has no Routing Context.
+	CountData(conn ConnInfo, dir Direction, rc uint32, si params.ServiceIndicator, size int)
+	// CountError is called with every ERROR sent or received, with the
+	// Error Code in it.
+	CountError(conn ConnInfo, dir Direction, code uint32)
+	// ObserveHeartbeatRTT is called when the BEAT ACK is received for the
+	// BEAT sent, with the time from sending the BEAT.
+	ObserveHeartbeatRTT(conn ConnInfo, rtt time.Duration)
+	// ChangeState is called when the state of the Conn changes.
+	ChangeState(conn ConnInfo, from, to State)
+	// Forget is called when the Conn is closed, after the last ChangeState.
+	// The implementation should drop the metrics of the Conn, as the same
+	// ConnInfo is not used again once the association is gone.
+	Forget(conn ConnInfo)
+}
+
+// metrics returns the Metrics in the Config of the Conn, or nil if not set.
+func (c *Conn) metrics() Metrics {
+	if cfg := c.config(); cfg != nil {
+		return cfg.Metrics
+	}
+	return nil
+}
+
+func (c *Conn) countMessage(dir Direction, m messages.M3UA) {
+	metrics := c.metrics()
+	if metrics == nil {
+		return
+	}
+	metrics.CountMessage(c.info, dir, m)
+
+	switch msg := m.(type) {
+	case *messages.Data:
+		c.countData(metrics, dir, msg)
+	case *messages.Error:
+		if code, err := msg.ErrorCode.DecodeErrorCode(); err == nil {
+			metrics.CountError(c.info, dir, code)
+		}
+	}
+}
+
+func (c *Conn) countData(metrics Metrics, dir Direction, d *messages.Data) {
+	// the first Routing Context is used as DATA should have only one.
+	var rc uint32
+	if rcs, err := d.RoutingContext.DecodeRoutingContexts(); err == nil {
+		rc = rcs[0]
+	}
+
+	// OPC, DPC, SI, NI, MP and SLS precede the user data.
+	if d.ProtocolData == nil || len(d.ProtocolData.Data) < 12 {
+		return
+	}
+	pd := d.ProtocolData.Data
+	metrics.CountData(c.info, dir, rc, params.ServiceIndicator(pd[8]), len(pd)-12)
+}
+
+func (c *Conn) observeHeartbeatRTT(rtt time.Duration) {
+	if metrics := c.metrics(); metrics != nil {
+		metrics.ObserveHeartbeatRTT(c.info, rtt)
+	}
+}
+
+func (c *Conn) forgetMetrics() {
+	if metrics := c.metrics(); metrics != nil {
+		metrics.Forget(c.info)
+	}
+}
+
+func (c *Conn) changeState(from, to State) {
+	if metrics := c.metrics(); metrics != nil {
+		metrics.ChangeState(c.info, from, to)
+	}
+}
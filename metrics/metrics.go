@@ -0,0 +1,364 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package metrics provides the implementation of m3ua.Metrics that exposes
+// the metrics of M3UA connections in the Prometheus text exposition format,
+// without depending on the Prometheus client library.
+//
+// The metrics are labeled with the addresses and the association ID of the
+// connection, and exposed as follows.
+//
+//	m3ua_messages_total               counter   direction, class, type
+//	m3ua_data_messages_total          counter   direction, rc, si
+//	m3ua_data_bytes_total             counter   direction, rc, si
+//	m3ua_errors_total                 counter   direction, code
+//	m3ua_heartbeat_rtt_seconds        histogram
+//	m3ua_state_transitions_total      counter   from, to
+//	m3ua_state                        gauge     state
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-m3ua"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// ContentType is the Content-Type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultRTTBuckets is the upper bounds of the buckets of the heartbeat RTT
+// histogram in seconds, used when no buckets are given to New.
+var DefaultRTTBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// states is the states exposed in m3ua_state.
+var states = []m3ua.State{
+	m3ua.StateAspDown, m3ua.StateAspInactive, m3ua.StateAspActive, m3ua.StateSCTPCDI, m3ua.StateSCTPRI,
+}
+
+type messageKey struct {
+	conn        m3ua.ConnInfo
+	dir         m3ua.Direction
+	class, name string
+}
+
+type dataKey struct {
+	conn m3ua.ConnInfo
+	dir  m3ua.Direction
+	rc   uint32
+	si   params.ServiceIndicator
+}
+
+type dataCount struct {
+	msus, bytes uint64
+}
+
+type errorKey struct {
+	conn m3ua.ConnInfo
+	dir  m3ua.Direction
+	code uint32
+}
+
+type transitionKey struct {
+	conn     m3ua.ConnInfo
+	from, to m3ua.State
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Collector collects the metrics of the M3UA connections, which implements
+// m3ua.Metrics and http.Handler to serve them in the Prometheus text format.
+//
+// Collector is safe for concurrent use.
+type Collector struct {
+	mu          sync.Mutex
+	buckets     []float64
+	messages    map[messageKey]uint64
+	data        map[dataKey]*dataCount
+	errors      map[errorKey]uint64
+	rtts        map[m3ua.ConnInfo]*histogram
+	transitions map[transitionKey]uint64
+	states      map[m3ua.ConnInfo]m3ua.State
+}
+
+// New creates a new Collector with the upper bounds of the buckets of the
+// heartbeat RTT histogram in seconds. DefaultRTTBuckets is used if no
+// buckets are given.
+func New(rttBuckets ...float64) *Collector {
+	if len(rttBuckets) == 0 {
+		rttBuckets = DefaultRTTBuckets
+	}
+	buckets := append([]float64(nil), rttBuckets...)
+	sort.Float64s(buckets)
+
+	return &Collector{
+		buckets:     buckets,
+		messages:    map[messageKey]uint64{},
+		data:        map[dataKey]*dataCount{},
+		errors:      map[errorKey]uint64{},
+		rtts:        map[m3ua.ConnInfo]*histogram{},
+		transitions: map[transitionKey]uint64{},
+		states:      map[m3ua.ConnInfo]m3ua.State{},
+	}
+}
+
+// CountMessage counts the M3UA message by the class and type.
+func (c *Collector) CountMessage(conn m3ua.ConnInfo, dir m3ua.Direction, m messages.M3UA) {
+	key := messageKey{conn, dir, m.MessageClassName(), m.MessageTypeName()}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages[key]++
+}
+
+// CountData counts the MSUs and the bytes of the user data by the Routing
+// Context and the Service Indicator.
+func (c *Collector) CountData(conn m3ua.ConnInfo, dir m3ua.Direction, rc uint32, si params.ServiceIndicator, size int) {
+	key := dataKey{conn, dir, rc, si}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.data[key]
+	if !ok {
+		d = &dataCount{}
+		c.data[key] = d
+	}
+	d.msus++
+	d.bytes += uint64(size)
+}
+
+// CountError counts the ERROR by the Error Code.
+func (c *Collector) CountError(conn m3ua.ConnInfo, dir m3ua.Direction, code uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errors[errorKey{conn, dir, code}]++
+}
+
+// ObserveHeartbeatRTT adds the RTT of the heartbeat to the histogram.
+func (c *Collector) ObserveHeartbeatRTT(conn m3ua.ConnInfo, rtt time.Duration) {
+	v := rtt.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.rtts[conn]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.rtts[conn] = h
+	}
+	for i, b := range c.buckets {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// ChangeState counts the state transition and updates the current state.
+func (c *Collector) ChangeState(conn m3ua.ConnInfo, from, to m3ua.State) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transitions[transitionKey{conn, from, to}]++
+	c.states[conn] = to
+}
+
+// Forget removes all the metrics of the connection given. It is called when
+// the Conn is closed, so that the series do not pile up as the associations
+// come and go.
+func (c *Collector) Forget(conn m3ua.ConnInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.messages {
+		if k.conn == conn {
+			delete(c.messages, k)
+		}
+	}
+	for k := range c.data {
+		if k.conn == conn {
+			delete(c.data, k)
+		}
+	}
+	for k := range c.errors {
+		if k.conn == conn {
+			delete(c.errors, k)
+		}
+	}
+	for k := range c.transitions {
+		if k.conn == conn {
+			delete(c.transitions, k)
+		}
+	}
+	delete(c.rtts, conn)
+	delete(c.states, conn)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = c.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format to w.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	c.mu.Lock()
+	fs := c.families()
+	c.mu.Unlock()
+
+	for _, f := range fs {
+		if len(f.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.typ)
+		// the series of a histogram must be kept in order.
+		if f.typ != "histogram" {
+			sort.Strings(f.samples)
+		}
+		for _, s := range f.samples {
+			bw.WriteString(s)
+		}
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+type family struct {
+	name, help, typ string
+	samples         []string
+}
+
+// families renders the samples of all the metrics, which should be called
+// with the lock held.
+func (c *Collector) families() []*family {
+	msgs := &family{name: "m3ua_messages_total", help: "Number of M3UA messages sent or received.", typ: "counter"}
+	for k, v := range c.messages {
+		msgs.add(labels(k.conn, "direction", dirLabel(k.dir), "class", k.class, "type", k.name), v)
+	}
+
+	msus := &family{name: "m3ua_data_messages_total", help: "Number of MSUs in DATA sent or received.", typ: "counter"}
+	bytes := &family{name: "m3ua_data_bytes_total", help: "Number of bytes of the user data in DATA sent or received.", typ: "counter"}
+	for k, v := range c.data {
+		l := labels(k.conn, "direction", dirLabel(k.dir), "rc", strconv.FormatUint(uint64(k.rc), 10), "si", k.si.String())
+		msus.add(l, v.msus)
+		bytes.add(l, v.bytes)
+	}
+
+	errs := &family{name: "m3ua_errors_total", help: "Number of ERROR sent or received.", typ: "counter"}
+	for k, v := range c.errors {
+		code := params.ErrorCodeName(k.code)
+		if code == "" {
+			code = strconv.FormatUint(uint64(k.code), 10)
+		}
+		errs.add(labels(k.conn, "direction", dirLabel(k.dir), "code", code), v)
+	}
+
+	rtt := &family{name: "m3ua_heartbeat_rtt_seconds", help: "Round trip time of BEAT and BEAT ACK.", typ: "histogram"}
+	conns := make([]m3ua.ConnInfo, 0, len(c.rtts))
+	for conn := range c.rtts {
+		conns = append(conns, conn)
+	}
+	sort.Slice(conns, func(i, j int) bool { return labels(conns[i]) < labels(conns[j]) })
+	for _, conn := range conns {
+		h := c.rtts[conn]
+		for i, b := range c.buckets {
+			rtt.addNamed("_bucket", labels(conn, "le", strconv.FormatFloat(b, 'g', -1, 64)), h.counts[i])
+		}
+		rtt.addNamed("_bucket", labels(conn, "le", "+Inf"), h.count)
+		rtt.samples = append(rtt.samples, fmt.Sprintf("%s_sum%s %s\n", rtt.name, labels(conn), strconv.FormatFloat(h.sum, 'g', -1, 64)))
+		rtt.addNamed("_count", labels(conn), h.count)
+	}
+
+	trans := &family{name: "m3ua_state_transitions_total", help: "Number of state transitions.", typ: "counter"}
+	for k, v := range c.transitions {
+		trans.add(labels(k.conn, "from", k.from.String(), "to", k.to.String()), v)
+	}
+
+	state := &family{name: "m3ua_state", help: "Current state of the connection, 1 for the current one.", typ: "gauge"}
+	for conn, current := range c.states {
+		for _, s := range states {
+			var v uint64
+			if s == current {
+				v = 1
+			}
+			state.add(labels(conn, "state", s.String()), v)
+		}
+	}
+
+	return []*family{msgs, msus, bytes, errs, rtt, trans, state}
+}
+
+func (f *family) add(labels string, v uint64) {
+	f.addNamed("", labels, v)
+}
+
+func (f *family) addNamed(suffix, labels string, v uint64) {
+	f.samples = append(f.samples, f.name+suffix+labels+" "+strconv.FormatUint(v, 10)+"\n")
+}
+
+// labels renders the labels of the connection and the additional pairs of
+// the label names and values.
+func labels(conn m3ua.ConnInfo, kvs ...string) string {
+	var b strings.Builder
+	b.WriteString(`{local_addr="`)
+	b.WriteString(escape(conn.LocalAddr))
+	b.WriteString(`",remote_addr="`)
+	b.WriteString(escape(conn.RemoteAddr))
+	b.WriteString(`",assoc_id="`)
+	b.WriteString(strconv.FormatInt(int64(conn.AssocID), 10))
+	b.WriteByte('"')
+	for i := 0; i+1 < len(kvs); i += 2 {
+		b.WriteByte(',')
+		b.WriteString(kvs[i])
+		b.WriteString(`="`)
+		b.WriteString(escape(kvs[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func dirLabel(d m3ua.Direction) string {
+	switch d {
+	case m3ua.DirectionSent:
+		return "sent"
+	case m3ua.DirectionReceived:
+		return "received"
+	default:
+		return "unknown"
+	}
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	return n, err
+}
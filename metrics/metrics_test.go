@@ -0,0 +1,91 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-m3ua"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestCollector(t *testing.T) {
+	var _ m3ua.Metrics = New()
+
+	c := New(0.01, 0.1)
+	conn := m3ua.ConnInfo{LocalAddr: "192.0.2.1:2905", RemoteAddr: "192.0.2.2:2905", AssocID: 1}
+	c.CountMessage(conn, m3ua.DirectionSent, messages.NewAspUp(nil, nil))
+	c.CountMessage(conn, m3ua.DirectionSent, messages.NewAspUp(nil, nil))
+	c.CountData(conn, m3ua.DirectionReceived, 10, params.ServiceIndicator(params.ServiceIndSCCP), 100)
+	c.CountData(conn, m3ua.DirectionReceived, 10, params.ServiceIndicator(params.ServiceIndSCCP), 50)
+	c.CountError(conn, m3ua.DirectionReceived, params.ErrInvalidRoutingContext)
+	c.ObserveHeartbeatRTT(conn, 5*time.Millisecond)
+	c.ObserveHeartbeatRTT(conn, 50*time.Millisecond)
+	c.ChangeState(conn, m3ua.StateAspDown, m3ua.StateAspInactive)
+
+	l := `local_addr="192.0.2.1:2905",remote_addr="192.0.2.2:2905",assoc_id="1"`
+	want := `# HELP m3ua_messages_total Number of M3UA messages sent or received.
+# TYPE m3ua_messages_total counter
+m3ua_messages_total{` + l + `,direction="sent",class="ASPSM",type="ASP Up"} 2
+# HELP m3ua_data_messages_total Number of MSUs in DATA sent or received.
+# TYPE m3ua_data_messages_total counter
+m3ua_data_messages_total{` + l + `,direction="received",rc="10",si="SCCP"} 2
+# HELP m3ua_data_bytes_total Number of bytes of the user data in DATA sent or received.
+# TYPE m3ua_data_bytes_total counter
+m3ua_data_bytes_total{` + l + `,direction="received",rc="10",si="SCCP"} 150
+# HELP m3ua_errors_total Number of ERROR sent or received.
+# TYPE m3ua_errors_total counter
+m3ua_errors_total{` + l + `,direction="received",code="Invalid Routing Context"} 1
+# HELP m3ua_heartbeat_rtt_seconds Round trip time of BEAT and BEAT ACK.
+# TYPE m3ua_heartbeat_rtt_seconds histogram
+m3ua_heartbeat_rtt_seconds_bucket{` + l + `,le="0.01"} 1
+m3ua_heartbeat_rtt_seconds_bucket{` + l + `,le="0.1"} 2
+m3ua_heartbeat_rtt_seconds_bucket{` + l + `,le="+Inf"} 2
+m3ua_heartbeat_rtt_seconds_sum{` + l + `} 0.055
+m3ua_heartbeat_rtt_seconds_count{` + l + `} 2
+# HELP m3ua_state_transitions_total Number of state transitions.
+# TYPE m3ua_state_transitions_total counter
+m3ua_state_transitions_total{` + l + `,from="AspDown",to="AspInactive"} 1
+# HELP m3ua_state Current state of the connection, 1 for the current one.
+# TYPE m3ua_state gauge
+m3ua_state{` + l + `,state="AspActive"} 0
+m3ua_state{` + l + `,state="AspDown"} 0
+m3ua_state{` + l + `,state="AspInactive"} 1
+m3ua_state{` + l + `,state="SCTPCDI"} 0
+m3ua_state{` + l + `,state="SCTPRI"} 0
+`
+
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Error(diff)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("got %d bytes written, want %d", n, buf.Len())
+	}
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("got Content-Type %q", got)
+	}
+	if rec.Body.String() != want {
+		t.Errorf("got different body from ServeHTTP: %s", rec.Body.String())
+	}
+
+	c.Forget(conn)
+	buf.Reset()
+	if _, err := c.WriteTo(&buf); err != nil || buf.Len() != 0 {
+		t.Errorf("got %q, %v after Forget", buf.String(), err)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+type testMetrics struct {
+	messages []string
+	data     []string
+	errors   []uint32
+	states   map[ConnInfo]State
+}
+
+func (m *testMetrics) CountMessage(_ ConnInfo, _ Direction, msg messages.M3UA) {
+	m.messages = append(m.messages, msg.MessageTypeName())
+}
+
+func (m *testMetrics) CountData(_ ConnInfo, dir Direction, rc uint32, si params.ServiceIndicator, size int) {
+	m.data = append(m.data, fmt.Sprintf("%s %s %d %d", dir, si, rc, size))
+}
+
+func (m *testMetrics) CountError(_ ConnInfo, _ Direction, code uint32) {
+	m.errors = append(m.errors, code)
+}
+
+func (m *testMetrics) ObserveHeartbeatRTT(ConnInfo, time.Duration) {}
+
+func (m *testMetrics) ChangeState(conn ConnInfo, _, to State) {
+	if m.states == nil {
+		m.states = map[ConnInfo]State{}
+	}
+	m.states[conn] = to
+}
+
+func (m *testMetrics) Forget(conn ConnInfo) {
+	delete(m.states, conn)
+}
+
+func TestConnMetrics(t *testing.T) {
+	m := &testMetrics{}
+	c := withConfig(&Conn{}, NewConfig(1, 2, 3, 0, 0, 0).SetMetrics(m))
+
+	c.countMessage(DirectionReceived, messages.NewData(
+		nil, params.NewRoutingContext(10),
+		params.NewProtocolData(1, 2, params.ServiceIndSCCP, 2, 0, 1, []byte{1, 2, 3}), nil,
+	))
+	c.countMessage(DirectionSent, messages.NewError(
+		params.NewErrorCode(params.ErrInvalidRoutingContext), nil, nil, nil, nil,
+	))
+
+	if len(m.messages) != 2 || m.messages[0] != "Payload Data" || m.messages[1] != "Error" {
+		t.Errorf("unexpected messages: %v", m.messages)
+	}
+	if len(m.data) != 1 || m.data[0] != "Received SCCP 10 3" {
+		t.Errorf("unexpected data: %v", m.data)
+	}
+	if len(m.errors) != 1 || m.errors[0] != params.ErrInvalidRoutingContext {
+		t.Errorf("unexpected errors: %v", m.errors)
+	}
+}
+
+func TestConnMetricsForgotten(t *testing.T) {
+	m := &testMetrics{}
+	c := withConfig(&Conn{
+		muState:     new(sync.RWMutex),
+		info:        ConnInfo{RemoteAddr: "192.0.2.2:2905", AssocID: 1},
+		established: make(chan struct{}, 1),
+		closed:      make(chan struct{}),
+		dataChan:    make(chan *params.ProtocolDataPayload),
+		sctpConn:    sctp.NewSCTPConn(-1, nil),
+	}, NewConfig(1, 2, 3, 0, 0, 0).SetMetrics(m))
+	c.setState(StateAspActive)
+	if _, ok := m.states[c.info]; !ok {
+		t.Fatal("state is not collected")
+	}
+
+	c.Close()
+	if len(m.states) != 0 {
+		t.Errorf("metrics are not forgotten after Close: %v", m.states)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// ConfigOption is a functional option to build Config with NewConfigWith.
+type ConfigOption func(*Config)
+
+// NewConfigWith creates a new Config with the options given. The params
+// that are not set with the options are omitted from the messages.
+//
+// This is the alternative to NewClientConfig and NewServerConfig, which
+// require all the parameters and setting nil to omit them.
+func NewConfigWith(opts ...ConfigOption) *Config {
+	c := &Config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithPointCodes sets the OPC and DPC used in DATA.
+func WithPointCodes(opc, dpc uint32) ConfigOption {
+	return func(c *Config) {
+		c.OriginatingPointCode = opc
+		c.DestinationPointCode = dpc
+	}
+}
+
+// WithMTP3 sets the SI, NI, MP and SLS used in DATA.
+func WithMTP3(si, ni, mp, sls uint8) ConfigOption {
+	return func(c *Config) {
+		c.ServiceIndicator = si
+		c.NetworkIndicator = ni
+		c.MessagePriority = mp
+		c.SignalingLinkSelection = sls
+	}
+}
+
+// WithAspIdentifier sets the ASP Identifier.
+func WithAspIdentifier(id uint32) ConfigOption {
+	return func(c *Config) {
+		c.SetAspIdentifier(id)
+	}
+}
+
+// WithRoutingContexts sets the Routing Contexts.
+func WithRoutingContexts(rtCtxs ...uint32) ConfigOption {
+	return func(c *Config) {
+		c.SetRoutingContexts(rtCtxs...)
+	}
+}
+
+// WithTrafficModeType sets the Traffic Mode Type.
+func WithTrafficModeType(tmType uint32) ConfigOption {
+	return func(c *Config) {
+		c.SetTrafficModeType(tmType)
+	}
+}
+
+// WithNetworkAppearance sets the Network Appearance.
+func WithNetworkAppearance(nwApr uint32) ConfigOption {
+	return func(c *Config) {
+		c.SetNetworkAppearance(nwApr)
+	}
+}
+
+// WithCorrelationID sets the Correlation ID.
+func WithCorrelationID(id uint32) ConfigOption {
+	return func(c *Config) {
+		c.SetCorrelationID(id)
+	}
+}
+
+// WithHeartbeat enables M3UA BEAT with the interval and expiration timer.
+func WithHeartbeat(interval, timer time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.EnableHeartbeat(interval, timer)
+	}
+}
+
+// WithHeartbeatMaxMisses sets the number of the consecutive BEATs without
+// BEAT ACK to consider the peer is not alive.
+func WithHeartbeatMaxMisses(n int) ConfigOption {
+	return func(c *Config) {
+		c.SetHeartbeatMaxMisses(n)
+	}
+}
+
+// WithHeartbeatStamp enables embedding the sequence number and the timestamp
+// in BEAT.
+func WithHeartbeatStamp() ConfigOption {
+	return func(c *Config) {
+		c.SetHeartbeatStamp(true)
+	}
+}
+
+// WithStrictValidation enables the strict validation of the received messages.
+func WithStrictValidation() ConfigOption {
+	return func(c *Config) {
+		c.SetStrictValidation(true)
+	}
+}
+
+// WithMaxMessageSize sets the maximum size of the M3UA messages to be received.
+func WithMaxMessageSize(size int) ConfigOption {
+	return func(c *Config) {
+		c.SetMaxMessageSize(size)
+	}
+}
+
+// WithEstablishTimeout sets the time to wait for the Conn to be established.
+func WithEstablishTimeout(timeout time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.SetEstablishTimeout(timeout)
+	}
+}
+
+// WithRoutingKeys sets the Routing Keys configured for the peer.
+func WithRoutingKeys(rks ...*params.RoutingKeyPayload) ConfigOption {
+	return func(c *Config) {
+		c.SetRoutingKeys(rks...)
+	}
+}
+
+// WithTap sets the Tap that is called with every M3UA message.
+func WithTap(tap Tap) ConfigOption {
+	return func(c *Config) {
+		c.SetTap(tap)
+	}
+}
+
+// WithLogger sets the Logger used by the Conn.
+func WithLogger(l *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.SetLogger(l)
+	}
+}
+
+// WithMetrics sets the Metrics that collects the metrics of the Conn.
+func WithMetrics(m Metrics) ConfigOption {
+	return func(c *Config) {
+		c.SetMetrics(m)
+	}
+}
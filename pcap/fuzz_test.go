@@ -0,0 +1,39 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package pcap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+func FuzzReader(f *testing.F) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		f.Fatal(err)
+	}
+	if err := w.WritePacket(&Packet{Src: asp, Dst: sgp, Message: messages.NewAspUp(nil, nil)}); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		r, err := NewReader(bytes.NewReader(b))
+		if err != nil {
+			return
+		}
+		for {
+			_, err := r.Next()
+			var merr *MessageError
+			if err != nil && !errors.As(err, &merr) {
+				return
+			}
+		}
+	})
+}
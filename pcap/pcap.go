@@ -0,0 +1,80 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package pcap provides the reader and writer of the captures of M3UA traffic.
+//
+// Writer writes M3UA messages into pcapng with the synthetic IP and SCTP
+// headers, so that they can be analyzed with Wireshark, and Reader reads
+// M3UA messages from the existing pcap or pcapng captures.
+//
+// Writer implements m3ua.Tap, so the messages sent and received on m3ua.Conn
+// can be captured by setting it in m3ua.Config.
+package pcap
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+// PPID is the SCTP Payload Protocol Identifier of M3UA.
+const PPID = 3
+
+// Port is the SCTP port registered for M3UA.
+const Port = 2905
+
+// Error definitions.
+var (
+	ErrUnknownFormat   = errors.New("unknown capture file format")
+	ErrMalformedFile   = errors.New("malformed capture file")
+	ErrAddressMismatch = errors.New("source and destination addresses are not of the same family")
+)
+
+// Packet is a M3UA message in a capture, with the information of the
+// SCTP association that carries it.
+type Packet struct {
+	// Timestamp is the time when the message is captured.
+	Timestamp time.Time
+	// Src and Dst are the IP addresses and SCTP ports of the endpoints.
+	Src, Dst netip.AddrPort
+	// StreamID is the SCTP stream the message is sent on.
+	StreamID uint16
+	// Data is the raw bytes of the message, which is not used in Writer.
+	Data []byte
+	// Message is the M3UA message.
+	Message messages.M3UA
+}
+
+// AddrPortFrom returns the IP address and port in the net.Addr given, which
+// is typically the one returned by LocalAddr or RemoteAddr of m3ua.Conn.
+// The first address is used if the SCTP association has multiple addresses.
+//
+// The zero value is returned if the net.Addr is not supported.
+func AddrPortFrom(addr net.Addr) netip.AddrPort {
+	var ip net.IP
+	var port int
+	switch a := addr.(type) {
+	case *sctp.SCTPAddr:
+		if a == nil || len(a.IPAddrs) == 0 {
+			return netip.AddrPort{}
+		}
+		ip, port = a.IPAddrs[0].IP, a.Port
+	case *net.TCPAddr:
+		ip, port = a.IP, a.Port
+	case *net.UDPAddr:
+		ip, port = a.IP, a.Port
+	default:
+		return netip.AddrPort{}
+	}
+
+	ipAddr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return netip.AddrPort{}
+	}
+	return netip.AddrPortFrom(ipAddr.Unmap(), uint16(port))
+}
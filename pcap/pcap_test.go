@@ -0,0 +1,231 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/wmnsk/go-m3ua"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+var (
+	asp = netip.MustParseAddrPort("192.0.2.1:2905")
+	sgp = netip.MustParseAddrPort("192.0.2.2:2905")
+)
+
+func TestWriterReader(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	pkts := []*Packet{
+		{Timestamp: ts, Src: asp, Dst: sgp, StreamID: 0, Message: messages.NewAspUp(nil, nil)},
+		{Timestamp: ts.Add(time.Millisecond), Src: sgp, Dst: asp, StreamID: 0, Message: messages.NewAspUpAck(nil, nil)},
+		{
+			Timestamp: ts.Add(2 * time.Millisecond), Src: asp, Dst: sgp, StreamID: 1,
+			Message: messages.NewData(
+				nil, params.NewRoutingContext(1),
+				params.NewProtocolData(1, 2, 3, 2, 0, 1, []byte{0xde, 0xad, 0xbe}),
+				nil,
+			),
+		},
+		{
+			Timestamp: ts.Add(3 * time.Millisecond),
+			Src:       netip.MustParseAddrPort("[2001:db8::1]:2905"),
+			Dst:       netip.MustParseAddrPort("[2001:db8::2]:2905"),
+			Message:   messages.NewHeartbeat(params.NewHeartbeatData([]byte("hb"))),
+		},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range pkts {
+		if err := w.WritePacket(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range pkts {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("#%d: got timestamp %v, want %v", i, got.Timestamp, want.Timestamp)
+		}
+		if got.Src != want.Src || got.Dst != want.Dst || got.StreamID != want.StreamID {
+			t.Errorf("#%d: got %v -> %v (%d), want %v -> %v (%d)",
+				i, got.Src, got.Dst, got.StreamID, want.Src, want.Dst, want.StreamID)
+		}
+		wantBytes, _ := want.Message.MarshalBinary()
+		gotBytes, _ := got.Message.MarshalBinary()
+		if !bytes.Equal(gotBytes, wantBytes) {
+			t.Errorf("#%d: got %x, want %x", i, gotBytes, wantBytes)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("got %v, want io.EOF", err)
+	}
+}
+
+func TestWriterChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePacket(&Packet{Src: asp, Dst: sgp, Message: messages.NewAspUp(nil, nil)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the IPv4 packet in the EPB that follows the SHB and IDB.
+	epb := buf.Bytes()[28+32:]
+	b := epb[28 : 28+binary.LittleEndian.Uint32(epb[20:24])]
+	if got := ipv4Checksum(b[:20]); got != 0 {
+		t.Errorf("invalid IPv4 checksum: %#x", got)
+	}
+	sctp := append([]byte(nil), b[20:]...)
+	sum := binary.LittleEndian.Uint32(sctp[8:12])
+	copy(sctp[8:12], []byte{0, 0, 0, 0})
+	if want := crc32.Checksum(sctp, crc32c); sum != want {
+		t.Errorf("got CRC32c %#x, want %#x", sum, want)
+	}
+
+	err = w.WritePacket(&Packet{
+		Src: asp, Dst: netip.MustParseAddrPort("[2001:db8::2]:2905"), Message: messages.NewAspUp(nil, nil),
+	})
+	if err != ErrAddressMismatch {
+		t.Errorf("got %v, want %v", err, ErrAddressMismatch)
+	}
+}
+
+func TestReaderPcap(t *testing.T) {
+	up, _ := messages.NewAspUp(nil, nil).MarshalBinary()
+	beat, _ := messages.NewHeartbeat(params.NewHeartbeatData([]byte("0123456789"))).MarshalBinary()
+
+	// three DATA chunks in a SCTP packet: M3UA, the other protocol, and M3UA with PPID 0.
+	p1 := appendSCTP(nil, 2905, 2905, &dataChunk{flags: flagBegin | flagEnd, ppid: PPID, data: up})
+	p1 = appendChunk(p1, &dataChunk{flags: flagBegin | flagEnd, ppid: 46, data: []byte{0x01}})
+	p1 = appendChunk(p1, &dataChunk{flags: flagBegin | flagEnd, ppid: 0, data: up})
+	// a message fragmented into two DATA chunks in the separate packets.
+	p2 := appendSCTP(nil, 2905, 2905, &dataChunk{flags: flagBegin, ppid: PPID, streamID: 1, data: beat[:10]})
+	p3 := appendSCTP(nil, 2905, 2905, &dataChunk{flags: flagEnd, ppid: PPID, streamID: 1, data: beat[10:]})
+	// broken M3UA message.
+	p4 := appendSCTP(nil, 2905, 2905, &dataChunk{flags: flagBegin | flagEnd, ppid: PPID, data: []byte{0x01}})
+
+	var buf bytes.Buffer
+	h := []byte{0xa1, 0xb2, 0xc3, 0xd4, 0, 2, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0, 0, 0, linkTypeEthernet}
+	buf.Write(h)
+	for i, sctp := range [][]byte{p1, p2, p3, p4} {
+		frame := []byte{
+			0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 1, // MAC addresses
+			0x81, 0x00, 0x00, 0x0a, // VLAN
+			0x08, 0x00,
+		}
+		frame, _ = appendIP(frame, asp.Addr(), sgp.Addr(), 0, len(sctp))
+		frame = append(frame, sctp...)
+
+		rec := binary.BigEndian.AppendUint32(nil, uint32(1700000000+i))
+		rec = binary.BigEndian.AppendUint32(rec, 500000)
+		rec = binary.BigEndian.AppendUint32(rec, uint32(len(frame)))
+		rec = binary.BigEndian.AppendUint32(rec, uint32(len(frame)))
+		buf.Write(rec)
+		buf.Write(frame)
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		msg []byte
+		ts  time.Time
+	}{
+		{up, time.Unix(1700000000, 500000000)},
+		{up, time.Unix(1700000000, 500000000)},
+		{beat, time.Unix(1700000001, 500000000)},
+	} {
+		p, err := r.Next()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !bytes.Equal(p.Data, want.msg) || !p.Timestamp.Equal(want.ts) {
+			t.Errorf("#%d: got %x at %v, want %x at %v", i, p.Data, p.Timestamp, want.msg, want.ts)
+		}
+		if p.Src != asp || p.Dst != sgp {
+			t.Errorf("#%d: got %v -> %v", i, p.Src, p.Dst)
+		}
+	}
+
+	p, err := r.Next()
+	var merr *MessageError
+	if !errors.As(err, &merr) || p == nil || p.Message != nil {
+		t.Errorf("got %v, %v, want *MessageError with the Packet", p, err)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("got %v, want io.EOF", err)
+	}
+}
+
+func TestReaderUnknownFormat(t *testing.T) {
+	if _, err := NewReader(bytes.NewReader([]byte("not a capture"))); err != ErrUnknownFormat {
+		t.Errorf("got %v, want %v", err, ErrUnknownFormat)
+	}
+}
+
+// appendChunk appends the DATA chunk to the SCTP packet in b.
+// The checksum is not updated as the Reader does not verify it.
+func appendChunk(b []byte, c *dataChunk) []byte {
+	return append(b, appendSCTP(nil, 0, 0, c)[12:]...)
+}
+
+func TestWriterTap(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tap m3ua.Tap = w
+	local := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 2905}
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 2905}
+	up, _ := messages.NewAspUp(nil, nil).MarshalBinary()
+	tap.Tap(m3ua.Frame{LocalAddr: local, RemoteAddr: remote, Direction: m3ua.DirectionSent, Raw: up})
+	tap.Tap(m3ua.Frame{LocalAddr: local, RemoteAddr: remote, Direction: m3ua.DirectionReceived, StreamID: 2, Raw: []byte{0x01}})
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Src != asp || p.Dst != sgp || !bytes.Equal(p.Data, up) {
+		t.Errorf("got %v -> %v: %x", p.Src, p.Dst, p.Data)
+	}
+
+	// the raw bytes are written even if they are not M3UA.
+	p, err = r.Next()
+	var merr *MessageError
+	if !errors.As(err, &merr) || p.Src != sgp || p.Dst != asp || p.StreamID != 2 {
+		t.Errorf("got %v, %v", p, err)
+	}
+}
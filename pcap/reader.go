@@ -0,0 +1,357 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"time"
+
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+// MessageError is returned from Reader.Next with the Packet when the M3UA
+// message in the Packet cannot be decoded.
+type MessageError struct {
+	Err error
+}
+
+// Error returns error string.
+func (e *MessageError) Error() string {
+	return fmt.Sprintf("failed to decode M3UA message: %v", e.Err)
+}
+
+// Unwrap returns the error from messages.Parse.
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads M3UA messages from pcap or pcapng captures.
+//
+// The messages are taken from the SCTP DATA chunks with the PPID of M3UA, or
+// with the PPID 0 on the M3UA port, over IPv4 or IPv6. The supported link
+// types are Ethernet (with VLAN tags), Linux cooked capture (v1 and v2), raw
+// IP and BSD loopback. The messages fragmented into multiple DATA chunks are
+// reassembled, while the fragmented IP packets are ignored.
+type Reader struct {
+	r *bufio.Reader
+
+	// pcap
+	isPcap   bool
+	linkType uint32
+	tsScale  time.Duration
+
+	// pcapng
+	order  binary.ByteOrder
+	ifaces []iface
+
+	queue     []*Packet
+	fragments map[fragmentKey]*Packet
+}
+
+type iface struct {
+	linkType uint32
+	// the timestamps are in the units of 1/tsUnits seconds.
+	tsUnits uint64
+}
+
+type fragmentKey struct {
+	src, dst netip.AddrPort
+	streamID uint16
+}
+
+// NewReader creates a new Reader that reads the capture in pcap or pcapng
+// format from r, which is detected from the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	pr := &Reader{
+		r:         bufio.NewReader(r),
+		fragments: map[fragmentKey]*Packet{},
+	}
+
+	magic, err := pr.r.Peek(4)
+	if err != nil {
+		return nil, ErrUnknownFormat
+	}
+
+	switch binary.LittleEndian.Uint32(magic) {
+	case blockTypeSHB:
+		return pr, nil
+	case 0xa1b2c3d4:
+		pr.order, pr.tsScale = binary.LittleEndian, time.Microsecond
+	case 0xd4c3b2a1:
+		pr.order, pr.tsScale = binary.BigEndian, time.Microsecond
+	case 0xa1b23c4d:
+		pr.order, pr.tsScale = binary.LittleEndian, time.Nanosecond
+	case 0x4d3cb2a1:
+		pr.order, pr.tsScale = binary.BigEndian, time.Nanosecond
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	h := make([]byte, 24)
+	if _, err := io.ReadFull(pr.r, h); err != nil {
+		return nil, ErrMalformedFile
+	}
+	pr.isPcap = true
+	pr.linkType = pr.order.Uint32(h[20:24]) & 0x0fffffff
+	return pr, nil
+}
+
+// Next returns the next M3UA message in the capture, or io.EOF if there are
+// no more messages.
+//
+// If the M3UA message cannot be decoded, the Packet without Message is
+// returned together with *MessageError, and the caller can continue reading.
+// The other errors mean that the capture file is broken.
+func (r *Reader) Next() (*Packet, error) {
+	for len(r.queue) == 0 {
+		var err error
+		if r.isPcap {
+			err = r.readPcapRecord()
+		} else {
+			err = r.readBlock()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p := r.queue[0]
+	r.queue = r.queue[1:]
+
+	m, err := messages.Parse(p.Data)
+	if err != nil {
+		return p, &MessageError{Err: err}
+	}
+	p.Message = m
+	return p, nil
+}
+
+func (r *Reader) readPcapRecord() error {
+	h := make([]byte, 16)
+	if _, err := io.ReadFull(r.r, h); err != nil {
+		if err == io.EOF {
+			return io.EOF
+		}
+		return ErrMalformedFile
+	}
+
+	ts := time.Unix(int64(r.order.Uint32(h[0:4])), int64(r.order.Uint32(h[4:8]))*int64(r.tsScale))
+	b, err := r.readN(r.order.Uint32(h[8:12]))
+	if err != nil {
+		return err
+	}
+	r.handleFrame(r.linkType, ts, b)
+	return nil
+}
+
+func (r *Reader) readBlock() error {
+	h := make([]byte, 8)
+	if _, err := io.ReadFull(r.r, h); err != nil {
+		if err == io.EOF {
+			return io.EOF
+		}
+		return ErrMalformedFile
+	}
+
+	// the byte order of the section is determined by the SHB.
+	if binary.LittleEndian.Uint32(h[0:4]) == blockTypeSHB {
+		magic, err := r.r.Peek(4)
+		if err != nil {
+			return ErrMalformedFile
+		}
+		switch binary.LittleEndian.Uint32(magic) {
+		case byteOrderMagic:
+			r.order = binary.LittleEndian
+		case 0x4d3c2b1a:
+			r.order = binary.BigEndian
+		default:
+			return ErrMalformedFile
+		}
+		r.ifaces = nil
+	}
+	if r.order == nil {
+		return ErrMalformedFile
+	}
+
+	l := r.order.Uint32(h[4:8])
+	if l < 12 || l%4 != 0 {
+		return ErrMalformedFile
+	}
+	body, err := r.readN(l - 8)
+	if err != nil {
+		return err
+	}
+	body = body[:len(body)-4] // trailing block length
+
+	switch r.order.Uint32(h[0:4]) {
+	case blockTypeIDB:
+		if len(body) < 8 {
+			return ErrMalformedFile
+		}
+		r.ifaces = append(r.ifaces, iface{
+			linkType: uint32(r.order.Uint16(body[0:2])),
+			tsUnits:  r.tsUnits(body[8:]),
+		})
+	case blockTypeEPB, blockTypePB:
+		if len(body) < 20 {
+			return ErrMalformedFile
+		}
+		var id uint32
+		if r.order.Uint32(h[0:4]) == blockTypeEPB {
+			id = r.order.Uint32(body[0:4])
+		} else {
+			id = uint32(r.order.Uint16(body[0:2]))
+		}
+		if int(id) >= len(r.ifaces) {
+			return ErrMalformedFile
+		}
+		ifc := r.ifaces[id]
+
+		ts := uint64(r.order.Uint32(body[4:8]))<<32 | uint64(r.order.Uint32(body[8:12]))
+		sec, frac := ts/ifc.tsUnits, ts%ifc.tsUnits
+		nsec := uint64(float64(frac) * 1e9 / float64(ifc.tsUnits))
+
+		caplen := r.order.Uint32(body[12:16])
+		if int(caplen) > len(body)-20 {
+			return ErrMalformedFile
+		}
+		r.handleFrame(ifc.linkType, time.Unix(int64(sec), int64(nsec)), body[20:20+caplen])
+	case blockTypeSPB:
+		if len(body) < 4 || len(r.ifaces) == 0 {
+			return ErrMalformedFile
+		}
+		l := int(r.order.Uint32(body[0:4]))
+		if l > len(body)-4 {
+			l = len(body) - 4
+		}
+		r.handleFrame(r.ifaces[0].linkType, time.Time{}, body[4:4+l])
+	}
+	return nil
+}
+
+// tsUnits returns the units of the timestamps per second, from the if_tsresol
+// option in the options of IDB given.
+func (r *Reader) tsUnits(opts []byte) uint64 {
+	for len(opts) >= 4 {
+		code, l := r.order.Uint16(opts[0:2]), int(r.order.Uint16(opts[2:4]))
+		if code == 0 || len(opts) < 4+l {
+			break
+		}
+		if code == 9 && l == 1 {
+			v := opts[4]
+			if v&0x80 == 0 && v <= 19 {
+				return uint64(math.Pow10(int(v)))
+			}
+			if v&0x80 != 0 && v&0x7f < 64 {
+				return 1 << (v & 0x7f)
+			}
+		}
+		opts = opts[4+(l+3)&^3:]
+	}
+	return 1e6
+}
+
+func (r *Reader) readN(n uint32) ([]byte, error) {
+	// larger than the maximum size of the IP packets with a room for headers.
+	if n > 0x40000 {
+		return nil, ErrMalformedFile
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, ErrMalformedFile
+	}
+	return b, nil
+}
+
+// handleFrame queues the M3UA messages in the frame given.
+// The frames that do not contain M3UA are ignored.
+func (r *Reader) handleFrame(linkType uint32, ts time.Time, b []byte) {
+	b = linkPayload(linkType, b)
+	if b == nil {
+		return
+	}
+	srcIP, dstIP, payload, ok := ipPayload(b)
+	if !ok {
+		return
+	}
+	srcPort, dstPort, chunks := dataChunks(payload)
+
+	src := netip.AddrPortFrom(srcIP, srcPort)
+	dst := netip.AddrPortFrom(dstIP, dstPort)
+	for _, c := range chunks {
+		if c.ppid != PPID && !(c.ppid == 0 && (srcPort == Port || dstPort == Port)) {
+			continue
+		}
+
+		key := fragmentKey{src, dst, c.streamID}
+		switch c.flags & (flagBegin | flagEnd) {
+		case flagBegin | flagEnd:
+			r.queue = append(r.queue, &Packet{
+				Timestamp: ts, Src: src, Dst: dst, StreamID: c.streamID, Data: c.data,
+			})
+		case flagBegin:
+			r.fragments[key] = &Packet{
+				Timestamp: ts, Src: src, Dst: dst, StreamID: c.streamID,
+				Data: append([]byte(nil), c.data...),
+			}
+		default:
+			p, ok := r.fragments[key]
+			if !ok {
+				// the first fragment is not in the capture.
+				continue
+			}
+			p.Data = append(p.Data, c.data...)
+			if c.flags&flagEnd != 0 {
+				delete(r.fragments, key)
+				r.queue = append(r.queue, p)
+			}
+		}
+	}
+}
+
+// linkPayload returns the IP packet in the frame of the link type given,
+// or nil if it is not supported.
+func linkPayload(linkType uint32, b []byte) []byte {
+	var etherType uint16
+	switch linkType {
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6, 12, 14: // 12 and 14 are raw IP on some platforms
+		return b
+	case linkTypeNull:
+		if len(b) < 4 {
+			return nil
+		}
+		return b[4:]
+	case linkTypeEthernet:
+		if len(b) < 14 {
+			return nil
+		}
+		etherType, b = binary.BigEndian.Uint16(b[12:14]), b[14:]
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(b) >= 4 {
+			etherType, b = binary.BigEndian.Uint16(b[2:4]), b[4:]
+		}
+	case linkTypeLinuxSLL:
+		if len(b) < 16 {
+			return nil
+		}
+		etherType, b = binary.BigEndian.Uint16(b[14:16]), b[16:]
+	case linkTypeSLL2:
+		if len(b) < 20 {
+			return nil
+		}
+		etherType, b = binary.BigEndian.Uint16(b[0:2]), b[20:]
+	default:
+		return nil
+	}
+
+	if etherType != 0x0800 && etherType != 0x86dd {
+		return nil
+	}
+	return b
+}
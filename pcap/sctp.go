@@ -0,0 +1,183 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package pcap
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"net/netip"
+)
+
+const (
+	protoSCTP = 132
+
+	chunkTypeData = 0
+
+	flagEnd   = 0x01
+	flagBegin = 0x02
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// dataChunk is a SCTP DATA chunk.
+type dataChunk struct {
+	flags    uint8
+	tsn      uint32
+	streamID uint16
+	ssn      uint16
+	ppid     uint32
+	data     []byte
+}
+
+// appendSCTP appends the SCTP packet with a DATA chunk that contains the
+// whole user data to b.
+func appendSCTP(b []byte, srcPort, dstPort uint16, c *dataChunk) []byte {
+	start := len(b)
+	b = binary.BigEndian.AppendUint16(b, srcPort)
+	b = binary.BigEndian.AppendUint16(b, dstPort)
+	b = binary.BigEndian.AppendUint32(b, 0) // verification tag
+	b = binary.BigEndian.AppendUint32(b, 0) // checksum, filled later
+
+	b = append(b, chunkTypeData, c.flags)
+	b = binary.BigEndian.AppendUint16(b, uint16(16+len(c.data)))
+	b = binary.BigEndian.AppendUint32(b, c.tsn)
+	b = binary.BigEndian.AppendUint16(b, c.streamID)
+	b = binary.BigEndian.AppendUint16(b, c.ssn)
+	b = binary.BigEndian.AppendUint32(b, c.ppid)
+	b = append(b, c.data...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+
+	// the checksum is stored in little endian, see RFC9260 Appendix A.
+	binary.LittleEndian.PutUint32(b[start+8:], crc32.Checksum(b[start:], crc32c))
+	return b
+}
+
+// appendIP appends the IPv4 or IPv6 header for the payload of the length
+// given to b.
+func appendIP(b []byte, src, dst netip.Addr, id uint16, payloadLen int) ([]byte, error) {
+	src, dst = src.Unmap(), dst.Unmap()
+	switch {
+	case src.Is4() && dst.Is4():
+		start := len(b)
+		b = append(b, 0x45, 0x00)
+		b = binary.BigEndian.AppendUint16(b, uint16(20+payloadLen))
+		b = binary.BigEndian.AppendUint16(b, id)
+		b = append(b, 0x40, 0x00, 64, protoSCTP, 0x00, 0x00) // DF, TTL, protocol, checksum
+		b = append(b, src.AsSlice()...)
+		b = append(b, dst.AsSlice()...)
+		binary.BigEndian.PutUint16(b[start+10:], ipv4Checksum(b[start:]))
+		return b, nil
+	case src.Is6() && dst.Is6():
+		b = binary.BigEndian.AppendUint32(b, 0x60000000)
+		b = binary.BigEndian.AppendUint16(b, uint16(payloadLen))
+		b = append(b, protoSCTP, 64)
+		b = append(b, src.AsSlice()...)
+		b = append(b, dst.AsSlice()...)
+		return b, nil
+	default:
+		return nil, ErrAddressMismatch
+	}
+}
+
+func ipv4Checksum(h []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(h); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(h[i:]))
+	}
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
+
+// ipPayload returns the addresses and the payload of the IPv4 or IPv6 packet
+// in b if it carries SCTP. Fragmented packets are not supported.
+func ipPayload(b []byte) (src, dst netip.Addr, payload []byte, ok bool) {
+	if len(b) < 1 {
+		return
+	}
+
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < 20 {
+			return
+		}
+		hl := int(b[0]&0x0f) * 4
+		tl := int(binary.BigEndian.Uint16(b[2:4]))
+		if hl < 20 || tl < hl || len(b) < hl {
+			return
+		}
+		// more fragments or non-zero offset
+		if binary.BigEndian.Uint16(b[6:8])&0x3fff != 0 || b[9] != protoSCTP {
+			return
+		}
+		if tl > len(b) {
+			tl = len(b)
+		}
+		src, _ = netip.AddrFromSlice(b[12:16])
+		dst, _ = netip.AddrFromSlice(b[16:20])
+		return src, dst, b[hl:tl], true
+	case 6:
+		if len(b) < 40 {
+			return
+		}
+		src, _ = netip.AddrFromSlice(b[8:24])
+		dst, _ = netip.AddrFromSlice(b[24:40])
+		end := 40 + int(binary.BigEndian.Uint16(b[4:6]))
+		if end > len(b) {
+			end = len(b)
+		}
+		next, off := b[6], 40
+		for {
+			switch next {
+			case protoSCTP:
+				if off > end {
+					return
+				}
+				return src, dst, b[off:end], true
+			case 0, 43, 60: // hop-by-hop, routing, destination options
+				if off+8 > end {
+					return
+				}
+				next, off = b[off], off+8+int(b[off+1])*8
+			default: // including fragment
+				return
+			}
+		}
+	}
+	return
+}
+
+// dataChunks returns the ports and the DATA chunks in the SCTP packet in b.
+// The chunks that are truncated in the capture are ignored.
+func dataChunks(b []byte) (srcPort, dstPort uint16, chunks []*dataChunk) {
+	if len(b) < 12 {
+		return
+	}
+	srcPort = binary.BigEndian.Uint16(b[0:2])
+	dstPort = binary.BigEndian.Uint16(b[2:4])
+
+	for off := 12; off+4 <= len(b); {
+		l := int(binary.BigEndian.Uint16(b[off+2 : off+4]))
+		if l < 4 || off+l > len(b) {
+			break
+		}
+		if b[off] == chunkTypeData && l >= 16 {
+			c := b[off : off+l]
+			chunks = append(chunks, &dataChunk{
+				flags:    c[1],
+				tsn:      binary.BigEndian.Uint32(c[4:8]),
+				streamID: binary.BigEndian.Uint16(c[8:10]),
+				ssn:      binary.BigEndian.Uint16(c[10:12]),
+				ppid:     binary.BigEndian.Uint32(c[12:16]),
+				data:     c[16:],
+			})
+		}
+		off += (l + 3) &^ 3
+	}
+	return
+}
@@ -0,0 +1,175 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package pcap
+
+import (
+	"encoding/binary"
+	"io"
+	"log"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-m3ua"
+)
+
+const (
+	blockTypeSHB = 0x0a0d0d0a
+	blockTypeIDB = 0x00000001
+	blockTypePB  = 0x00000002
+	blockTypeSPB = 0x00000003
+	blockTypeEPB = 0x00000006
+
+	byteOrderMagic = 0x1a2b3c4d
+
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSLL2     = 276
+)
+
+// Writer writes M3UA messages into pcapng, with the synthetic IP and SCTP
+// headers. Each message is written as a SCTP packet with a single DATA chunk,
+// whose TSN and Stream Sequence Number are counted per direction.
+//
+// Writer is safe for concurrent use.
+type Writer struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf []byte
+
+	ipID  uint16
+	flows map[flowKey]*flowState
+}
+
+type flowKey struct {
+	src, dst netip.AddrPort
+}
+
+type flowState struct {
+	tsn  uint32
+	ssns map[uint16]uint16
+}
+
+// NewWriter creates a new Writer and writes the pcapng header to w.
+func NewWriter(w io.Writer) (*Writer, error) {
+	pw := &Writer{
+		w:     w,
+		flows: map[flowKey]*flowState{},
+	}
+
+	// Section Header Block, with unknown section length.
+	b := pw.buf[:0]
+	b = binary.LittleEndian.AppendUint32(b, blockTypeSHB)
+	b = binary.LittleEndian.AppendUint32(b, 28)
+	b = binary.LittleEndian.AppendUint32(b, byteOrderMagic)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint64(b, 0xffffffffffffffff)
+	b = binary.LittleEndian.AppendUint32(b, 28)
+
+	// Interface Description Block, with if_tsresol for nanoseconds.
+	b = binary.LittleEndian.AppendUint32(b, blockTypeIDB)
+	b = binary.LittleEndian.AppendUint32(b, 32)
+	b = binary.LittleEndian.AppendUint16(b, linkTypeRaw)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	b = append(b, 9, 0, 1, 0, 9, 0, 0, 0) // if_tsresol = 9
+	b = append(b, 0, 0, 0, 0)             // opt_endofopt
+	b = binary.LittleEndian.AppendUint32(b, 32)
+
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	pw.buf = b
+	return pw, nil
+}
+
+// WritePacket writes the M3UA message in the Packet. The Data in Packet is
+// ignored and the Message is marshaled instead, and the current time is used
+// if the Timestamp is zero.
+func (w *Writer) WritePacket(p *Packet) error {
+	msg, err := p.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return w.write(p.Timestamp, p.Src, p.Dst, p.StreamID, msg)
+}
+
+// Tap writes the message in the Frame, which makes Writer usable as m3ua.Tap
+// to capture the messages sent and received on m3ua.Conn. The raw bytes are
+// written as they are, even if they cannot be parsed as M3UA.
+//
+// The errors in writing are logged as Tap cannot return them.
+func (w *Writer) Tap(f m3ua.Frame) {
+	local, remote := AddrPortFrom(f.LocalAddr), AddrPortFrom(f.RemoteAddr)
+	src, dst := remote, local
+	if f.Direction == m3ua.DirectionSent {
+		src, dst = local, remote
+	}
+	if err := w.write(f.Timestamp, src, dst, f.StreamID, f.Raw); err != nil {
+		log.Printf("failed to write M3UA message to pcapng: %v", err)
+	}
+}
+
+func (w *Writer) write(t time.Time, src, dst netip.AddrPort, streamID uint16, msg []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	flow := w.flows[flowKey{src, dst}]
+	if flow == nil {
+		flow = &flowState{ssns: map[uint16]uint16{}}
+		w.flows[flowKey{src, dst}] = flow
+	}
+
+	sctpLen := 12 + 16 + (len(msg)+3)&^3
+	b := w.buf[:0]
+	b = binary.LittleEndian.AppendUint32(b, blockTypeEPB)
+	b = binary.LittleEndian.AppendUint32(b, 0) // block length, filled later
+	b = binary.LittleEndian.AppendUint32(b, 0) // interface ID
+	if t.IsZero() {
+		t = time.Now()
+	}
+	ts := uint64(t.UnixNano())
+	b = binary.LittleEndian.AppendUint32(b, uint32(ts>>32))
+	b = binary.LittleEndian.AppendUint32(b, uint32(ts))
+	b = binary.LittleEndian.AppendUint32(b, 0) // captured length, filled later
+	b = binary.LittleEndian.AppendUint32(b, 0) // original length, filled later
+
+	pktStart := len(b)
+	b, err := appendIP(b, src.Addr(), dst.Addr(), w.ipID, sctpLen)
+	if err != nil {
+		return err
+	}
+	b = appendSCTP(b, src.Port(), dst.Port(), &dataChunk{
+		flags:    flagBegin | flagEnd,
+		tsn:      flow.tsn,
+		streamID: streamID,
+		ssn:      flow.ssns[streamID],
+		ppid:     PPID,
+		data:     msg,
+	})
+	pktLen := len(b) - pktStart
+	b = append(b, make([]byte, (4-pktLen%4)%4)...)
+
+	blockLen := len(b) + 4
+	b = binary.LittleEndian.AppendUint32(b, uint32(blockLen))
+	binary.LittleEndian.PutUint32(b[4:], uint32(blockLen))
+	binary.LittleEndian.PutUint32(b[20:], uint32(pktLen))
+	binary.LittleEndian.PutUint32(b[24:], uint32(pktLen))
+
+	w.buf = b
+	if _, err := w.w.Write(b); err != nil {
+		return err
+	}
+
+	w.ipID++
+	flow.tsn++
+	flow.ssns[streamID]++
+	return nil
+}
@@ -0,0 +1,420 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Routing Indicator definitions.
+const (
+	RouteOnGT  uint8 = 0
+	RouteOnSSN uint8 = 1
+)
+
+// Global Title Indicator definitions.
+const (
+	GTINone uint8 = iota
+	GTINatureOfAddress
+	GTITranslationType
+	GTITranslationTypeNumberingPlan
+	GTITranslationTypeNumberingPlanNature
+)
+
+// Numbering Plan definitions.
+const (
+	NumberingPlanUnknown       uint8 = 0
+	NumberingPlanISDNTelephony uint8 = 1 // E.164
+	NumberingPlanData          uint8 = 3 // X.121
+	NumberingPlanTelex         uint8 = 4 // F.69
+	NumberingPlanMaritime      uint8 = 5 // E.210, E.211
+	NumberingPlanLandMobile    uint8 = 6 // E.212
+	NumberingPlanISDNMobile    uint8 = 7 // E.214
+	NumberingPlanPrivate       uint8 = 14
+)
+
+// Encoding Scheme definitions.
+const (
+	EncodingSchemeUnknown uint8 = iota
+	EncodingSchemeBCDOdd
+	EncodingSchemeBCDEven
+	EncodingSchemeNational
+)
+
+// Nature of Address Indicator definitions.
+const (
+	NatureUnknown       uint8 = 0
+	NatureSubscriber    uint8 = 1
+	NatureNational      uint8 = 3
+	NatureInternational uint8 = 4
+)
+
+// Subsystem Number definitions.
+const (
+	SSNUnknown    uint8 = 0
+	SSNSCCPMgmt   uint8 = 1
+	SSNISUP       uint8 = 3
+	SSNOMAP       uint8 = 4
+	SSNMAP        uint8 = 5
+	SSNHLR        uint8 = 6
+	SSNVLR        uint8 = 7
+	SSNMSC        uint8 = 8
+	SSNEIR        uint8 = 9
+	SSNAuC        uint8 = 10
+	SSNISDNSS     uint8 = 11
+	SSNINAP       uint8 = 12
+	SSNCAP        uint8 = 146
+	SSNgsmSCF     uint8 = 147
+	SSNSIWF       uint8 = 148
+	SSNSGSN       uint8 = 149
+	SSNGGSN       uint8 = 150
+	SSNBSSAP      uint8 = 254
+	SSNBSSAPPlusA uint8 = 252
+)
+
+// Error definitions.
+var (
+	ErrUnsupportedGTI = errors.New("unsupported global title indicator")
+	ErrInvalidDigits  = errors.New("invalid digits in global title")
+)
+
+// PartyAddress is the Called Party Address or Calling Party Address.
+type PartyAddress struct {
+	// National is the bit reserved for national use.
+	National         bool
+	RoutingIndicator uint8
+	// GlobalTitleIndicator is the format of GlobalTitle, which is ignored if
+	// GlobalTitle is nil.
+	GlobalTitleIndicator uint8
+	// PointCode is the 14-bit Signalling Point Code, present if
+	// PointCodeIndicator is set.
+	PointCodeIndicator bool
+	PointCode          uint16
+	// SubsystemNumber is present if SSNIndicator is set.
+	SSNIndicator    bool
+	SubsystemNumber uint8
+	GlobalTitle     *GlobalTitle
+}
+
+// NewPartyAddressSSN creates a PartyAddress routed on PC and SSN.
+func NewPartyAddressSSN(pc uint16, ssn uint8) *PartyAddress {
+	return &PartyAddress{
+		RoutingIndicator:   RouteOnSSN,
+		PointCodeIndicator: true,
+		PointCode:          pc,
+		SSNIndicator:       true,
+		SubsystemNumber:    ssn,
+	}
+}
+
+// pointCode converts the point code in the MTP routing label to the one in
+// the PartyAddress. It returns ErrInvalidPointCode if it exceeds 14 bits.
+func pointCode(pc uint32) (uint16, error) {
+	if pc > 0x3fff {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidPointCode, pc)
+	}
+	return uint16(pc), nil
+}
+
+// NewPartyAddressGT creates a PartyAddress routed on Global Title with the
+// format of GTI given. The SSN is included if it is not zero.
+func NewPartyAddressGT(gti uint8, gt *GlobalTitle, ssn uint8) *PartyAddress {
+	return &PartyAddress{
+		RoutingIndicator:     RouteOnGT,
+		GlobalTitleIndicator: gti,
+		SSNIndicator:         ssn != 0,
+		SubsystemNumber:      ssn,
+		GlobalTitle:          gt,
+	}
+}
+
+// ParsePartyAddress decodes the given bytes as a PartyAddress.
+func ParsePartyAddress(b []byte) (*PartyAddress, error) {
+	a := &PartyAddress{}
+	if err := a.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a PartyAddress.
+func (a *PartyAddress) UnmarshalBinary(b []byte) error {
+	if len(b) < 1 {
+		return ErrTooShortToParse
+	}
+	ai := b[0]
+	a.National = ai&0x80 != 0
+	a.RoutingIndicator = (ai >> 6) & 0x01
+	a.GlobalTitleIndicator = (ai >> 2) & 0x0f
+	a.SSNIndicator = ai&0x02 != 0
+	a.PointCodeIndicator = ai&0x01 != 0
+
+	offset := 1
+	if a.PointCodeIndicator {
+		if len(b) < offset+2 {
+			return ErrTooShortToParse
+		}
+		a.PointCode = (uint16(b[offset]) | uint16(b[offset+1])<<8) & 0x3fff
+		offset += 2
+	}
+	if a.SSNIndicator {
+		if len(b) < offset+1 {
+			return ErrTooShortToParse
+		}
+		a.SubsystemNumber = b[offset]
+		offset++
+	}
+
+	a.GlobalTitle = nil
+	if a.GlobalTitleIndicator == GTINone {
+		return nil
+	}
+	gt := &GlobalTitle{}
+	if err := gt.unmarshal(b[offset:], a.GlobalTitleIndicator); err != nil {
+		return err
+	}
+	a.GlobalTitle = gt
+	return nil
+}
+
+// MarshalBinary returns the byte sequence generated from a PartyAddress.
+func (a *PartyAddress) MarshalBinary() ([]byte, error) {
+	b := make([]byte, a.MarshalLen())
+	if err := a.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (a *PartyAddress) MarshalTo(b []byte) error {
+	if len(b) < a.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	gti := a.gti()
+	ai := (a.RoutingIndicator&0x01)<<6 | (gti&0x0f)<<2
+	if a.National {
+		ai |= 0x80
+	}
+	if a.SSNIndicator {
+		ai |= 0x02
+	}
+	if a.PointCodeIndicator {
+		ai |= 0x01
+	}
+	b[0] = ai
+
+	offset := 1
+	if a.PointCodeIndicator {
+		b[offset] = uint8(a.PointCode)
+		b[offset+1] = uint8(a.PointCode>>8) & 0x3f
+		offset += 2
+	}
+	if a.SSNIndicator {
+		b[offset] = a.SubsystemNumber
+		offset++
+	}
+	if gti == GTINone {
+		return nil
+	}
+	return a.GlobalTitle.marshalTo(b[offset:], gti)
+}
+
+// MarshalLen returns the serial length of PartyAddress.
+func (a *PartyAddress) MarshalLen() int {
+	l := 1
+	if a.PointCodeIndicator {
+		l += 2
+	}
+	if a.SSNIndicator {
+		l++
+	}
+	if gti := a.gti(); gti != GTINone {
+		l += a.GlobalTitle.marshalLen(gti)
+	}
+	return l
+}
+
+func (a *PartyAddress) gti() uint8 {
+	if a.GlobalTitle == nil {
+		return GTINone
+	}
+	return a.GlobalTitleIndicator
+}
+
+// GlobalTitle is the Global Title in PartyAddress. Which of the fields are
+// used depends on the Global Title Indicator in PartyAddress.
+//
+//   - GTI 1: NatureOfAddress and Odd
+//   - GTI 2: TranslationType
+//   - GTI 3: TranslationType, NumberingPlan and EncodingScheme
+//   - GTI 4: TranslationType, NumberingPlan, EncodingScheme and NatureOfAddress
+type GlobalTitle struct {
+	TranslationType uint8
+	NumberingPlan   uint8
+	EncodingScheme  uint8
+	NatureOfAddress uint8
+	// Odd is the odd/even indicator in GTI 1. In GTI 3 and 4, it is derived
+	// from EncodingScheme when decoded.
+	Odd bool
+	// Address is the address information, which is BCD encoded digits if the
+	// EncodingScheme is BCD. Use Digits and SetDigits to access it as string.
+	Address []byte
+}
+
+// NewGlobalTitle creates a GlobalTitle with the digits encoded in BCD.
+func NewGlobalTitle(tt, np, nai uint8, digits string) (*GlobalTitle, error) {
+	gt := &GlobalTitle{
+		TranslationType: tt,
+		NumberingPlan:   np,
+		NatureOfAddress: nai,
+	}
+	if err := gt.SetDigits(digits); err != nil {
+		return nil, err
+	}
+	return gt, nil
+}
+
+const bcdDigits = "0123456789abcdef"
+
+// Digits returns the address information decoded from BCD.
+func (g *GlobalTitle) Digits() string {
+	var sb strings.Builder
+	sb.Grow(len(g.Address) * 2)
+	for i, o := range g.Address {
+		sb.WriteByte(bcdDigits[o&0x0f])
+		if i == len(g.Address)-1 && g.odd() {
+			break
+		}
+		sb.WriteByte(bcdDigits[o>>4])
+	}
+	return sb.String()
+}
+
+// SetDigits sets the address information encoded in BCD, and the odd/even
+// indicator and the encoding scheme accordingly. The digits are hexadecimal
+// characters, where 'b' and 'c' are '*' and '#' in some numbering plans.
+func (g *GlobalTitle) SetDigits(digits string) error {
+	addr := make([]byte, (len(digits)+1)/2)
+	for i := 0; i < len(digits); i++ {
+		d := strings.IndexByte(bcdDigits, toLower(digits[i]))
+		if d < 0 {
+			return ErrInvalidDigits
+		}
+		if i%2 == 0 {
+			addr[i/2] = uint8(d)
+		} else {
+			addr[i/2] |= uint8(d) << 4
+		}
+	}
+
+	g.Address = addr
+	g.Odd = len(digits)%2 == 1
+	g.EncodingScheme = EncodingSchemeBCDEven
+	if g.Odd {
+		g.EncodingScheme = EncodingSchemeBCDOdd
+	}
+	return nil
+}
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'F' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// odd reports whether the number of digits is odd, from the field that is
+// available in the GTI.
+func (g *GlobalTitle) odd() bool {
+	if g.EncodingScheme == EncodingSchemeBCDOdd {
+		return true
+	}
+	return g.EncodingScheme == EncodingSchemeUnknown && g.Odd
+}
+
+func (g *GlobalTitle) unmarshal(b []byte, gti uint8) error {
+	var n int
+	switch gti {
+	case GTINatureOfAddress:
+		n = 1
+	case GTITranslationType:
+		n = 1
+	case GTITranslationTypeNumberingPlan:
+		n = 2
+	case GTITranslationTypeNumberingPlanNature:
+		n = 3
+	default:
+		return ErrUnsupportedGTI
+	}
+	if len(b) < n {
+		return ErrTooShortToParse
+	}
+
+	switch gti {
+	case GTINatureOfAddress:
+		g.Odd = b[0]&0x80 != 0
+		g.NatureOfAddress = b[0] & 0x7f
+	case GTITranslationType:
+		g.TranslationType = b[0]
+	case GTITranslationTypeNumberingPlan:
+		g.TranslationType = b[0]
+		g.NumberingPlan = b[1] >> 4
+		g.EncodingScheme = b[1] & 0x0f
+		g.Odd = g.EncodingScheme == EncodingSchemeBCDOdd
+	case GTITranslationTypeNumberingPlanNature:
+		g.TranslationType = b[0]
+		g.NumberingPlan = b[1] >> 4
+		g.EncodingScheme = b[1] & 0x0f
+		g.Odd = g.EncodingScheme == EncodingSchemeBCDOdd
+		g.NatureOfAddress = b[2] & 0x7f
+	}
+	g.Address = b[n:]
+	return nil
+}
+
+func (g *GlobalTitle) marshalTo(b []byte, gti uint8) error {
+	var n int
+	switch gti {
+	case GTINatureOfAddress:
+		b[0] = g.NatureOfAddress & 0x7f
+		if g.Odd {
+			b[0] |= 0x80
+		}
+		n = 1
+	case GTITranslationType:
+		b[0] = g.TranslationType
+		n = 1
+	case GTITranslationTypeNumberingPlan:
+		b[0] = g.TranslationType
+		b[1] = g.NumberingPlan<<4 | g.EncodingScheme&0x0f
+		n = 2
+	case GTITranslationTypeNumberingPlanNature:
+		b[0] = g.TranslationType
+		b[1] = g.NumberingPlan<<4 | g.EncodingScheme&0x0f
+		b[2] = g.NatureOfAddress & 0x7f
+		n = 3
+	default:
+		return ErrUnsupportedGTI
+	}
+	copy(b[n:], g.Address)
+	return nil
+}
+
+func (g *GlobalTitle) marshalLen(gti uint8) int {
+	switch gti {
+	case GTINatureOfAddress, GTITranslationType:
+		return 1 + len(g.Address)
+	case GTITranslationTypeNumberingPlan:
+		return 2 + len(g.Address)
+	case GTITranslationTypeNumberingPlanNature:
+		return 3 + len(g.Address)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestPartyAddress(t *testing.T) {
+	gt := func(tt, np, nai uint8, digits string) *GlobalTitle {
+		g, err := NewGlobalTitle(tt, np, nai, digits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+	gti1 := gt(0, 0, NatureInternational, "12345")
+	gti1.EncodingScheme = EncodingSchemeUnknown // not in GTI 1
+	gti2 := gt(0x11, 0, 0, "1234")
+	gti2.EncodingScheme = EncodingSchemeUnknown // not in GTI 2
+	gti3 := gt(0, NumberingPlanLandMobile, 0, "440101234567890")
+
+	cases := []struct {
+		name       string
+		structured *PartyAddress
+		serialized []byte
+		digits     string
+	}{
+		{
+			"ssn",
+			NewPartyAddressSSN(0x3fff, SSNVLR),
+			[]byte{0x43, 0xff, 0x3f, 0x07},
+			"",
+		}, {
+			"gti-1",
+			NewPartyAddressGT(GTINatureOfAddress, gti1, 0),
+			[]byte{0x04, 0x84, 0x21, 0x43, 0x05},
+			"12345",
+		}, {
+			"gti-2",
+			NewPartyAddressGT(GTITranslationType, gti2, SSNCAP),
+			[]byte{0x0a, 0x92, 0x11, 0x21, 0x43},
+			"1234",
+		}, {
+			"gti-3",
+			NewPartyAddressGT(GTITranslationTypeNumberingPlan, gti3, SSNHLR),
+			[]byte{0x0e, 0x06, 0x00, 0x61, 0x44, 0x10, 0x10, 0x32, 0x54, 0x76, 0x98, 0x00},
+			"440101234567890",
+		}, {
+			"gti-4-with-pc",
+			&PartyAddress{
+				National:             true,
+				GlobalTitleIndicator: GTITranslationTypeNumberingPlanNature,
+				PointCodeIndicator:   true,
+				PointCode:            1,
+				GlobalTitle:          gt(0, NumberingPlanISDNTelephony, NatureNational, "0312"),
+			},
+			[]byte{0x91, 0x01, 0x00, 0x00, 0x12, 0x03, 0x30, 0x21},
+			"0312",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b, err := c.structured.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := b, c.serialized; !verify.Values(t, "", got, want) {
+				t.Fail()
+			}
+
+			a, err := ParsePartyAddress(c.serialized)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := a, c.structured; !verify.Values(t, "", got, want) {
+				t.Fail()
+			}
+			if a.GlobalTitle != nil {
+				if got := a.GlobalTitle.Digits(); got != c.digits {
+					t.Errorf("got digits %s, want %s", got, c.digits)
+				}
+			}
+		})
+	}
+}
+
+func TestPartyAddressErrors(t *testing.T) {
+	if _, err := NewGlobalTitle(0, 0, 0, "12x"); !errors.Is(err, ErrInvalidDigits) {
+		t.Errorf("got %v, want %v", err, ErrInvalidDigits)
+	}
+	if _, err := ParsePartyAddress([]byte{0x14}); !errors.Is(err, ErrUnsupportedGTI) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedGTI)
+	}
+	if _, err := ParsePartyAddress([]byte{0x43, 0x01}); !errors.Is(err, ErrTooShortToParse) {
+		t.Errorf("got %v, want %v", err, ErrTooShortToParse)
+	}
+}
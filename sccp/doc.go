@@ -0,0 +1,23 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+/*
+Package sccp provides encoding/decoding feature of the connectionless SCCP
+messages, which are carried as the MTP3 user payload in M3UA DATA.
+
+The messages can be decoded directly from the ProtocolDataPayload returned by
+m3ua.Conn.ReadPD with ParsePD. The XUDT messages can be segmented and
+reassembled with Segment and Reassembler.
+
+SCLC provides the connectionless service to the SCCP users such as MAP and
+CAP on top of m3ua.Conn, with the dispatch per subsystem and the subsystem
+management with SCMG. GTT translates the Called Party Address routed on
+Global Title for the SG relaying SCCP, and relays the messages to the Conn
+chosen by the DPC translated.
+
+Only the ITU-T variant is supported.
+
+Specification: ITU-T Q.713
+*/
+package sccp
@@ -0,0 +1,53 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"testing"
+)
+
+func fuzzSeeds() []Message {
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+	seg := &Segmentation{First: true, Remaining: 1, LocalReference: 1}
+	return []Message{
+		NewUDT(NewProtocolClass(0, true), testCdPA(), testCgPA(), data),
+		NewUDTS(ReturnCauseUnqualified, testCdPA(), testCgPA(), data),
+		NewXUDT(NewProtocolClass(1, false), 15, testCdPA(), testCgPA(), data, seg, NewImportance(1)),
+		NewXUDTS(ReturnCauseUnqualified, 15, testCdPA(), testCgPA(), data, seg),
+		NewLUDT(NewProtocolClass(1, false), 15, testCdPA(), testCgPA(), data, seg),
+		NewLUDTS(ReturnCauseUnqualified, 15, testCdPA(), testCgPA(), data, nil, NewImportance(1)),
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, m := range fuzzSeeds() {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m, err := Parse(b)
+		if err != nil {
+			return
+		}
+
+		// anything decoded successfully should be encoded and decoded again,
+		// unless the parts overlapping in b are too long to be laid out.
+		encoded, err := m.MarshalBinary()
+		if errors.Is(err, ErrTooLong) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to encode decoded message: %v", err)
+		}
+		if _, err := Parse(encoded); err != nil {
+			t.Fatalf("failed to decode encoded message %x: %v", encoded, err)
+		}
+	})
+}
@@ -0,0 +1,315 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// Rule is a Global Title Translation rule.
+type Rule struct {
+	Match  Match
+	Result Result
+}
+
+// Match is the criteria of Rule to be applied to a Called Party Address.
+// The nil or zero fields match any.
+type Match struct {
+	GlobalTitleIndicator uint8
+	TranslationType      *uint8
+	NumberingPlan        *uint8
+	NatureOfAddress      *uint8
+	// Prefix is matched against the leading digits of the Global Title.
+	// The rule with the longest Prefix is applied among the ones matched.
+	Prefix string
+}
+
+// Result is the translation by Rule.
+type Result struct {
+	// DPC is the point code the message is routed to.
+	DPC uint32
+	// Strip is the number of the leading digits removed, which is followed by
+	// Prepend added. To replace all the digits, set Strip to the number large
+	// enough and the new digits in Prepend.
+	Strip   int
+	Prepend string
+	// RouteOnSSN sets the Routing Indicator to route on SSN, which is the
+	// translation at the final destination. Otherwise, the message is routed
+	// on GT at DPC again.
+	RouteOnSSN bool
+	// SSN is set in the Called Party Address if not zero.
+	SSN uint8
+	// IncludePC sets DPC in the Called Party Address.
+	IncludePC bool
+	// GlobalTitleIndicator, TranslationType, NumberingPlan and NatureOfAddress
+	// replace the ones in the Global Title if not zero or nil.
+	GlobalTitleIndicator uint8
+	TranslationType      *uint8
+	NumberingPlan        *uint8
+	NatureOfAddress      *uint8
+}
+
+// ErrNoRoute is returned by Relay when no Transport is found for the DPC.
+var ErrNoRoute = errors.New("no route to the destination point code")
+
+// NoTranslationError is returned by GTT when the message cannot be translated.
+// Cause is the Return Cause to return the message with.
+type NoTranslationError struct {
+	Cause uint8
+}
+
+// Error returns error string with the cause.
+func (e *NoTranslationError) Error() string {
+	switch e.Cause {
+	case ReturnCauseNoTranslationForNature:
+		return "no translation for an address of such nature"
+	case ReturnCauseNoTranslationForAddress:
+		return "no translation for this specific address"
+	case ReturnCauseHopCounterViolation:
+		return "hop counter violation"
+	default:
+		return fmt.Sprintf("no translation, cause: %d", e.Cause)
+	}
+}
+
+// GTT is the Global Title Translation engine to route the SCCP messages on the
+// Called Party Address, which is typically used in the SG that relays SCCP.
+//
+// The ProtocolDataPayload rewritten by TranslatePD has the DPC translated,
+// which should be written to the Conn towards the DPC, e.g., the one serving
+// the AS whose Routing Key has it. Relay does it with the Transport chosen by
+// Route.
+//
+// GTT is safe for concurrent use, and the rules can be replaced at runtime.
+type GTT struct {
+	// OPC replaces the OPC in the messages translated if not zero, which is
+	// the point code of the SG.
+	OPC uint32
+	// Route returns the Transport, e.g., *m3ua.Conn, that the messages to the
+	// DPC are written to by Relay, or false if there is none.
+	Route func(dpc uint32) (Transport, bool)
+
+	mu    sync.RWMutex
+	rules []*Rule
+}
+
+// NewGTT creates a new GTT with the rules.
+func NewGTT(rules ...*Rule) *GTT {
+	return &GTT{rules: rules}
+}
+
+// SetRules replaces the rules.
+func (g *GTT) SetRules(rules ...*Rule) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rules = rules
+}
+
+// Translate translates the Called Party Address routed on GT. It returns
+// the new Called Party Address and the DPC to route the message to.
+// The address given is not modified.
+//
+// It returns ErrInvalidPointCode if the DPC is to be included in the address
+// but does not fit in 14 bits.
+func (g *GTT) Translate(cd *PartyAddress) (*PartyAddress, uint32, error) {
+	gt := cd.GlobalTitle
+	if gt == nil {
+		return nil, 0, &NoTranslationError{Cause: ReturnCauseNoTranslationForNature}
+	}
+	digits := gt.Digits()
+
+	rule, err := g.lookup(cd.GlobalTitleIndicator, gt, digits)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := rule.Result
+
+	newGT := *gt
+	if res.TranslationType != nil {
+		newGT.TranslationType = *res.TranslationType
+	}
+	if res.NumberingPlan != nil {
+		newGT.NumberingPlan = *res.NumberingPlan
+	}
+	if res.NatureOfAddress != nil {
+		newGT.NatureOfAddress = *res.NatureOfAddress
+	}
+	if res.Strip > 0 || res.Prepend != "" {
+		digits = res.Prepend + digits[min(res.Strip, len(digits)):]
+		if err := newGT.SetDigits(digits); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	newCd := *cd
+	newCd.GlobalTitle = &newGT
+	if res.GlobalTitleIndicator != 0 {
+		newCd.GlobalTitleIndicator = res.GlobalTitleIndicator
+	}
+	if res.RouteOnSSN {
+		newCd.RoutingIndicator = RouteOnSSN
+	}
+	if res.SSN != 0 {
+		newCd.SSNIndicator = true
+		newCd.SubsystemNumber = res.SSN
+	}
+	if res.IncludePC {
+		pc, err := pointCode(res.DPC)
+		if err != nil {
+			return nil, 0, err
+		}
+		newCd.PointCodeIndicator = true
+		newCd.PointCode = pc
+	}
+	return &newCd, res.DPC, nil
+}
+
+// lookup returns the rule with the longest prefix among the ones matched.
+func (g *GTT) lookup(gti uint8, gt *GlobalTitle, digits string) (*Rule, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var found *Rule
+	var natureMatched bool
+	for _, r := range g.rules {
+		m := r.Match
+		if (m.GlobalTitleIndicator != 0 && m.GlobalTitleIndicator != gti) ||
+			(m.TranslationType != nil && *m.TranslationType != gt.TranslationType) ||
+			(m.NumberingPlan != nil && *m.NumberingPlan != gt.NumberingPlan) ||
+			(m.NatureOfAddress != nil && *m.NatureOfAddress != gt.NatureOfAddress) {
+			continue
+		}
+		natureMatched = true
+
+		if !strings.HasPrefix(digits, m.Prefix) {
+			continue
+		}
+		if found == nil || len(m.Prefix) > len(found.Match.Prefix) {
+			found = r
+		}
+	}
+
+	switch {
+	case found != nil:
+		return found, nil
+	case natureMatched:
+		return nil, &NoTranslationError{Cause: ReturnCauseNoTranslationForAddress}
+	default:
+		return nil, &NoTranslationError{Cause: ReturnCauseNoTranslationForNature}
+	}
+}
+
+// TranslatePD translates the SCCP message in ProtocolDataPayload. It returns
+// the copy of pd with the DPC translated and the message rewritten, or pd
+// as it is if the message is routed on SSN.
+//
+// The Hop Counter in XUDT, XUDTS, LUDT and LUDTS is decremented, and
+// the Calling Party Address routed on SSN without point code is added the
+// OPC in pd, so that the response can be routed back.
+//
+// It returns ErrNotSCCP if pd is not SCCP, and NoTranslationError if the
+// message cannot be translated, which should be returned to the originator
+// if the return option is set. ErrInvalidPointCode is returned if the OPC to
+// be added does not fit in 14 bits.
+func (g *GTT) TranslatePD(pd *params.ProtocolDataPayload) (*params.ProtocolDataPayload, error) {
+	m, err := ParsePD(pd)
+	if err != nil {
+		return nil, err
+	}
+
+	cd, cg, hop := routingFields(m)
+	if cd == nil || (*cd).RoutingIndicator == RouteOnSSN {
+		return pd, nil
+	}
+
+	if hop != nil {
+		if *hop <= 1 {
+			return nil, &NoTranslationError{Cause: ReturnCauseHopCounterViolation}
+		}
+		*hop--
+	}
+
+	newCd, dpc, err := g.Translate(*cd)
+	if err != nil {
+		return nil, err
+	}
+	*cd = newCd
+	if c := *cg; c.RoutingIndicator == RouteOnSSN && !c.PointCodeIndicator {
+		pc, err := pointCode(pd.OriginatingPointCode)
+		if err != nil {
+			return nil, err
+		}
+		newCg := *c
+		newCg.PointCodeIndicator = true
+		newCg.PointCode = pc
+		*cg = &newCg
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	newPD := *pd
+	newPD.DestinationPointCode = dpc
+	if g.OPC != 0 {
+		newPD.OriginatingPointCode = g.OPC
+	}
+	newPD.Data = b
+	return &newPD, nil
+}
+
+// Relay translates the SCCP message in pd with TranslatePD, and writes it to
+// the Transport returned by Route for the DPC translated.
+//
+// It returns the errors from TranslatePD as they are, and ErrNoRoute if Route
+// is not set or returns no Transport for the DPC.
+func (g *GTT) Relay(pd *params.ProtocolDataPayload) error {
+	translated, err := g.TranslatePD(pd)
+	if err != nil {
+		return err
+	}
+
+	dpc := translated.DestinationPointCode
+	if g.Route == nil {
+		return fmt.Errorf("%w: %d", ErrNoRoute, dpc)
+	}
+	tr, ok := g.Route(dpc)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrNoRoute, dpc)
+	}
+
+	_, err = tr.WritePD(params.NewProtocolData(
+		translated.OriginatingPointCode, dpc, translated.ServiceIndicator,
+		translated.NetworkIndicator, translated.MessagePriority,
+		translated.SignalingLinkSelection, translated.Data,
+	))
+	return err
+}
+
+// routingFields returns the pointers to the fields used in routing, where
+// hop is nil if the message has no Hop Counter.
+func routingFields(m Message) (cd, cg **PartyAddress, hop *uint8) {
+	switch m := m.(type) {
+	case *UDT:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, nil
+	case *UDTS:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, nil
+	case *XUDT:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, &m.HopCounter
+	case *XUDTS:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, &m.HopCounter
+	case *LUDT:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, &m.HopCounter
+	case *LUDTS:
+		return &m.CalledPartyAddress, &m.CallingPartyAddress, &m.HopCounter
+	default:
+		return nil, nil, nil
+	}
+}
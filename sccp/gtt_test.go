@@ -0,0 +1,150 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestGTT(t *testing.T) {
+	e164, e214 := NumberingPlanISDNTelephony, NumberingPlanISDNMobile
+	g := NewGTT(
+		&Rule{
+			Match:  Match{NumberingPlan: &e164, Prefix: "81"},
+			Result: Result{DPC: 10},
+		},
+		&Rule{
+			// the longer prefix wins regardless of the order.
+			Match: Match{NumberingPlan: &e164, Prefix: "8180"},
+			Result: Result{
+				DPC: 20, Strip: 2, Prepend: "0", NumberingPlan: &e214,
+				RouteOnSSN: true, SSN: SSNHLR, IncludePC: true,
+			},
+		},
+	)
+
+	cd, dpc, err := g.Translate(testCgPA())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dpc != 20 || cd.GlobalTitle.Digits() != "08012345678" || cd.GlobalTitle.NumberingPlan != e214 {
+		t.Errorf("unexpected translation: %d, %+v", dpc, cd.GlobalTitle)
+	}
+	if cd.RoutingIndicator != RouteOnSSN || cd.SubsystemNumber != SSNHLR || !cd.PointCodeIndicator || cd.PointCode != 20 {
+		t.Errorf("unexpected Called Party Address: %+v", cd)
+	}
+	if testCgPA().GlobalTitle.Digits() != "818012345678" {
+		t.Error("the address given is modified")
+	}
+
+	var noTranslation *NoTranslationError
+	gt, _ := NewGlobalTitle(0, e164, NatureInternational, "44")
+	if _, _, err := g.Translate(NewPartyAddressGT(GTITranslationTypeNumberingPlanNature, gt, 0)); !errors.As(err, &noTranslation) || noTranslation.Cause != ReturnCauseNoTranslationForAddress {
+		t.Errorf("got %v, want no translation for address", err)
+	}
+	gt, _ = NewGlobalTitle(0, e214, NatureInternational, "81")
+	if _, _, err := g.Translate(NewPartyAddressGT(GTITranslationTypeNumberingPlanNature, gt, 0)); !errors.As(err, &noTranslation) || noTranslation.Cause != ReturnCauseNoTranslationForNature {
+		t.Errorf("got %v, want no translation for nature", err)
+	}
+}
+
+func TestGTTTranslatePD(t *testing.T) {
+	g := NewGTT(&Rule{Match: Match{Prefix: "81"}, Result: Result{DPC: 10}})
+	g.OPC = 5
+
+	cg := NewPartyAddressSSN(0, SSNMSC)
+	cg.PointCodeIndicator = false
+	x := NewXUDT(NewProtocolClass(0, true), 2, testCgPA(), cg, []byte{1}, nil)
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd := params.NewProtocolDataPayload(3, 4, params.ServiceIndSCCP, 0, 0, 1, b)
+
+	got, err := g.TranslatePD(pd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.OriginatingPointCode != 5 || got.DestinationPointCode != 10 || pd.DestinationPointCode != 4 {
+		t.Errorf("unexpected routing label: %+v", got)
+	}
+	translated, err := ParseXUDT(got.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated.HopCounter != 1 {
+		t.Errorf("got Hop Counter %d, want 1", translated.HopCounter)
+	}
+	if c := translated.CallingPartyAddress; !c.PointCodeIndicator || c.PointCode != 3 {
+		t.Errorf("OPC is not added in Calling Party Address: %+v", c)
+	}
+
+	// the hop counter reaches zero in the next translation.
+	var noTranslation *NoTranslationError
+	if _, err := g.TranslatePD(got); !errors.As(err, &noTranslation) || noTranslation.Cause != ReturnCauseHopCounterViolation {
+		t.Errorf("got %v, want hop counter violation", err)
+	}
+
+	// routed on SSN as it is.
+	b, _ = NewUDT(0, testCdPA(), testCgPA(), nil).MarshalBinary()
+	pd = params.NewProtocolDataPayload(3, 4, params.ServiceIndSCCP, 0, 0, 1, b)
+	if got, err := g.TranslatePD(pd); err != nil || got != pd {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestGTTRelay(t *testing.T) {
+	g := NewGTT(&Rule{Match: Match{Prefix: "81"}, Result: Result{DPC: 10}})
+	b, err := NewUDT(0, testCgPA(), testCdPA(), []byte{1}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd := params.NewProtocolDataPayload(3, 4, params.ServiceIndSCCP, 2, 0, 1, b)
+
+	if err := g.Relay(pd); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("got %v, want %v", err, ErrNoRoute)
+	}
+
+	tr := newFakeTransport()
+	g.Route = func(dpc uint32) (Transport, bool) {
+		return tr, dpc == 10
+	}
+	if err := g.Relay(pd); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := tr.written(t)
+	if got.OriginatingPointCode != 3 || got.DestinationPointCode != 10 || got.NetworkIndicator != 2 || got.SignalingLinkSelection != 1 {
+		t.Errorf("unexpected routing label: %+v", got)
+	}
+
+	g.SetRules(&Rule{Match: Match{Prefix: "81"}, Result: Result{DPC: 20}})
+	if err := g.Relay(pd); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("got %v, want %v", err, ErrNoRoute)
+	}
+}
+
+func TestGTTInvalidPointCode(t *testing.T) {
+	// the DPC does not fit in the Called Party Address.
+	g := NewGTT(&Rule{Match: Match{Prefix: "81"}, Result: Result{DPC: 0x4000, IncludePC: true}})
+	if _, _, err := g.Translate(testCgPA()); !errors.Is(err, ErrInvalidPointCode) {
+		t.Errorf("got %v, want %v", err, ErrInvalidPointCode)
+	}
+
+	// the OPC does not fit in the Calling Party Address.
+	g.SetRules(&Rule{Match: Match{Prefix: "81"}, Result: Result{DPC: 10}})
+	cg := NewPartyAddressSSN(0, SSNMSC)
+	cg.PointCodeIndicator = false
+	b, err := NewUDT(0, testCgPA(), cg, []byte{1}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd := params.NewProtocolDataPayload(0x4003, 4, params.ServiceIndSCCP, 0, 0, 1, b)
+	if _, err := g.TranslatePD(pd); !errors.Is(err, ErrInvalidPointCode) {
+		t.Errorf("got %v, want %v", err, ErrInvalidPointCode)
+	}
+}
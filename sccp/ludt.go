@@ -0,0 +1,112 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// LUDT is a Long Unitdata message.
+//
+// Spec: 4.20, ITU-T Q.713.
+type LUDT struct {
+	ProtocolClass       ProtocolClass
+	HopCounter          uint8
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	Data                []byte
+	// Segmentation is the Segmentation parameter, if any.
+	Segmentation *Segmentation
+	// Optional is the optional parameters other than Segmentation.
+	Optional []*OptionalParameter
+}
+
+// NewLUDT creates a new LUDT.
+func NewLUDT(pc ProtocolClass, hopCounter uint8, cdpa, cgpa *PartyAddress, data []byte, seg *Segmentation, opts ...*OptionalParameter) *LUDT {
+	return &LUDT{
+		ProtocolClass:       pc,
+		HopCounter:          hopCounter,
+		CalledPartyAddress:  cdpa,
+		CallingPartyAddress: cgpa,
+		Data:                data,
+		Segmentation:        seg,
+		Optional:            opts,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from an LUDT.
+func (l *LUDT) MarshalBinary() ([]byte, error) {
+	b := make([]byte, l.MarshalLen())
+	if err := l.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (l *LUDT) MarshalTo(b []byte) error {
+	if len(b) < l.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	parts, err := addressParts(l.CalledPartyAddress, l.CallingPartyAddress, 2, l.Data)
+	if err != nil {
+		return err
+	}
+	opt, err := marshalOptional(l.Segmentation, l.Optional)
+	if err != nil {
+		return err
+	}
+	b[0] = uint8(MsgTypeLUDT)
+	b[1] = uint8(l.ProtocolClass)
+	b[2] = l.HopCounter
+	return marshalVariable(b[3:], 2, parts, opt, true)
+}
+
+// ParseLUDT decodes given byte sequence as an LUDT.
+func ParseLUDT(b []byte) (*LUDT, error) {
+	l := &LUDT{}
+	if err := l.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an LUDT.
+func (l *LUDT) UnmarshalBinary(b []byte) error {
+	if len(b) < 3 {
+		return ErrTooShortToParse
+	}
+	l.ProtocolClass = ProtocolClass(b[1])
+	l.HopCounter = b[2]
+
+	parts, opt, err := parseVariable(b[3:], 2, []int{1, 1, 2}, true)
+	if err != nil {
+		return err
+	}
+	l.CalledPartyAddress, l.CallingPartyAddress, err = parseAddresses(parts)
+	if err != nil {
+		return err
+	}
+	l.Data = parts[2]
+
+	l.Segmentation, l.Optional = nil, nil
+	if opt == nil {
+		return nil
+	}
+	l.Segmentation, l.Optional, err = parseOptional(opt)
+	return err
+}
+
+// MarshalLen returns the serial length of LUDT.
+func (l *LUDT) MarshalLen() int {
+	return 3 + 8 + addressesLen(l.CalledPartyAddress, l.CallingPartyAddress) + 2 + len(l.Data) + optionalLen(l.Segmentation, l.Optional)
+}
+
+// MessageType returns the Message Type.
+func (l *LUDT) MessageType() MsgType {
+	return MsgTypeLUDT
+}
+
+// MessageTypeName returns the name of Message Type.
+func (l *LUDT) MessageTypeName() string {
+	return MsgTypeLUDT.String()
+}
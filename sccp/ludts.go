@@ -0,0 +1,112 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// LUDTS is a Long Unitdata Service message.
+//
+// Spec: 4.21, ITU-T Q.713.
+type LUDTS struct {
+	ReturnCause         uint8
+	HopCounter          uint8
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	Data                []byte
+	// Segmentation is the Segmentation parameter, if any.
+	Segmentation *Segmentation
+	// Optional is the optional parameters other than Segmentation.
+	Optional []*OptionalParameter
+}
+
+// NewLUDTS creates a new LUDTS.
+func NewLUDTS(cause uint8, hopCounter uint8, cdpa, cgpa *PartyAddress, data []byte, seg *Segmentation, opts ...*OptionalParameter) *LUDTS {
+	return &LUDTS{
+		ReturnCause:         cause,
+		HopCounter:          hopCounter,
+		CalledPartyAddress:  cdpa,
+		CallingPartyAddress: cgpa,
+		Data:                data,
+		Segmentation:        seg,
+		Optional:            opts,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from an LUDTS.
+func (l *LUDTS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, l.MarshalLen())
+	if err := l.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (l *LUDTS) MarshalTo(b []byte) error {
+	if len(b) < l.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	parts, err := addressParts(l.CalledPartyAddress, l.CallingPartyAddress, 2, l.Data)
+	if err != nil {
+		return err
+	}
+	opt, err := marshalOptional(l.Segmentation, l.Optional)
+	if err != nil {
+		return err
+	}
+	b[0] = uint8(MsgTypeLUDTS)
+	b[1] = l.ReturnCause
+	b[2] = l.HopCounter
+	return marshalVariable(b[3:], 2, parts, opt, true)
+}
+
+// ParseLUDTS decodes given byte sequence as an LUDTS.
+func ParseLUDTS(b []byte) (*LUDTS, error) {
+	l := &LUDTS{}
+	if err := l.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an LUDTS.
+func (l *LUDTS) UnmarshalBinary(b []byte) error {
+	if len(b) < 3 {
+		return ErrTooShortToParse
+	}
+	l.ReturnCause = b[1]
+	l.HopCounter = b[2]
+
+	parts, opt, err := parseVariable(b[3:], 2, []int{1, 1, 2}, true)
+	if err != nil {
+		return err
+	}
+	l.CalledPartyAddress, l.CallingPartyAddress, err = parseAddresses(parts)
+	if err != nil {
+		return err
+	}
+	l.Data = parts[2]
+
+	l.Segmentation, l.Optional = nil, nil
+	if opt == nil {
+		return nil
+	}
+	l.Segmentation, l.Optional, err = parseOptional(opt)
+	return err
+}
+
+// MarshalLen returns the serial length of LUDTS.
+func (l *LUDTS) MarshalLen() int {
+	return 3 + 8 + addressesLen(l.CalledPartyAddress, l.CallingPartyAddress) + 2 + len(l.Data) + optionalLen(l.Segmentation, l.Optional)
+}
+
+// MessageType returns the Message Type.
+func (l *LUDTS) MessageType() MsgType {
+	return MsgTypeLUDTS
+}
+
+// MessageTypeName returns the name of Message Type.
+func (l *LUDTS) MessageTypeName() string {
+	return MsgTypeLUDTS.String()
+}
@@ -0,0 +1,38 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import "testing"
+
+func TestLUDTS(t *testing.T) {
+	cases := []testCase{
+		{
+			"has-importance",
+			NewLUDTS(
+				ReturnCauseNoTranslationForAddress, 15, testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef},
+				nil, NewImportance(3),
+			),
+			concat(
+				// Type, Return Cause, Hop Counter
+				[]byte{0x14, 0x01, 0x0f},
+				// Pointers
+				[]byte{0x08, 0x00, 0x0b, 0x00, 0x15, 0x00, 0x19, 0x00},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0x00, 0xde, 0xad, 0xbe, 0xef},
+				// Importance, End of Optional Parameters
+				[]byte{0x12, 0x01, 0x03, 0x00},
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte) (Message, error) {
+		v, err := ParseLUDTS(b)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// Optional Parameter Name definitions.
+const (
+	ParamEndOfOptionalParameters uint8 = 0x00
+	ParamSegmentation            uint8 = 0x10
+	ParamImportance              uint8 = 0x12
+)
+
+// OptionalParameter is an optional parameter in XUDT, XUDTS, LUDT and LUDTS,
+// other than Segmentation which has its own field in the messages.
+type OptionalParameter struct {
+	Code  uint8
+	Value []byte
+}
+
+// NewImportance creates the Importance parameter. The importance is 0 to 7.
+func NewImportance(importance uint8) *OptionalParameter {
+	return &OptionalParameter{Code: ParamImportance, Value: []byte{importance & 0x07}}
+}
+
+// Segmentation is the Segmentation parameter.
+type Segmentation struct {
+	// First is set in the first segment.
+	First bool
+	// InSequence is set if the message is class 1, which is to be delivered
+	// in sequence after reassembly.
+	InSequence bool
+	// Remaining is the number of the segments remaining, 0 to 15.
+	Remaining uint8
+	// LocalReference is the 24-bit reference to identify the segments of the
+	// same message.
+	LocalReference uint32
+}
+
+const segmentationLen = 4
+
+func (s *Segmentation) marshalTo(b []byte) {
+	b[0] = s.Remaining & 0x0f
+	if s.First {
+		b[0] |= 0x80
+	}
+	if s.InSequence {
+		b[0] |= 0x40
+	}
+	b[1] = uint8(s.LocalReference)
+	b[2] = uint8(s.LocalReference >> 8)
+	b[3] = uint8(s.LocalReference >> 16)
+}
+
+func (s *Segmentation) unmarshal(b []byte) error {
+	if len(b) != segmentationLen {
+		return ErrInvalidLength
+	}
+	s.First = b[0]&0x80 != 0
+	s.InSequence = b[0]&0x40 != 0
+	s.Remaining = b[0] & 0x0f
+	s.LocalReference = uint32(b[1]) | uint32(b[2])<<8 | uint32(b[3])<<16
+	return nil
+}
+
+// optionalLen returns the length of the optional part, which is zero if no
+// optional parameter is present.
+func optionalLen(seg *Segmentation, opts []*OptionalParameter) int {
+	var l int
+	if seg != nil {
+		l += 2 + segmentationLen
+	}
+	for _, o := range opts {
+		l += 2 + len(o.Value)
+	}
+	if l == 0 {
+		return 0
+	}
+	return l + 1 // End of Optional Parameters
+}
+
+// marshalOptional returns the optional part with Segmentation first.
+func marshalOptional(seg *Segmentation, opts []*OptionalParameter) ([]byte, error) {
+	l := optionalLen(seg, opts)
+	if l == 0 {
+		return nil, nil
+	}
+
+	b := make([]byte, l)
+	var offset int
+	if seg != nil {
+		b[0], b[1] = ParamSegmentation, segmentationLen
+		seg.marshalTo(b[2:])
+		offset += 2 + segmentationLen
+	}
+	for _, o := range opts {
+		if len(o.Value) > 0xff {
+			return nil, ErrTooLong
+		}
+		b[offset], b[offset+1] = o.Code, uint8(len(o.Value))
+		offset += 2
+		offset += copy(b[offset:], o.Value)
+	}
+	b[offset] = ParamEndOfOptionalParameters
+	return b, nil
+}
+
+// parseOptional decodes the optional part until End of Optional Parameters.
+func parseOptional(b []byte) (*Segmentation, []*OptionalParameter, error) {
+	var seg *Segmentation
+	var opts []*OptionalParameter
+	for {
+		if len(b) < 1 {
+			return nil, nil, ErrTooShortToParse
+		}
+		if b[0] == ParamEndOfOptionalParameters {
+			return seg, opts, nil
+		}
+		if len(b) < 2 || len(b) < 2+int(b[1]) {
+			return nil, nil, ErrInvalidLength
+		}
+
+		code, value := b[0], b[2:2+int(b[1])]
+		if code == ParamSegmentation {
+			seg = &Segmentation{}
+			if err := seg.unmarshal(value); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			opts = append(opts, &OptionalParameter{Code: code, Value: value})
+		}
+		b = b[2+len(value):]
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// MsgType is the Message Type of SCCP.
+type MsgType uint8
+
+// Message Type definitions.
+const (
+	MsgTypeUDT   MsgType = 0x09
+	MsgTypeUDTS  MsgType = 0x0a
+	MsgTypeXUDT  MsgType = 0x11
+	MsgTypeXUDTS MsgType = 0x12
+	MsgTypeLUDT  MsgType = 0x13
+	MsgTypeLUDTS MsgType = 0x14
+)
+
+// String returns the name of MsgType.
+func (t MsgType) String() string {
+	switch t {
+	case MsgTypeUDT:
+		return "UDT"
+	case MsgTypeUDTS:
+		return "UDTS"
+	case MsgTypeXUDT:
+		return "XUDT"
+	case MsgTypeXUDTS:
+		return "XUDTS"
+	case MsgTypeLUDT:
+		return "LUDT"
+	case MsgTypeLUDTS:
+		return "LUDTS"
+	default:
+		return fmt.Sprintf("Unknown (%d)", uint8(t))
+	}
+}
+
+// Return Cause definitions.
+const (
+	ReturnCauseNoTranslationForNature uint8 = iota
+	ReturnCauseNoTranslationForAddress
+	ReturnCauseSubsystemCongestion
+	ReturnCauseSubsystemFailure
+	ReturnCauseUnequippedUser
+	ReturnCauseMTPFailure
+	ReturnCauseNetworkCongestion
+	ReturnCauseUnqualified
+	ReturnCauseErrorInMessageTransport
+	ReturnCauseErrorInLocalProcessing
+	ReturnCauseDestinationCannotPerformReassembly
+	ReturnCauseSCCPFailure
+	ReturnCauseHopCounterViolation
+	ReturnCauseSegmentationNotSupported
+	ReturnCauseSegmentationFailure
+)
+
+// Error definitions.
+var (
+	ErrTooShortToMarshalBinary = errors.New("insufficient buffer to serialize SCCP to")
+	ErrTooShortToParse         = errors.New("too short to decode as SCCP")
+	ErrInvalidPointer          = errors.New("message has invalid pointer value")
+	ErrInvalidLength           = errors.New("message has invalid length value")
+	ErrTooLong                 = errors.New("parameter is too long to be pointed")
+	ErrNotSCCP                 = errors.New("service indicator is not SCCP")
+	ErrMissingAddress          = errors.New("called or calling party address is missing")
+	ErrInvalidPointCode        = errors.New("point code does not fit in 14 bits")
+)
+
+// UnsupportedTypeError is used if a message with an unsupported Message Type
+// is given to Parse.
+type UnsupportedTypeError struct {
+	Type MsgType
+}
+
+// Error returns error string with the Message Type.
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported message type: %s", e.Type)
+}
+
+// Message is an interface that defines SCCP messages.
+type Message interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	MarshalTo([]byte) error
+	MarshalLen() int
+	MessageType() MsgType
+	MessageTypeName() string
+}
+
+// Parse decodes the given bytes as a SCCP message.
+// The values in the message returned refer to b, which should not be
+// modified while the message is in use.
+func Parse(b []byte) (Message, error) {
+	if len(b) < 1 {
+		return nil, ErrTooShortToParse
+	}
+
+	var m Message
+	switch MsgType(b[0]) {
+	case MsgTypeUDT:
+		m = &UDT{}
+	case MsgTypeUDTS:
+		m = &UDTS{}
+	case MsgTypeXUDT:
+		m = &XUDT{}
+	case MsgTypeXUDTS:
+		m = &XUDTS{}
+	case MsgTypeLUDT:
+		m = &LUDT{}
+	case MsgTypeLUDTS:
+		m = &LUDTS{}
+	default:
+		return nil, &UnsupportedTypeError{Type: MsgType(b[0])}
+	}
+
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParsePD decodes the Data in ProtocolDataPayload as a SCCP message.
+// It returns ErrNotSCCP if the Service Indicator is not SCCP.
+func ParsePD(pd *params.ProtocolDataPayload) (Message, error) {
+	if pd.ServiceIndicator != params.ServiceIndSCCP {
+		return nil, ErrNotSCCP
+	}
+	return Parse(pd.Data)
+}
+
+// ProtocolClass is the Protocol Class parameter. The lower 4 bits are the
+// class, and the higher 4 bits are the message handling in class 0 and 1.
+type ProtocolClass uint8
+
+// NewProtocolClass creates a ProtocolClass. The returnOnError is to request
+// the message to be returned on error.
+func NewProtocolClass(class uint8, returnOnError bool) ProtocolClass {
+	pc := ProtocolClass(class & 0x0f)
+	if returnOnError {
+		pc |= 0x80
+	}
+	return pc
+}
+
+// Class returns the protocol class, 0 or 1 in connectionless messages.
+func (p ProtocolClass) Class() uint8 {
+	return uint8(p) & 0x0f
+}
+
+// ReturnOnError reports whether the message is requested to be returned on error.
+func (p ProtocolClass) ReturnOnError() bool {
+	return p&0x80 != 0
+}
+
+// part is a mandatory variable part of a message.
+type part struct {
+	// lenSize is the size of the length indicator, 1 or 2
+	lenSize int
+	value   []byte
+}
+
+// marshalVariable puts the pointers with the size of ptrSize, followed by
+// the mandatory variable parts and the optional part. optPtr is whether the
+// message has the pointer to the optional part, which is zero if opt is empty.
+func marshalVariable(b []byte, ptrSize int, parts []part, opt []byte, optPtr bool) error {
+	nptr := len(parts)
+	if optPtr {
+		nptr++
+	}
+
+	offset := nptr * ptrSize
+	for i, p := range parts {
+		if err := putPointer(b[i*ptrSize:], ptrSize, offset-i*ptrSize); err != nil {
+			return err
+		}
+		switch p.lenSize {
+		case 1:
+			if len(p.value) > 0xff {
+				return ErrTooLong
+			}
+			b[offset] = uint8(len(p.value))
+		case 2:
+			if len(p.value) > 0xffff {
+				return ErrTooLong
+			}
+			b[offset] = uint8(len(p.value))
+			b[offset+1] = uint8(len(p.value) >> 8)
+		}
+		offset += p.lenSize
+		offset += copy(b[offset:], p.value)
+	}
+
+	if !optPtr {
+		return nil
+	}
+	i := len(parts) * ptrSize
+	if len(opt) == 0 {
+		return putPointer(b[i:], ptrSize, 0)
+	}
+	if err := putPointer(b[i:], ptrSize, offset-i); err != nil {
+		return err
+	}
+	copy(b[offset:], opt)
+	return nil
+}
+
+func putPointer(b []byte, size, ptr int) error {
+	switch size {
+	case 1:
+		if ptr > 0xff {
+			return ErrTooLong
+		}
+		b[0] = uint8(ptr)
+	case 2:
+		if ptr > 0xffff {
+			return ErrTooLong
+		}
+		b[0] = uint8(ptr)
+		b[1] = uint8(ptr >> 8)
+	}
+	return nil
+}
+
+func pointer(b []byte, size int) int {
+	if size == 1 {
+		return int(b[0])
+	}
+	return int(b[0]) | int(b[1])<<8
+}
+
+// parseVariable decodes the pointers and the parts pointed from b. lenSizes
+// is the sizes of the length indicators of the mandatory variable parts.
+// The optional part returned is nil if it is absent.
+func parseVariable(b []byte, ptrSize int, lenSizes []int, optPtr bool) ([][]byte, []byte, error) {
+	nptr := len(lenSizes)
+	if optPtr {
+		nptr++
+	}
+	if len(b) < nptr*ptrSize {
+		return nil, nil, ErrTooShortToParse
+	}
+
+	parts := make([][]byte, len(lenSizes))
+	for i, lenSize := range lenSizes {
+		pos := i*ptrSize + pointer(b[i*ptrSize:], ptrSize)
+		if pos < nptr*ptrSize || pos+lenSize > len(b) {
+			return nil, nil, ErrInvalidPointer
+		}
+		l := pointer(b[pos:], lenSize)
+		pos += lenSize
+		if pos+l > len(b) {
+			return nil, nil, ErrInvalidLength
+		}
+		parts[i] = b[pos : pos+l]
+	}
+
+	if !optPtr {
+		return parts, nil, nil
+	}
+	i := len(lenSizes) * ptrSize
+	ptr := pointer(b[i:], ptrSize)
+	if ptr == 0 {
+		return parts, nil, nil
+	}
+	if i+ptr < nptr*ptrSize || i+ptr >= len(b) {
+		return nil, nil, ErrInvalidPointer
+	}
+	return parts, b[i+ptr:], nil
+}
+
+// addressParts returns the mandatory variable parts common in the
+// connectionless messages; Called Party Address, Calling Party Address and
+// the data with the length indicator of dataLenSize.
+func addressParts(cdpa, cgpa *PartyAddress, dataLenSize int, data []byte) ([]part, error) {
+	if cdpa == nil || cgpa == nil {
+		return nil, ErrMissingAddress
+	}
+	cd, err := cdpa.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	cg, err := cgpa.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return []part{{1, cd}, {1, cg}, {dataLenSize, data}}, nil
+}
+
+// addressesLen returns the length of the Called and Calling Party Address
+// parts including the length indicators.
+func addressesLen(cdpa, cgpa *PartyAddress) int {
+	l := 2
+	if cdpa != nil {
+		l += cdpa.MarshalLen()
+	}
+	if cgpa != nil {
+		l += cgpa.MarshalLen()
+	}
+	return l
+}
+
+func parseAddresses(parts [][]byte) (*PartyAddress, *PartyAddress, error) {
+	cdpa, err := ParsePartyAddress(parts[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	cgpa, err := ParsePartyAddress(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return cdpa, cgpa, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestParsePD(t *testing.T) {
+	udt := NewUDT(NewProtocolClass(0, false), testCdPA(), testCgPA(), []byte{0x01})
+	b, err := udt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pd := params.NewProtocolDataPayload(1, 2, params.ServiceIndSCCP, 0, 0, 0, b)
+	m, err := ParsePD(pd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.(*UDT); !ok {
+		t.Errorf("got %T, want *UDT", m)
+	}
+
+	pd.ServiceIndicator = params.ServiceIndISUP
+	if _, err := ParsePD(pd); !errors.Is(err, ErrNotSCCP) {
+		t.Errorf("got %v, want %v", err, ErrNotSCCP)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var unsupported *UnsupportedTypeError
+	if _, err := Parse([]byte{0x01}); !errors.As(err, &unsupported) {
+		t.Errorf("got %v, want UnsupportedTypeError", err)
+	}
+	// pointer to the outside of the message.
+	if _, err := Parse([]byte{0x09, 0x00, 0x03, 0x04, 0x20}); !errors.Is(err, ErrInvalidPointer) {
+		t.Errorf("got %v, want %v", err, ErrInvalidPointer)
+	}
+	// length longer than the message.
+	if _, err := Parse([]byte{0x09, 0x00, 0x03, 0x03, 0x03, 0x05, 0x43}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("got %v, want %v", err, ErrInvalidLength)
+	}
+	if _, err := NewUDT(0, nil, testCgPA(), nil).MarshalBinary(); !errors.Is(err, ErrMissingAddress) {
+		t.Errorf("got %v, want %v", err, ErrMissingAddress)
+	}
+}
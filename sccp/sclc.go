@@ -0,0 +1,495 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// Transport is the MTP-TRANSFER service that SCLC runs over, which is
+// satisfied by *m3ua.Conn.
+type Transport interface {
+	ReadPD() (*params.ProtocolDataPayload, error)
+	WritePD(protocolData *params.Param) (int, error)
+}
+
+// Default values of SCLC.
+const (
+	DefaultHopCounter uint8 = 15
+	// DefaultMaxMessageLen is the maximum length of the SCCP message that fits
+	// in the 272 octets of MTP3 SIF, excluding the routing label.
+	DefaultMaxMessageLen = 268
+	DefaultSSTInterval   = 30 * time.Second
+)
+
+// Error definitions.
+var (
+	ErrSubsystemProhibited   = errors.New("destination subsystem is prohibited")
+	ErrMaxMessageLenTooShort = errors.New("MaxMessageLen is too short to carry data in XUDT")
+)
+
+// Unitdata is the N-UNITDATA primitive exchanged with the SCCP users.
+type Unitdata struct {
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	// Class is the protocol class, 0 or 1.
+	Class uint8
+	// SequenceControl is to choose the SLS in class 1, so that the messages
+	// with the same value are delivered in sequence. On receipt, it is the SLS
+	// of the message.
+	SequenceControl uint32
+	// ReturnOnError requests the message to be returned on error.
+	ReturnOnError bool
+	Data          []byte
+	// OPC and DPC are the point codes in the MTP routing label, which are set
+	// on receipt.
+	OPC, DPC uint32
+}
+
+// Notice is the N-NOTICE primitive, which indicates that the message sent
+// could not be delivered and is returned from the network.
+type Notice struct {
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	ReturnCause         uint8
+	Data                []byte
+	// OPC is the point code of the signalling point that returned the message.
+	OPC uint32
+}
+
+// SCLC is the SCCP connectionless control, which provides the connectionless
+// service to the SCCP users on top of Transport. The users are registered per
+// local SSN with Handle, and Serve dispatches the messages received to them.
+//
+// The UDT is chosen to send N-UNITDATA if it fits in MaxMessageLen, otherwise
+// the data is segmented into XUDTs. The messages received with the return
+// option set are returned with UDTS, XUDTS or LUDTS when they cannot be
+// delivered. The subsystem status is managed with SSA, SSP and SST of SCCP
+// management (SCMG) on SSN 1.
+//
+// The exported fields should be set before Serve is called.
+type SCLC struct {
+	// OPC is the own point code.
+	OPC uint32
+	// DPC is the point code the messages are sent to when the Called Party
+	// Address has no point code, e.g., the STP that translates GT.
+	DPC              uint32
+	NetworkIndicator uint8
+	MessagePriority  uint8
+	// HopCounter is the Hop Counter in XUDT. DefaultHopCounter is used if zero.
+	HopCounter uint8
+	// MaxMessageLen is the maximum length of the SCCP message to be sent.
+	// DefaultMaxMessageLen is used if zero.
+	MaxMessageLen int
+	// SSTInterval is the interval to send SST to the prohibited subsystems.
+	// DefaultSSTInterval is used if zero.
+	SSTInterval time.Duration
+	// OnNotice is called with N-NOTICE when a message sent is returned.
+	OnNotice func(n *Notice)
+	// OnSubsystemStatus is called when the status of a remote subsystem
+	// changes by SSA or SSP.
+	OnSubsystemStatus func(pc uint32, ssn uint8, allowed bool)
+
+	tr          Transport
+	reassembler Reassembler
+	done        chan struct{}
+	doneOnce    sync.Once
+
+	mu       sync.Mutex
+	local    map[uint8]*subsystem
+	remote   map[remoteSubsystem]struct{} // the prohibited ones
+	sls      uint8
+	localRef uint32
+}
+
+type subsystem struct {
+	handler func(u *Unitdata)
+	allowed bool
+}
+
+type remoteSubsystem struct {
+	pc  uint32
+	ssn uint8
+}
+
+// NewSCLC creates a new SCLC on top of Transport, e.g., *m3ua.Conn.
+func NewSCLC(tr Transport, opc, dpc uint32) *SCLC {
+	return &SCLC{
+		OPC:    opc,
+		DPC:    dpc,
+		tr:     tr,
+		done:   make(chan struct{}),
+		local:  map[uint8]*subsystem{},
+		remote: map[remoteSubsystem]struct{}{},
+	}
+}
+
+// Handle registers the handler of the local subsystem, which is called with
+// N-UNITDATA for the SSN. The subsystem is allowed on registration. The
+// subsystem is removed if the handler is nil.
+//
+// The handler is called in the goroutine running Serve, so the messages of
+// class 1 are handled in sequence.
+func (s *SCLC) Handle(ssn uint8, handler func(u *Unitdata)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if handler == nil {
+		delete(s.local, ssn)
+		return
+	}
+	s.local[ssn] = &subsystem{handler: handler, allowed: true}
+}
+
+// SetSubsystemAllowed changes the status of the local subsystem, and
+// broadcasts SSA or SSP to DPC. It returns ErrInvalidPointCode if OPC or DPC
+// does not fit in 14 bits of SCCP.
+func (s *SCLC) SetSubsystemAllowed(ssn uint8, allowed bool) error {
+	s.mu.Lock()
+	if ss, ok := s.local[ssn]; ok {
+		ss.allowed = allowed
+	}
+	s.mu.Unlock()
+
+	typ := SCMGTypeSSP
+	if allowed {
+		typ = SCMGTypeSSA
+	}
+	return s.sendSCMG(s.DPC, typ, ssn, s.OPC)
+}
+
+// SubsystemAllowed reports whether the remote subsystem is allowed, which is
+// true unless SSP is received for it.
+func (s *SCLC) SubsystemAllowed(pc uint32, ssn uint8) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, prohibited := s.remote[remoteSubsystem{pc, ssn}]
+	return !prohibited
+}
+
+// Send sends the N-UNITDATA.
+//
+// It returns ErrSubsystemProhibited if the Called Party Address is routed on
+// SSN that is prohibited, and ErrMaxMessageLenTooShort if the data needs to be
+// segmented but MaxMessageLen leaves no room for it.
+func (s *SCLC) Send(u *Unitdata) error {
+	dpc := s.DPC
+	if cd := u.CalledPartyAddress; cd != nil && cd.PointCodeIndicator {
+		dpc = uint32(cd.PointCode)
+		if cd.RoutingIndicator == RouteOnSSN && cd.SSNIndicator && !s.SubsystemAllowed(dpc, cd.SubsystemNumber) {
+			return ErrSubsystemProhibited
+		}
+	}
+
+	msgs, err := s.encode(u)
+	if err != nil {
+		return err
+	}
+
+	// all the segments are sent with the same SLS to be in sequence.
+	sls := s.selectSLS(u)
+	for _, m := range msgs {
+		if err := s.write(dpc, sls, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode chooses UDT if it fits in MaxMessageLen, or segmented XUDTs.
+func (s *SCLC) encode(u *Unitdata) ([]Message, error) {
+	pc := NewProtocolClass(u.Class, u.ReturnOnError)
+	udt := NewUDT(pc, u.CalledPartyAddress, u.CallingPartyAddress, u.Data)
+	maxLen := s.maxMessageLen()
+	if len(u.Data) <= 0xff && udt.MarshalLen() <= maxLen {
+		return []Message{udt}, nil
+	}
+
+	xudt := NewXUDT(pc, s.hopCounter(), u.CalledPartyAddress, u.CallingPartyAddress, u.Data, nil)
+	overhead := xudt.MarshalLen() - len(u.Data) + optionalLen(&Segmentation{}, nil)
+	if maxLen <= overhead {
+		return nil, fmt.Errorf("%w: %d octets, while the addresses and Segmentation take %d", ErrMaxMessageLenTooShort, maxLen, overhead)
+	}
+	segs, err := Segment(xudt, min(maxLen-overhead, 0xff), s.nextLocalRef())
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]Message, len(segs))
+	for i, seg := range segs {
+		msgs[i] = seg
+	}
+	return msgs, nil
+}
+
+func (s *SCLC) selectSLS(u *Unitdata) uint8 {
+	if u.Class == 1 {
+		return uint8(u.SequenceControl) & 0x0f
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sls = (s.sls + 1) & 0x0f
+	return s.sls
+}
+
+func (s *SCLC) nextLocalRef() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.localRef = (s.localRef + 1) & 0xffffff
+	return s.localRef
+}
+
+func (s *SCLC) write(dpc uint32, sls uint8, m Message) error {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = s.tr.WritePD(params.NewProtocolData(
+		s.OPC, dpc, params.ServiceIndSCCP, s.NetworkIndicator, s.MessagePriority, sls, b,
+	))
+	return err
+}
+
+// Serve reads the messages from Transport and dispatches them until reading
+// fails, which returns the error. The messages other than SCCP, and the
+// ones that cannot be decoded are discarded.
+func (s *SCLC) Serve() error {
+	defer s.doneOnce.Do(func() { close(s.done) })
+
+	for {
+		pd, err := s.tr.ReadPD()
+		if err != nil {
+			return err
+		}
+		m, err := ParsePD(pd)
+		if err != nil {
+			continue
+		}
+		s.handle(pd, m)
+	}
+}
+
+func (s *SCLC) handle(pd *params.ProtocolDataPayload, m Message) {
+	switch m := m.(type) {
+	case *UDT:
+		s.deliver(pd, m, m.ProtocolClass, m.CalledPartyAddress, m.CallingPartyAddress, m.Data)
+	case *XUDT:
+		x, err := s.reassembler.Add(pd.OriginatingPointCode, m)
+		if err != nil {
+			s.returnMessage(pd, m, m.ProtocolClass, m.CalledPartyAddress, m.CallingPartyAddress, m.Data, ReturnCauseSegmentationFailure)
+			return
+		}
+		if x == nil {
+			return
+		}
+		s.deliver(pd, x, x.ProtocolClass, x.CalledPartyAddress, x.CallingPartyAddress, x.Data)
+	case *LUDT:
+		s.deliver(pd, m, m.ProtocolClass, m.CalledPartyAddress, m.CallingPartyAddress, m.Data)
+	case *UDTS:
+		s.notice(pd, m.ReturnCause, m.CalledPartyAddress, m.CallingPartyAddress, m.Data)
+	case *XUDTS:
+		s.notice(pd, m.ReturnCause, m.CalledPartyAddress, m.CallingPartyAddress, m.Data)
+	case *LUDTS:
+		s.notice(pd, m.ReturnCause, m.CalledPartyAddress, m.CallingPartyAddress, m.Data)
+	}
+}
+
+// deliver passes the message to the local subsystem, or returns it if the
+// subsystem is not available.
+func (s *SCLC) deliver(pd *params.ProtocolDataPayload, m Message, pc ProtocolClass, cd, cg *PartyAddress, data []byte) {
+	if !cd.SSNIndicator || cd.SubsystemNumber == SSNUnknown {
+		// the GT should have been translated to SSN before reaching here.
+		s.returnMessage(pd, m, pc, cd, cg, data, ReturnCauseNoTranslationForAddress)
+		return
+	}
+	if cd.SubsystemNumber == SSNSCCPMgmt {
+		s.handleSCMG(pd, data)
+		return
+	}
+
+	s.mu.Lock()
+	ss, ok := s.local[cd.SubsystemNumber]
+	var handler func(*Unitdata)
+	var allowed bool
+	if ok {
+		handler, allowed = ss.handler, ss.allowed
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !ok:
+		s.returnMessage(pd, m, pc, cd, cg, data, ReturnCauseUnequippedUser)
+	case !allowed:
+		s.returnMessage(pd, m, pc, cd, cg, data, ReturnCauseSubsystemFailure)
+	default:
+		handler(&Unitdata{
+			CalledPartyAddress:  cd,
+			CallingPartyAddress: cg,
+			Class:               pc.Class(),
+			SequenceControl:     uint32(pd.SignalingLinkSelection),
+			ReturnOnError:       pc.ReturnOnError(),
+			Data:                data,
+			OPC:                 pd.OriginatingPointCode,
+			DPC:                 pd.DestinationPointCode,
+		})
+	}
+}
+
+// returnMessage returns the message to the originator with the service
+// message of the same kind, if the return option is set.
+func (s *SCLC) returnMessage(pd *params.ProtocolDataPayload, m Message, pc ProtocolClass, cd, cg *PartyAddress, data []byte, cause uint8) {
+	if !pc.ReturnOnError() {
+		return
+	}
+
+	var res Message
+	switch m.MessageType() {
+	case MsgTypeUDT:
+		res = NewUDTS(cause, cg, cd, data)
+	case MsgTypeXUDT:
+		res = NewXUDTS(cause, s.hopCounter(), cg, cd, data, nil)
+	case MsgTypeLUDT:
+		res = NewLUDTS(cause, s.hopCounter(), cg, cd, data, nil)
+	default:
+		return
+	}
+	_ = s.write(pd.OriginatingPointCode, pd.SignalingLinkSelection, res)
+}
+
+func (s *SCLC) notice(pd *params.ProtocolDataPayload, cause uint8, cd, cg *PartyAddress, data []byte) {
+	if s.OnNotice == nil {
+		return
+	}
+	s.OnNotice(&Notice{
+		CalledPartyAddress:  cd,
+		CallingPartyAddress: cg,
+		ReturnCause:         cause,
+		Data:                data,
+		OPC:                 pd.OriginatingPointCode,
+	})
+}
+
+func (s *SCLC) handleSCMG(pd *params.ProtocolDataPayload, data []byte) {
+	m, err := ParseSCMG(data)
+	if err != nil {
+		return
+	}
+
+	switch m.Type {
+	case SCMGTypeSST:
+		s.mu.Lock()
+		ss, ok := s.local[m.AffectedSSN]
+		allowed := ok && ss.allowed
+		s.mu.Unlock()
+
+		// no response is sent while the subsystem is prohibited.
+		if allowed {
+			_ = s.sendSCMG(pd.OriginatingPointCode, SCMGTypeSSA, m.AffectedSSN, uint32(m.AffectedPC))
+		}
+	case SCMGTypeSSA:
+		s.setRemoteStatus(uint32(m.AffectedPC), m.AffectedSSN, true)
+	case SCMGTypeSSP:
+		s.setRemoteStatus(uint32(m.AffectedPC), m.AffectedSSN, false)
+	}
+}
+
+// sendSCMG sends the SCMG message of typ for the affected subsystem to dpc.
+// The point codes are carried in SCCP, so they must fit in 14 bits.
+func (s *SCLC) sendSCMG(dpc uint32, typ, ssn uint8, affectedPC uint32) error {
+	cdPC, err := pointCode(dpc)
+	if err != nil {
+		return err
+	}
+	cgPC, err := pointCode(s.OPC)
+	if err != nil {
+		return err
+	}
+	apc, err := pointCode(affectedPC)
+	if err != nil {
+		return err
+	}
+
+	b, err := NewSCMG(typ, ssn, apc).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.write(dpc, 0, NewUDT(
+		NewProtocolClass(0, false),
+		NewPartyAddressSSN(cdPC, SSNSCCPMgmt),
+		NewPartyAddressSSN(cgPC, SSNSCCPMgmt),
+		b,
+	))
+}
+
+// setRemoteStatus updates the status of the remote subsystem, and starts the
+// subsystem status test when it is prohibited.
+func (s *SCLC) setRemoteStatus(pc uint32, ssn uint8, allowed bool) {
+	key := remoteSubsystem{pc, ssn}
+
+	s.mu.Lock()
+	_, prohibited := s.remote[key]
+	changed := prohibited == allowed
+	if allowed {
+		delete(s.remote, key)
+	} else {
+		s.remote[key] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if !allowed {
+		go s.testSubsystem(pc, ssn)
+	}
+	if s.OnSubsystemStatus != nil {
+		s.OnSubsystemStatus(pc, ssn, allowed)
+	}
+}
+
+// testSubsystem sends SST periodically until the subsystem becomes allowed.
+func (s *SCLC) testSubsystem(pc uint32, ssn uint8) {
+	ticker := time.NewTicker(s.sstInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		if s.SubsystemAllowed(pc, ssn) {
+			return
+		}
+		_ = s.sendSCMG(pc, SCMGTypeSST, ssn, pc)
+	}
+}
+
+func (s *SCLC) hopCounter() uint8 {
+	if s.HopCounter == 0 {
+		return DefaultHopCounter
+	}
+	return s.HopCounter
+}
+
+func (s *SCLC) maxMessageLen() int {
+	if s.MaxMessageLen == 0 {
+		return DefaultMaxMessageLen
+	}
+	return s.MaxMessageLen
+}
+
+func (s *SCLC) sstInterval() time.Duration {
+	if s.SSTInterval == 0 {
+		return DefaultSSTInterval
+	}
+	return s.SSTInterval
+}
@@ -0,0 +1,230 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	m3ua "github.com/wmnsk/go-m3ua"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+var _ Transport = (*m3ua.Conn)(nil)
+
+// fakeTransport passes the messages written to the channel.
+type fakeTransport struct {
+	in  chan *params.ProtocolDataPayload
+	out chan *params.ProtocolDataPayload
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{
+		in:  make(chan *params.ProtocolDataPayload, 16),
+		out: make(chan *params.ProtocolDataPayload, 16),
+	}
+}
+
+func (f *fakeTransport) ReadPD() (*params.ProtocolDataPayload, error) {
+	pd, ok := <-f.in
+	if !ok {
+		return nil, io.EOF
+	}
+	return pd, nil
+}
+
+func (f *fakeTransport) WritePD(protocolData *params.Param) (int, error) {
+	pd, err := protocolData.ProtocolData()
+	if err != nil {
+		return 0, err
+	}
+	f.out <- pd
+	return protocolData.MarshalLen(), nil
+}
+
+func (f *fakeTransport) written(t *testing.T) (*params.ProtocolDataPayload, Message) {
+	t.Helper()
+
+	select {
+	case pd := <-f.out:
+		m, err := ParsePD(pd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pd, m
+	case <-time.After(time.Second):
+		t.Fatal("nothing is written")
+		return nil, nil
+	}
+}
+
+func TestSCLCSend(t *testing.T) {
+	tr := newFakeTransport()
+	s := NewSCLC(tr, 1, 2)
+
+	// UDT to the PC in the Called Party Address, with the SLS by sequence control.
+	u := &Unitdata{
+		CalledPartyAddress:  NewPartyAddressSSN(3, SSNHLR),
+		CallingPartyAddress: testCgPA(),
+		Class:               1,
+		SequenceControl:     0x15,
+		Data:                []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	if err := s.Send(u); err != nil {
+		t.Fatal(err)
+	}
+	pd, m := tr.written(t)
+	if pd.OriginatingPointCode != 1 || pd.DestinationPointCode != 3 || pd.SignalingLinkSelection != 0x05 {
+		t.Errorf("unexpected routing label: %+v", pd)
+	}
+	if udt, ok := m.(*UDT); !ok || udt.ProtocolClass.Class() != 1 || !bytes.Equal(udt.Data, u.Data) {
+		t.Errorf("unexpected message: %#v", m)
+	}
+
+	// XUDT segments to DPC for the large data, with the same SLS.
+	u.CalledPartyAddress = testCgPA()
+	u.Data = bytes.Repeat([]byte{0xff}, 500)
+	if err := s.Send(u); err != nil {
+		t.Fatal(err)
+	}
+	r := &Reassembler{}
+	var n int
+	for {
+		pd, m := tr.written(t)
+		n++
+		if pd.DestinationPointCode != 2 || pd.SignalingLinkSelection != 0x05 {
+			t.Errorf("unexpected routing label: %+v", pd)
+		}
+		x, ok := m.(*XUDT)
+		if !ok || x.MarshalLen() > DefaultMaxMessageLen {
+			t.Fatalf("unexpected message: %#v", m)
+		}
+		got, err := r.Add(pd.OriginatingPointCode, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			if !bytes.Equal(got.Data, u.Data) {
+				t.Error("unexpected data reassembled")
+			}
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("got %d segments, want 3", n)
+	}
+
+	// no room for the data after the addresses and Segmentation.
+	s.MaxMessageLen = 20
+	if err := s.Send(u); !errors.Is(err, ErrMaxMessageLenTooShort) {
+		t.Errorf("got %v, want %v", err, ErrMaxMessageLenTooShort)
+	}
+}
+
+func TestSCLCInvalidPointCode(t *testing.T) {
+	tr := newFakeTransport()
+
+	// the point codes in SCMG must fit in 14 bits, instead of being truncated.
+	for _, s := range []*SCLC{NewSCLC(tr, 0x4001, 2), NewSCLC(tr, 1, 0x4002)} {
+		if err := s.SetSubsystemAllowed(SSNHLR, true); !errors.Is(err, ErrInvalidPointCode) {
+			t.Errorf("got %v, want %v", err, ErrInvalidPointCode)
+		}
+	}
+	select {
+	case pd := <-tr.out:
+		t.Errorf("unexpected message written: %+v", pd)
+	default:
+	}
+}
+
+func TestSCLCServe(t *testing.T) {
+	tr := newFakeTransport()
+	s := NewSCLC(tr, 1, 2)
+	s.SSTInterval = 10 * time.Millisecond
+
+	received := make(chan *Unitdata, 1)
+	s.Handle(SSNHLR, func(u *Unitdata) { received <- u })
+	var mu sync.Mutex
+	var statuses []bool
+	s.OnSubsystemStatus = func(pc uint32, ssn uint8, allowed bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = append(statuses, allowed)
+	}
+
+	served := make(chan error)
+	go func() { served <- s.Serve() }()
+
+	send := func(m Message, opc uint32) {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr.in <- params.NewProtocolDataPayload(opc, 1, params.ServiceIndSCCP, 0, 0, 7, b)
+	}
+
+	// delivered to the handler.
+	send(NewUDT(NewProtocolClass(0, true), NewPartyAddressSSN(1, SSNHLR), testCgPA(), []byte{1}), 3)
+	select {
+	case u := <-received:
+		if u.OPC != 3 || !u.ReturnOnError || !bytes.Equal(u.Data, []byte{1}) {
+			t.Errorf("unexpected Unitdata: %+v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("not delivered")
+	}
+
+	// returned with UDTS for the unequipped subsystem.
+	send(NewUDT(NewProtocolClass(0, true), NewPartyAddressSSN(1, SSNVLR), testCgPA(), []byte{1}), 3)
+	pd, m := tr.written(t)
+	if udts, ok := m.(*UDTS); !ok || udts.ReturnCause != ReturnCauseUnequippedUser || pd.DestinationPointCode != 3 {
+		t.Errorf("unexpected message: %#v", m)
+	}
+
+	// SST is responded with SSA only while the subsystem is allowed.
+	sst, _ := NewSCMG(SCMGTypeSST, SSNHLR, 1).MarshalBinary()
+	send(NewUDT(0, NewPartyAddressSSN(1, SSNSCCPMgmt), NewPartyAddressSSN(3, SSNSCCPMgmt), sst), 3)
+	_, m = tr.written(t)
+	if scmg, err := ParseSCMG(m.(*UDT).Data); err != nil || scmg.Type != SCMGTypeSSA || scmg.AffectedSSN != SSNHLR {
+		t.Errorf("unexpected SCMG: %+v, %v", scmg, err)
+	}
+	if err := s.SetSubsystemAllowed(SSNHLR, false); err != nil {
+		t.Fatal(err)
+	}
+	_, m = tr.written(t)
+	if scmg, err := ParseSCMG(m.(*UDT).Data); err != nil || scmg.Type != SCMGTypeSSP {
+		t.Errorf("unexpected SCMG: %+v, %v", scmg, err)
+	}
+
+	// SSP prohibits sending to the subsystem and starts SST until SSA.
+	ssp, _ := NewSCMG(SCMGTypeSSP, SSNMSC, 3).MarshalBinary()
+	send(NewUDT(0, NewPartyAddressSSN(1, SSNSCCPMgmt), NewPartyAddressSSN(3, SSNSCCPMgmt), ssp), 3)
+	pd, m = tr.written(t)
+	if scmg, err := ParseSCMG(m.(*UDT).Data); err != nil || scmg.Type != SCMGTypeSST || pd.DestinationPointCode != 3 {
+		t.Errorf("unexpected SCMG: %+v, %v", scmg, err)
+	}
+	if err := s.Send(&Unitdata{CalledPartyAddress: NewPartyAddressSSN(3, SSNMSC), CallingPartyAddress: testCdPA()}); !errors.Is(err, ErrSubsystemProhibited) {
+		t.Errorf("got %v, want %v", err, ErrSubsystemProhibited)
+	}
+	ssa, _ := NewSCMG(SCMGTypeSSA, SSNMSC, 3).MarshalBinary()
+	send(NewUDT(0, NewPartyAddressSSN(1, SSNSCCPMgmt), NewPartyAddressSSN(3, SSNSCCPMgmt), ssa), 3)
+
+	close(tr.in)
+	if err := <-served; !errors.Is(err, io.EOF) {
+		t.Errorf("got %v, want %v", err, io.EOF)
+	}
+	if !s.SubsystemAllowed(3, SSNMSC) {
+		t.Error("subsystem is not allowed by SSA")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(statuses) != 2 || statuses[0] || !statuses[1] {
+		t.Errorf("unexpected status changes: %v", statuses)
+	}
+}
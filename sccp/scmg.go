@@ -0,0 +1,100 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// SCMG Format Identifier definitions.
+const (
+	SCMGTypeSSA uint8 = iota + 1
+	SCMGTypeSSP
+	SCMGTypeSST
+	SCMGTypeSOR
+	SCMGTypeSOG
+	SCMGTypeSSC
+)
+
+// SCMG is a SCCP management message, which is carried in the data of UDT or
+// XUDT between the SCCP management subsystems (SSN 1).
+//
+// Spec: 5.3, ITU-T Q.713.
+type SCMG struct {
+	Type                  uint8
+	AffectedSSN           uint8
+	AffectedPC            uint16
+	SubsystemMultiplicity uint8
+	// CongestionLevel is used only in SSC.
+	CongestionLevel uint8
+}
+
+// NewSCMG creates a new SCMG.
+func NewSCMG(typ, ssn uint8, pc uint16) *SCMG {
+	return &SCMG{
+		Type:        typ,
+		AffectedSSN: ssn,
+		AffectedPC:  pc,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a SCMG.
+func (s *SCMG) MarshalBinary() ([]byte, error) {
+	b := make([]byte, s.MarshalLen())
+	if err := s.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (s *SCMG) MarshalTo(b []byte) error {
+	if len(b) < s.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	b[0] = s.Type
+	b[1] = s.AffectedSSN
+	b[2] = uint8(s.AffectedPC)
+	b[3] = uint8(s.AffectedPC>>8) & 0x3f
+	b[4] = s.SubsystemMultiplicity & 0x03
+	if s.Type == SCMGTypeSSC {
+		b[5] = s.CongestionLevel & 0x0f
+	}
+	return nil
+}
+
+// ParseSCMG decodes given byte sequence as a SCMG.
+func ParseSCMG(b []byte) (*SCMG, error) {
+	s := &SCMG{}
+	if err := s.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a SCMG.
+func (s *SCMG) UnmarshalBinary(b []byte) error {
+	if len(b) < 5 {
+		return ErrTooShortToParse
+	}
+
+	s.Type = b[0]
+	s.AffectedSSN = b[1]
+	s.AffectedPC = (uint16(b[2]) | uint16(b[3])<<8) & 0x3fff
+	s.SubsystemMultiplicity = b[4] & 0x03
+	s.CongestionLevel = 0
+	if s.Type == SCMGTypeSSC {
+		if len(b) < 6 {
+			return ErrTooShortToParse
+		}
+		s.CongestionLevel = b[5] & 0x0f
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of SCMG.
+func (s *SCMG) MarshalLen() int {
+	if s.Type == SCMGTypeSSC {
+		return 6
+	}
+	return 5
+}
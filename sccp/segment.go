@@ -0,0 +1,175 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// MaxSegments is the maximum number of segments of a message.
+const MaxSegments = 16
+
+// DefaultReassemblyTimeout is the default value of T(reassembly).
+const DefaultReassemblyTimeout = 10 * time.Second
+
+// Error definitions.
+var (
+	ErrTooManySegments       = errors.New("data is too long to be segmented")
+	ErrSegmentOutOfSequence  = errors.New("segment received out of sequence")
+	ErrInvalidSegmentDataLen = errors.New("invalid maximum data length of segment")
+)
+
+// Segment splits the Data in XUDT into the segments whose Data is maxDataLen
+// octets at most. The segments are the copies of x with Segmentation set,
+// which share the Data with x. x is returned as it is if no segmentation is
+// needed.
+//
+// The localRef is the 24-bit Local Reference to identify the segments, which
+// should be unique for the Calling Party Address while the segments are in
+// transit.
+func Segment(x *XUDT, maxDataLen int, localRef uint32) ([]*XUDT, error) {
+	if maxDataLen <= 0 {
+		return nil, ErrInvalidSegmentDataLen
+	}
+	if len(x.Data) <= maxDataLen {
+		return []*XUDT{x}, nil
+	}
+
+	n := (len(x.Data) + maxDataLen - 1) / maxDataLen
+	if n > MaxSegments {
+		return nil, ErrTooManySegments
+	}
+
+	segs := make([]*XUDT, n)
+	for i := range segs {
+		seg := *x
+		end := min((i+1)*maxDataLen, len(x.Data))
+		seg.Data = x.Data[i*maxDataLen : end]
+		seg.Segmentation = &Segmentation{
+			First:          i == 0,
+			InSequence:     x.ProtocolClass.Class() == 1,
+			Remaining:      uint8(n - 1 - i),
+			LocalReference: localRef & 0xffffff,
+		}
+		segs[i] = &seg
+	}
+	return segs, nil
+}
+
+// Reassembler reassembles the segmented XUDT messages.
+//
+// Reassembler is safe for concurrent use.
+type Reassembler struct {
+	// Timeout is the time to wait for all the segments of a message since
+	// the first one is received. DefaultReassemblyTimeout is used if zero.
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*reassembly
+}
+
+type reassembly struct {
+	first     *XUDT
+	data      []byte
+	remaining uint8
+	deadline  time.Time
+}
+
+// Add adds the XUDT received from the signalling point of opc. It returns the
+// reassembled XUDT without Segmentation when all the segments are received,
+// or nil if more segments are expected. The XUDT without Segmentation is
+// returned as it is.
+//
+// If the segment is out of sequence, the segments received so far are
+// discarded and ErrSegmentOutOfSequence is returned. The incomplete messages
+// are discarded after Timeout.
+func (r *Reassembler) Add(opc uint32, x *XUDT) (*XUDT, error) {
+	seg := x.Segmentation
+	if seg == nil {
+		return x, nil
+	}
+
+	cgpa, err := x.CallingPartyAddress.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	key := string([]byte{
+		uint8(opc >> 24), uint8(opc >> 16), uint8(opc >> 8), uint8(opc),
+		uint8(seg.LocalReference >> 16), uint8(seg.LocalReference >> 8), uint8(seg.LocalReference),
+	}) + string(cgpa)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.expire(now)
+
+	if seg.First {
+		if seg.Remaining == 0 {
+			return reassembled(x, x.Data), nil
+		}
+		if r.pending == nil {
+			r.pending = map[string]*reassembly{}
+		}
+		r.pending[key] = &reassembly{
+			first:     x,
+			data:      append([]byte(nil), x.Data...),
+			remaining: seg.Remaining,
+			deadline:  now.Add(r.timeout()),
+		}
+		return nil, nil
+	}
+
+	p, ok := r.pending[key]
+	if !ok {
+		return nil, ErrSegmentOutOfSequence
+	}
+	if seg.Remaining != p.remaining-1 {
+		delete(r.pending, key)
+		return nil, ErrSegmentOutOfSequence
+	}
+	p.data = append(p.data, x.Data...)
+	p.remaining = seg.Remaining
+	if p.remaining > 0 {
+		return nil, nil
+	}
+
+	delete(r.pending, key)
+	return reassembled(p.first, p.data), nil
+}
+
+// Pending returns the number of the messages waiting for more segments.
+func (r *Reassembler) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(time.Now())
+	return len(r.pending)
+}
+
+func (r *Reassembler) timeout() time.Duration {
+	if r.Timeout == 0 {
+		return DefaultReassemblyTimeout
+	}
+	return r.Timeout
+}
+
+// expire discards the messages whose deadline has passed, which should be
+// called with the lock held.
+func (r *Reassembler) expire(now time.Time) {
+	for key, p := range r.pending {
+		if now.After(p.deadline) {
+			delete(r.pending, key)
+		}
+	}
+}
+
+func reassembled(first *XUDT, data []byte) *XUDT {
+	x := *first
+	x.Data = data
+	x.Segmentation = nil
+	return &x
+}
@@ -0,0 +1,106 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSegmentAndReassemble(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 25)
+	x := NewXUDT(NewProtocolClass(1, false), 15, testCdPA(), testCgPA(), data, nil)
+
+	segs, err := Segment(x, 100, 0xabcdef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segs) != 3 {
+		t.Fatalf("got %d segments, want 3", len(segs))
+	}
+	for i, seg := range segs {
+		s := seg.Segmentation
+		if s.First != (i == 0) || !s.InSequence || int(s.Remaining) != 2-i || s.LocalReference != 0xabcdef {
+			t.Errorf("unexpected Segmentation in #%d: %+v", i, s)
+		}
+	}
+
+	// the segments go over the wire to be reassembled.
+	r := &Reassembler{}
+	var got *XUDT
+	for i, seg := range segs {
+		b, err := seg.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParseXUDT(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = r.Add(1, parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got == nil) != (i < len(segs)-1) {
+			t.Fatalf("unexpected result after #%d: %v", i, got)
+		}
+	}
+	if !bytes.Equal(got.Data, data) || got.Segmentation != nil {
+		t.Errorf("unexpected reassembled message: %+v", got)
+	}
+	if r.Pending() != 0 {
+		t.Errorf("got %d pending, want 0", r.Pending())
+	}
+
+	// no segmentation needed.
+	if segs, err := Segment(x, len(data), 1); err != nil || len(segs) != 1 || segs[0] != x {
+		t.Errorf("got %v, %v", segs, err)
+	}
+	if got, err := r.Add(1, x); err != nil || got != x {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := Segment(x, 10, 1); !errors.Is(err, ErrTooManySegments) {
+		t.Errorf("got %v, want %v", err, ErrTooManySegments)
+	}
+}
+
+func TestReassemblerErrors(t *testing.T) {
+	data := bytes.Repeat([]byte{0xff}, 30)
+	x := NewXUDT(NewProtocolClass(0, false), 15, testCdPA(), testCgPA(), data, nil)
+	segs, err := Segment(x, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Reassembler{}
+	if _, err := r.Add(1, segs[1]); !errors.Is(err, ErrSegmentOutOfSequence) {
+		t.Errorf("got %v, want %v", err, ErrSegmentOutOfSequence)
+	}
+
+	// the segments from the other signalling point are not mixed.
+	if _, err := r.Add(1, segs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Add(2, segs[1]); !errors.Is(err, ErrSegmentOutOfSequence) {
+		t.Errorf("got %v, want %v", err, ErrSegmentOutOfSequence)
+	}
+	if _, err := r.Add(1, segs[2]); !errors.Is(err, ErrSegmentOutOfSequence) {
+		t.Errorf("got %v, want %v", err, ErrSegmentOutOfSequence)
+	}
+	if r.Pending() != 0 {
+		t.Errorf("out of sequence message is not discarded: %d", r.Pending())
+	}
+
+	r.Timeout = time.Nanosecond
+	if _, err := r.Add(1, segs[0]); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if r.Pending() != 0 {
+		t.Errorf("expired message is not discarded: %d", r.Pending())
+	}
+}
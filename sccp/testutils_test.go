@@ -0,0 +1,98 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import (
+	"testing"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+type testCase struct {
+	name       string
+	structured Message
+	serialized []byte
+}
+
+type decoderFunc func([]byte) (Message, error)
+
+func runTests(t *testing.T, cases []testCase, decode decoderFunc) {
+	t.Helper()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Run("decode", func(t *testing.T) {
+				v, err := decode(c.serialized)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := v, c.structured; !verify.Values(t, "", got, want) {
+					t.Fail()
+				}
+			})
+
+			t.Run("encode", func(t *testing.T) {
+				b, err := c.structured.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := b, c.serialized; !verify.Values(t, "", got, want) {
+					t.Fail()
+				}
+			})
+
+			t.Run("len", func(t *testing.T) {
+				if got, want := c.structured.MarshalLen(), len(c.serialized); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+			})
+
+			t.Run("interface", func(t *testing.T) {
+				decoded, err := Parse(c.serialized)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := decoded.MessageType(), c.structured.MessageType(); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+				if got, want := decoded.MessageTypeName(), c.structured.MessageTypeName(); got != want {
+					t.Fatalf("got %v want %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+// testCdPA is routed on PC 0x0123 and SSN 6 (HLR).
+func testCdPA() *PartyAddress {
+	return NewPartyAddressSSN(0x0123, SSNHLR)
+}
+
+var testCdPABytes = []byte{0x04, 0x43, 0x23, 0x01, 0x06}
+
+// testCgPA is routed on GT 818012345678 (GTI 4) with SSN 8 (MSC).
+func testCgPA() *PartyAddress {
+	gt, err := NewGlobalTitle(0, NumberingPlanISDNTelephony, NatureInternational, "818012345678")
+	if err != nil {
+		panic(err)
+	}
+	return NewPartyAddressGT(GTITranslationTypeNumberingPlanNature, gt, SSNMSC)
+}
+
+var testCgPABytes = []byte{
+	0x0b, 0x12, 0x08, 0x00, 0x12, 0x04,
+	0x18, 0x08, 0x21, 0x43, 0x65, 0x87,
+}
+
+func concat(bs ...[]byte) []byte {
+	var b []byte
+	for _, bb := range bs {
+		b = append(b, bb...)
+	}
+	return b
+}
@@ -0,0 +1,92 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// UDT is a Unitdata message.
+//
+// Spec: 4.10, ITU-T Q.713.
+type UDT struct {
+	ProtocolClass       ProtocolClass
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	Data                []byte
+}
+
+// NewUDT creates a new UDT.
+func NewUDT(pc ProtocolClass, cdpa, cgpa *PartyAddress, data []byte) *UDT {
+	return &UDT{
+		ProtocolClass:       pc,
+		CalledPartyAddress:  cdpa,
+		CallingPartyAddress: cgpa,
+		Data:                data,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a UDT.
+func (u *UDT) MarshalBinary() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (u *UDT) MarshalTo(b []byte) error {
+	if len(b) < u.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	parts, err := addressParts(u.CalledPartyAddress, u.CallingPartyAddress, 1, u.Data)
+	if err != nil {
+		return err
+	}
+	b[0] = uint8(MsgTypeUDT)
+	b[1] = uint8(u.ProtocolClass)
+	return marshalVariable(b[2:], 1, parts, nil, false)
+}
+
+// ParseUDT decodes given byte sequence as a UDT.
+func ParseUDT(b []byte) (*UDT, error) {
+	u := &UDT{}
+	if err := u.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a UDT.
+func (u *UDT) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return ErrTooShortToParse
+	}
+	u.ProtocolClass = ProtocolClass(b[1])
+
+	parts, _, err := parseVariable(b[2:], 1, []int{1, 1, 1}, false)
+	if err != nil {
+		return err
+	}
+	u.CalledPartyAddress, u.CallingPartyAddress, err = parseAddresses(parts)
+	if err != nil {
+		return err
+	}
+	u.Data = parts[2]
+	return nil
+}
+
+// MarshalLen returns the serial length of UDT.
+func (u *UDT) MarshalLen() int {
+	return 2 + 3 + addressesLen(u.CalledPartyAddress, u.CallingPartyAddress) + 1 + len(u.Data)
+}
+
+// MessageType returns the Message Type.
+func (u *UDT) MessageType() MsgType {
+	return MsgTypeUDT
+}
+
+// MessageTypeName returns the name of Message Type.
+func (u *UDT) MessageTypeName() string {
+	return MsgTypeUDT.String()
+}
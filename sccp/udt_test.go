@@ -0,0 +1,33 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import "testing"
+
+func TestUDT(t *testing.T) {
+	cases := []testCase{
+		{
+			"ssn-to-gt",
+			NewUDT(NewProtocolClass(0, true), testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef}),
+			concat(
+				// Type, Protocol Class
+				[]byte{0x09, 0x80},
+				// Pointers
+				[]byte{0x03, 0x07, 0x12},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0xde, 0xad, 0xbe, 0xef},
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte) (Message, error) {
+		v, err := ParseUDT(b)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// UDTS is a Unitdata Service message.
+//
+// Spec: 4.11, ITU-T Q.713.
+type UDTS struct {
+	ReturnCause         uint8
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	Data                []byte
+}
+
+// NewUDTS creates a new UDTS.
+func NewUDTS(cause uint8, cdpa, cgpa *PartyAddress, data []byte) *UDTS {
+	return &UDTS{
+		ReturnCause:         cause,
+		CalledPartyAddress:  cdpa,
+		CallingPartyAddress: cgpa,
+		Data:                data,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a UDTS.
+func (u *UDTS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (u *UDTS) MarshalTo(b []byte) error {
+	if len(b) < u.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	parts, err := addressParts(u.CalledPartyAddress, u.CallingPartyAddress, 1, u.Data)
+	if err != nil {
+		return err
+	}
+	b[0] = uint8(MsgTypeUDTS)
+	b[1] = u.ReturnCause
+	return marshalVariable(b[2:], 1, parts, nil, false)
+}
+
+// ParseUDTS decodes given byte sequence as a UDTS.
+func ParseUDTS(b []byte) (*UDTS, error) {
+	u := &UDTS{}
+	if err := u.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a UDTS.
+func (u *UDTS) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return ErrTooShortToParse
+	}
+	u.ReturnCause = b[1]
+
+	parts, _, err := parseVariable(b[2:], 1, []int{1, 1, 1}, false)
+	if err != nil {
+		return err
+	}
+	u.CalledPartyAddress, u.CallingPartyAddress, err = parseAddresses(parts)
+	if err != nil {
+		return err
+	}
+	u.Data = parts[2]
+	return nil
+}
+
+// MarshalLen returns the serial length of UDTS.
+func (u *UDTS) MarshalLen() int {
+	return 2 + 3 + addressesLen(u.CalledPartyAddress, u.CallingPartyAddress) + 1 + len(u.Data)
+}
+
+// MessageType returns the Message Type.
+func (u *UDTS) MessageType() MsgType {
+	return MsgTypeUDTS
+}
+
+// MessageTypeName returns the name of Message Type.
+func (u *UDTS) MessageTypeName() string {
+	return MsgTypeUDTS.String()
+}
@@ -0,0 +1,33 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import "testing"
+
+func TestUDTS(t *testing.T) {
+	cases := []testCase{
+		{
+			"no-translation",
+			NewUDTS(ReturnCauseNoTranslationForAddress, testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef}),
+			concat(
+				// Type, Return Cause
+				[]byte{0x0a, 0x01},
+				// Pointers
+				[]byte{0x03, 0x07, 0x12},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0xde, 0xad, 0xbe, 0xef},
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte) (Message, error) {
+		v, err := ParseUDTS(b)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
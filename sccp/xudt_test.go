@@ -0,0 +1,56 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import "testing"
+
+func TestXUDT(t *testing.T) {
+	cases := []testCase{
+		{
+			"no-optional",
+			NewXUDT(NewProtocolClass(0, false), 15, testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef}, nil),
+			concat(
+				// Type, Protocol Class, Hop Counter
+				[]byte{0x11, 0x00, 0x0f},
+				// Pointers
+				[]byte{0x04, 0x08, 0x13, 0x00},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0xde, 0xad, 0xbe, 0xef},
+			),
+		},
+		{
+			"segmented",
+			NewXUDT(
+				NewProtocolClass(1, true), 15, testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef},
+				&Segmentation{First: true, InSequence: true, Remaining: 1, LocalReference: 0x123456},
+				NewImportance(3),
+			),
+			concat(
+				// Type, Protocol Class, Hop Counter
+				[]byte{0x11, 0x81, 0x0f},
+				// Pointers
+				[]byte{0x04, 0x08, 0x13, 0x17},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0xde, 0xad, 0xbe, 0xef},
+				// Segmentation
+				[]byte{0x10, 0x04, 0xc1, 0x56, 0x34, 0x12},
+				// Importance
+				[]byte{0x12, 0x01, 0x03},
+				// End of Optional Parameters
+				[]byte{0x00},
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte) (Message, error) {
+		v, err := ParseXUDT(b)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+// XUDTS is an Extended Unitdata Service message.
+//
+// Spec: 4.19, ITU-T Q.713.
+type XUDTS struct {
+	ReturnCause         uint8
+	HopCounter          uint8
+	CalledPartyAddress  *PartyAddress
+	CallingPartyAddress *PartyAddress
+	Data                []byte
+	// Segmentation is the Segmentation parameter, if any.
+	Segmentation *Segmentation
+	// Optional is the optional parameters other than Segmentation.
+	Optional []*OptionalParameter
+}
+
+// NewXUDTS creates a new XUDTS.
+func NewXUDTS(cause uint8, hopCounter uint8, cdpa, cgpa *PartyAddress, data []byte, seg *Segmentation, opts ...*OptionalParameter) *XUDTS {
+	return &XUDTS{
+		ReturnCause:         cause,
+		HopCounter:          hopCounter,
+		CalledPartyAddress:  cdpa,
+		CallingPartyAddress: cgpa,
+		Data:                data,
+		Segmentation:        seg,
+		Optional:            opts,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from an XUDTS.
+func (x *XUDTS) MarshalBinary() ([]byte, error) {
+	b := make([]byte, x.MarshalLen())
+	if err := x.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (x *XUDTS) MarshalTo(b []byte) error {
+	if len(b) < x.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
+	parts, err := addressParts(x.CalledPartyAddress, x.CallingPartyAddress, 1, x.Data)
+	if err != nil {
+		return err
+	}
+	opt, err := marshalOptional(x.Segmentation, x.Optional)
+	if err != nil {
+		return err
+	}
+	b[0] = uint8(MsgTypeXUDTS)
+	b[1] = x.ReturnCause
+	b[2] = x.HopCounter
+	return marshalVariable(b[3:], 1, parts, opt, true)
+}
+
+// ParseXUDTS decodes given byte sequence as an XUDTS.
+func ParseXUDTS(b []byte) (*XUDTS, error) {
+	x := &XUDTS{}
+	if err := x.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in an XUDTS.
+func (x *XUDTS) UnmarshalBinary(b []byte) error {
+	if len(b) < 3 {
+		return ErrTooShortToParse
+	}
+	x.ReturnCause = b[1]
+	x.HopCounter = b[2]
+
+	parts, opt, err := parseVariable(b[3:], 1, []int{1, 1, 1}, true)
+	if err != nil {
+		return err
+	}
+	x.CalledPartyAddress, x.CallingPartyAddress, err = parseAddresses(parts)
+	if err != nil {
+		return err
+	}
+	x.Data = parts[2]
+
+	x.Segmentation, x.Optional = nil, nil
+	if opt == nil {
+		return nil
+	}
+	x.Segmentation, x.Optional, err = parseOptional(opt)
+	return err
+}
+
+// MarshalLen returns the serial length of XUDTS.
+func (x *XUDTS) MarshalLen() int {
+	return 3 + 4 + addressesLen(x.CalledPartyAddress, x.CallingPartyAddress) + 1 + len(x.Data) + optionalLen(x.Segmentation, x.Optional)
+}
+
+// MessageType returns the Message Type.
+func (x *XUDTS) MessageType() MsgType {
+	return MsgTypeXUDTS
+}
+
+// MessageTypeName returns the name of Message Type.
+func (x *XUDTS) MessageTypeName() string {
+	return MsgTypeXUDTS.String()
+}
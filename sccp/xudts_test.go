@@ -0,0 +1,33 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package sccp
+
+import "testing"
+
+func TestXUDTS(t *testing.T) {
+	cases := []testCase{
+		{
+			"no-optional",
+			NewXUDTS(ReturnCauseSubsystemFailure, 15, testCdPA(), testCgPA(), []byte{0xde, 0xad, 0xbe, 0xef}, nil),
+			concat(
+				// Type, Return Cause, Hop Counter
+				[]byte{0x12, 0x03, 0x0f},
+				// Pointers
+				[]byte{0x04, 0x08, 0x13, 0x00},
+				testCdPABytes,
+				testCgPABytes,
+				[]byte{0x04, 0xde, 0xad, 0xbe, 0xef},
+			),
+		},
+	}
+
+	runTests(t, cases, func(b []byte) (Message, error) {
+		v, err := ParseXUDTS(b)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"encoding/binary"
+	"io"
+	"syscall"
+
+	"github.com/ishidawataru/sctp"
+)
+
+// sctpRead reads from the SCTP association, and reports whether the end of
+// the SCTP message (MSG_EOR) is read, which SCTPConn.SCTPRead does not tell.
+// The stream ID in the SndRcvInfo, which is available only when the events
+// are subscribed for Tap, is kept in recvStream.
+func (c *Conn) sctpRead(b []byte) (int, bool, error) {
+	rc, err := c.sctpConn.SyscallConn()
+	if err != nil {
+		return 0, false, err
+	}
+
+	oob := make([]byte, syscall.CmsgSpace(32))
+	for {
+		var n, oobn, flags int
+		var rerr error
+		if err := rc.Control(func(fd uintptr) {
+			n, oobn, flags, _, rerr = syscall.Recvmsg(int(fd), b, oob, 0)
+		}); err != nil {
+			return 0, false, err
+		}
+		if rerr != nil {
+			return 0, false, rerr
+		}
+		if n == 0 && oobn == 0 {
+			return 0, false, io.EOF
+		}
+		// the notifications are not subscribed, but skipped just in case.
+		if flags&sctp.MSG_NOTIFICATION != 0 {
+			continue
+		}
+
+		if stream, ok := parseRecvStream(oob[:oobn]); ok {
+			c.recvStream = stream
+		}
+		return n, flags&syscall.MSG_EOR != 0, nil
+	}
+}
+
+// parseRecvStream returns the stream ID in the SndRcvInfo in the ancillary
+// data, whose first field is the stream ID.
+func parseRecvStream(oob []byte) (uint16, bool) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return 0, false
+	}
+	for _, m := range msgs {
+		if m.Header.Level == syscall.IPPROTO_SCTP && m.Header.Type == sctp.SCTP_CMSG_SNDRCV && len(m.Data) >= 2 {
+			return binary.NativeEndian.Uint16(m.Data[0:2]), true
+		}
+	}
+	return 0, false
+}
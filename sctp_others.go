@@ -0,0 +1,17 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+//go:build !linux
+
+package m3ua
+
+// sctpRead reads from the SCTP association. Each read is regarded as the end
+// of the SCTP message, as MSG_EOR is not available.
+func (c *Conn) sctpRead(b []byte) (int, bool, error) {
+	n, info, err := c.sctpConn.SCTPRead(b)
+	if info != nil {
+		c.recvStream = info.Stream
+	}
+	return n, true, err
+}
@@ -9,17 +9,42 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
 )
 
 // Listener is a M3UA listener.
+//
+// The Config is used as a template of the Config of each Conn accepted,
+// which can be overridden per ASP with ConfigForASP.
 type Listener struct {
-	sctpListener *sctp.SCTPListener
+	sctpListener net.Listener
 	*Config
+	// ConfigForASP is called with the ASP Identifier in the ASP Up received,
+	// to get the Config for the ASP instead of the one in Listener. The one in
+	// Listener is used if it returns nil. If this is set, ASP Up without ASP
+	// Identifier is rejected with ERROR (ASP Identifier Required).
+	//
+	// It is called only with the first ASP Up in the association, and the
+	// Config is kept until the Conn is closed.
+	ConfigForASP func(aspID uint32) *Config
+	// Store is the configuration of the ASes, which can be updated at runtime.
+	// If this is set, the ASPs are activated for the ASes in Store instead of
+	// the Routing Contexts in Config. See Store for details.
+	Store *Store
+
+	inShutdown atomic.Bool
+	mu         sync.Mutex
+	conns      map[*Conn]struct{}
 }
 
+// shutdownPollInterval is how often Shutdown checks if the DATA is read.
+const shutdownPollInterval = 100 * time.Millisecond
+
 // Listen returns a M3UA listener.
 func Listen(net string, laddr *sctp.SCTPAddr, cfg *Config) (*Listener, error) {
 	var err error
@@ -40,22 +65,30 @@ func Listen(net string, laddr *sctp.SCTPAddr, cfg *Config) (*Listener, error) {
 // Accept waits for and returns the next connection to the listener.
 // After successfully establishing the association with peer, Payload can be read with Read() func.
 // Other signals are automatically handled background in another goroutine.
+//
+// After Shutdown or Close is called, it returns ErrListenerClosed.
 func (l *Listener) Accept(ctx context.Context) (*Conn, error) {
-	conn := &Conn{
-		muState:     new(sync.RWMutex),
-		mode:        modeServer,
-		stateChan:   make(chan State),
-		established: make(chan struct{}),
-		sctpInfo:    &sctp.SndRcvInfo{PPID: 3, Stream: 0},
-		cfg:         l.Config,
+	if l.inShutdown.Load() {
+		return nil, ErrListenerClosed
 	}
 
-	if conn.cfg.HeartbeatInfo.Interval == 0 {
-		conn.cfg.HeartbeatInfo.Enabled = false
+	conn := &Conn{
+		muState:      new(sync.RWMutex),
+		mode:         modeServer,
+		stateChan:    make(chan State),
+		established:  make(chan struct{}, 1),
+		sctpInfo:     &sctp.SndRcvInfo{PPID: 3, Stream: 0},
+		configForASP: l.ConfigForASP,
+		store:        l.Store,
+		listener:     l,
 	}
+	conn.cfg.Store(l.Config.forConn())
 
 	c, err := l.sctpListener.Accept()
 	if err != nil {
+		if l.inShutdown.Load() {
+			return nil, ErrListenerClosed
+		}
 		return nil, err
 	}
 
@@ -71,6 +104,14 @@ func (l *Listener) Accept(ctx context.Context) (*Conn, error) {
 		return nil, fmt.Errorf("failed to get sctpConn status: %w", err)
 	}
 	conn.maxMessageStreamID = r.Ostreams - 1 // removing 1 for management messages of stream ID 0
+	conn.init(r.AssocID)
+	if !l.track(conn) {
+		conn.sctpConn.Close()
+		return nil, ErrListenerClosed
+	}
+	if conn.store != nil {
+		conn.store.register(conn)
+	}
 
 	go func() {
 		conn.stateChan <- StateAspDown
@@ -80,23 +121,159 @@ func (l *Listener) Accept(ctx context.Context) (*Conn, error) {
 	select {
 	case _, ok := <-conn.established:
 		if !ok {
+			conn.unregister()
 			conn.sctpConn.Close()
 			return nil, ErrFailedToEstablish
 		}
 		return conn, nil
-	case <-time.After(10 * time.Second):
+	case <-time.After(conn.config().establishTimeout()):
+		conn.unregister()
 		conn.sctpConn.Close()
 		return nil, ErrTimeout
 	}
 }
 
-// Close closes the listener.
+// Close closes the listener immediately. The Conns accepted are not affected,
+// use Shutdown to deactivate and close them gracefully.
 func (l *Listener) Close() error {
-	// XXX - should close on M3UA layer.
+	l.inShutdown.Store(true)
 	return l.sctpListener.Close()
 }
 
+// Shutdown gracefully shuts down the listener and the Conns accepted, in the
+// similar manner as http.Server.Shutdown.
+//
+// It first stops accepting new associations, and refuses ASP Up and ASP Active
+// on the existing ones with ERROR (Refused - Management Blocking). Then, it
+// takes the ASPs down with the unsolicited ASP Inactive Ack and ASP Down Ack,
+// and waits until the DATA already received is read by the user, which is
+// still returned by Read and ReadPD after the ASP is down. Finally, it closes
+// all the Conns.
+//
+// If ctx expires before the DATA is read, the Conns are closed anyway and the
+// context's error is returned. Otherwise, it returns the error from closing
+// the listener, if any.
+func (l *Listener) Shutdown(ctx context.Context) error {
+	l.inShutdown.Store(true)
+	lnErr := l.sctpListener.Close()
+
+	conns := l.trackedConns()
+	for _, c := range conns {
+		c.draining.Store(true)
+		c.takeDown()
+	}
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for !drained(conns) {
+		select {
+		case <-ctx.Done():
+			closeConns(conns)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	closeConns(conns)
+	return lnErr
+}
+
+func closeConns(conns []*Conn) {
+	for _, c := range conns {
+		c.log().Info("closing connection on shutdown")
+		c.Close()
+	}
+}
+
+// drained reports whether none of the Conns has DATA unread.
+func drained(conns []*Conn) bool {
+	for _, c := range conns {
+		if len(c.dataChan) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// takeDown moves the ASP to ASP-DOWN with the unsolicited ASP Inactive Ack
+// and ASP Down Ack, as the SGP does when it is taken out of service. The
+// state is moved even if the messages cannot be sent, as the Conn is closed
+// anyway.
+func (c *Conn) takeDown() {
+	switch c.State() {
+	case StateAspActive:
+		if _, err := c.WriteSignal(messages.NewAspInactiveAck(c.activeRoutingContexts(), nil)); err != nil {
+			c.log().Warn("failed to deactivate ASP on shutdown", "error", err)
+		}
+		c.deactivateAS()
+		c.setState(StateAspInactive)
+		fallthrough
+	case StateAspInactive:
+		if _, err := c.WriteSignal(messages.NewAspDownAck(nil)); err != nil {
+			c.log().Warn("failed to take ASP down on shutdown", "error", err)
+		}
+		c.setState(StateAspDown)
+	}
+}
+
+// track adds the Conn to the ones to be shut down. It returns false if the
+// listener is already closed.
+func (l *Listener) track(c *Conn) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inShutdown.Load() {
+		return false
+	}
+	if l.conns == nil {
+		l.conns = map[*Conn]struct{}{}
+	}
+	l.conns[c] = struct{}{}
+	return true
+}
+
+func (l *Listener) untrack(c *Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.conns, c)
+}
+
+func (l *Listener) trackedConns() []*Conn {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	conns := make([]*Conn, 0, len(l.conns))
+	for c := range l.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
 // Addr returns the listener's network address.
 func (l *Listener) Addr() net.Addr {
 	return l.sctpListener.Addr()
 }
+
+// unregister removes the Conn from the Listener and Store it is accepted by.
+func (c *Conn) unregister() {
+	if c.listener != nil {
+		c.listener.untrack(c)
+	}
+	if c.store != nil {
+		c.store.unregister(c)
+	}
+}
+
+// activeRoutingContexts returns the Routing Contexts the peer is active for.
+func (c *Conn) activeRoutingContexts() *params.Param {
+	if c.store == nil {
+		return c.config().RoutingContexts
+	}
+
+	c.as.mu.Lock()
+	defer c.as.mu.Unlock()
+	if len(c.as.rcs) == 0 {
+		return nil
+	}
+	return params.NewRoutingContext(c.as.rcs...)
+}
@@ -0,0 +1,189 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestListenerTrack(t *testing.T) {
+	l := &Listener{}
+	active := &Conn{muState: new(sync.RWMutex), state: StateAspActive, listener: l}
+	inactive := &Conn{
+		muState:  new(sync.RWMutex),
+		state:    StateAspInactive,
+		listener: l,
+		dataChan: make(chan *params.ProtocolDataPayload, 1),
+	}
+	for _, c := range []*Conn{active, inactive} {
+		if !l.track(c) {
+			t.Fatal("Conn is not tracked")
+		}
+	}
+	if got := len(l.trackedConns()); got != 2 {
+		t.Errorf("got %d Conns, want 2", got)
+	}
+
+	conns := []*Conn{inactive, active}
+	inactive.dataChan <- &params.ProtocolDataPayload{}
+	if drained(conns) {
+		t.Error("ASP with unread DATA should not be drained")
+	}
+	<-inactive.dataChan
+	if !drained(conns) {
+		t.Error("ASPs without unread DATA should be drained")
+	}
+
+	active.unregister()
+	if got := l.trackedConns(); len(got) != 1 || got[0] != inactive {
+		t.Errorf("unexpected Conns: %v", got)
+	}
+
+	l.inShutdown.Store(true)
+	if l.track(&Conn{}) {
+		t.Error("Conn should not be tracked after shutdown")
+	}
+}
+
+func TestTakeDown(t *testing.T) {
+	// the SCTP options are ignored on the UNIX domain socket.
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(fds[0])
+	defer syscall.Close(fds[1])
+
+	c := withConfig(&Conn{
+		muState:  new(sync.RWMutex),
+		state:    StateAspActive,
+		sctpConn: sctp.NewSCTPConn(fds[0], nil),
+		sctpInfo: &sctp.SndRcvInfo{PPID: 3},
+	}, NewConfig(1, 2, 3, 0, 0, 0).SetRoutingContexts(10))
+	c.takeDown()
+
+	if got := c.State(); got != StateAspDown {
+		t.Errorf("got %s, want %s", got, StateAspDown)
+	}
+
+	var got []string
+	for range 2 {
+		buf := make([]byte, 1024)
+		n, err := syscall.Read(fds[1], buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := messages.Parse(buf[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m.MessageTypeName())
+		if ack, ok := m.(*messages.AspInactiveAck); ok {
+			if rcs := ack.RoutingContext.RoutingContexts(); len(rcs) != 1 || rcs[0] != 10 {
+				t.Errorf("got Routing Contexts %v, want [10]", rcs)
+			}
+		}
+	}
+	want := []string{"ASP Inactive Ack", "ASP Down Ack"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+
+	// nothing is sent when the ASP is already down.
+	c.takeDown()
+	if _, _, err := syscall.Recvfrom(fds[1], make([]byte, 1), syscall.MSG_DONTWAIT); err != syscall.EAGAIN {
+		t.Errorf("got %v, want %v", err, syscall.EAGAIN)
+	}
+}
+
+// closedListener is the listener that is already closed.
+type closedListener struct{}
+
+func (closedListener) Accept() (net.Conn, error) { return nil, net.ErrClosed }
+func (closedListener) Close() error              { return nil }
+func (closedListener) Addr() net.Addr            { return nil }
+
+func TestListenerShutdown(t *testing.T) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_SEQPACKET, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fds[0] is closed with the Conn.
+	defer syscall.Close(fds[1])
+
+	l := &Listener{sctpListener: closedListener{}}
+	c := withConfig(&Conn{
+		muState:     new(sync.RWMutex),
+		state:       StateAspActive,
+		established: make(chan struct{}, 1),
+		closed:      make(chan struct{}),
+		dataChan:    make(chan *params.ProtocolDataPayload, 2),
+		sctpConn:    sctp.NewSCTPConn(fds[0], nil),
+		sctpInfo:    &sctp.SndRcvInfo{PPID: 3},
+		listener:    l,
+	}, NewConfig(1, 2, 3, 0, 0, 0))
+	if !l.track(c) {
+		t.Fatal("Conn is not tracked")
+	}
+	for i := range 2 {
+		c.dataChan <- &params.ProtocolDataPayload{Data: []byte{byte(i)}}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- l.Shutdown(ctx)
+	}()
+
+	// the DATA received before is read even after the ASP is taken down.
+	for i := range 2 {
+		pd, err := c.ReadPD()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pd.Data[0] != byte(i) {
+			t.Errorf("got DATA %x, want %x", pd.Data, []byte{byte(i)})
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Shutdown did not return before ctx expired")
+	}
+
+	if got := c.State(); got != StateAspDown {
+		t.Errorf("got %s, want %s", got, StateAspDown)
+	}
+	if _, err := c.ReadPD(); !errors.Is(err, ErrNotEstablished) {
+		t.Errorf("got %v, want %v", err, ErrNotEstablished)
+	}
+	select {
+	case <-c.closed:
+	default:
+		t.Error("closed is not closed")
+	}
+	if _, ok := <-c.dataChan; ok {
+		t.Error("dataChan is not closed")
+	}
+
+	// closing again does not panic.
+	c.Close()
+}
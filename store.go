@@ -0,0 +1,350 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// AS is the configuration of an Application Server on the SGP side, which is
+// identified by the Routing Context.
+type AS struct {
+	RoutingContext uint32
+	// RoutingKey is the Routing Key of the AS. The DPC in it is notified to
+	// the ASPs with DAVA and DUNA when the AS is added, changed or removed.
+	RoutingKey *params.RoutingKeyPayload
+	// TrafficModeType is the Traffic Mode Type of the AS. If zero, any Traffic
+	// Mode Type requested by the ASPs is accepted.
+	TrafficModeType uint32
+	// AllowedASPs is the ASP Identifiers of the ASPs allowed to serve the AS.
+	// If empty, any ASP is allowed, including the ones without ASP Identifier.
+	AllowedASPs []uint32
+}
+
+// Clone returns a deep copy of the AS, or nil if a is nil.
+func (a *AS) Clone() *AS {
+	if a == nil {
+		return nil
+	}
+	as := *a
+	as.RoutingKey = a.RoutingKey.Clone()
+	as.AllowedASPs = slices.Clone(a.AllowedASPs)
+	return &as
+}
+
+// allows reports whether the ASP with the ASP Identifier is allowed to serve
+// the AS. The known is false if the ASP has not sent ASP Identifier.
+func (a *AS) allows(aspID uint32, known bool) bool {
+	if a == nil {
+		return false
+	}
+	if len(a.AllowedASPs) == 0 {
+		return true
+	}
+	return known && slices.Contains(a.AllowedASPs, aspID)
+}
+
+// dpc returns the DPC in the Routing Key if any.
+func (a *AS) dpc() (uint32, bool) {
+	if a == nil || a.RoutingKey == nil {
+		return 0, false
+	}
+	dpc, err := a.RoutingKey.DestinationPointCode.DecodeDestinationPointCode()
+	return dpc, err == nil
+}
+
+// Store is the configuration of the ASes on the SGP side, which can be updated
+// at runtime. It is set to Listener and shared by all the Conns accepted.
+//
+// When it is set, the ASPs are allowed to be up only if any AS allows them,
+// and to be active only for the ASes that allow them. The changes made with
+// SetAS and RemoveAS are reconciled with the live Conns as follows.
+//
+//   - The ASPs that are no longer allowed to serve the AS, or that requested
+//     the other Traffic Mode Type than the one of the AS, are deactivated for
+//     the AS with the unsolicited ASP Inactive Ack. The Conn becomes
+//     ASP-Inactive when it is not active for any AS.
+//   - The ASPs that are allowed to serve the added AS are notified with
+//     NOTIFY (AS-Inactive) to request the activation.
+//   - The DPC in the Routing Key is notified with DAVA to the ASPs allowed to
+//     serve the AS, and with DUNA when the AS is removed, the DPC is changed,
+//     or the ASP is no longer allowed.
+//
+// Store is safe for concurrent use.
+type Store struct {
+	mu    sync.RWMutex
+	ases  map[uint32]*AS
+	conns map[*Conn]struct{}
+}
+
+// NewStore creates a new Store with the ASes given.
+func NewStore(ases ...*AS) *Store {
+	s := &Store{
+		ases:  map[uint32]*AS{},
+		conns: map[*Conn]struct{}{},
+	}
+	for _, as := range ases {
+		s.ases[as.RoutingContext] = as.Clone()
+	}
+	return s
+}
+
+// AS returns the copy of the AS with the Routing Context, or nil if not found.
+func (s *Store) AS(rc uint32) *AS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ases[rc].Clone()
+}
+
+// ASes returns the copies of all the ASes, sorted by the Routing Context.
+func (s *Store) ASes() []*AS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ases := make([]*AS, 0, len(s.ases))
+	for _, as := range s.ases {
+		ases = append(ases, as.Clone())
+	}
+	sort.Slice(ases, func(i, j int) bool { return ases[i].RoutingContext < ases[j].RoutingContext })
+	return ases
+}
+
+// SetAS adds the AS, or replaces the one with the same Routing Context, and
+// reconciles the live Conns with the change.
+func (s *Store) SetAS(as *AS) {
+	as = as.Clone()
+
+	s.mu.Lock()
+	old := s.ases[as.RoutingContext]
+	s.ases[as.RoutingContext] = as
+	conns := s.connList()
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.reconcileAS(as.RoutingContext, old, as)
+	}
+}
+
+// RemoveAS removes the AS with the Routing Context and reconciles the live
+// Conns with the change. It returns false if the AS is not found.
+func (s *Store) RemoveAS(rc uint32) bool {
+	s.mu.Lock()
+	old, ok := s.ases[rc]
+	delete(s.ases, rc)
+	conns := s.connList()
+	s.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	for _, c := range conns {
+		c.reconcileAS(rc, old, nil)
+	}
+	return true
+}
+
+// connList returns the Conns registered, which should be called with the lock held.
+func (s *Store) connList() []*Conn {
+	conns := make([]*Conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+func (s *Store) register(c *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns[c] = struct{}{}
+}
+
+func (s *Store) unregister(c *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, c)
+}
+
+// allowsASP reports whether any AS allows the ASP.
+func (s *Store) allowsASP(aspID uint32, known bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, as := range s.ases {
+		if as.allows(aspID, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the AS with the Routing Context, which must not be modified.
+func (s *Store) lookup(rc uint32) *AS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ases[rc]
+}
+
+// allowedRCs returns the Routing Contexts of all the ASes that allow the ASP.
+func (s *Store) allowedRCs(aspID uint32, known bool) []uint32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rcs []uint32
+	for rc, as := range s.ases {
+		if as.allows(aspID, known) {
+			rcs = append(rcs, rc)
+		}
+	}
+	slices.Sort(rcs)
+	return rcs
+}
+
+// asState is the state of the Conn regarding the ASes in Store.
+type asState struct {
+	mu sync.Mutex
+	// aspID is the ASP Identifier of the peer, if known
+	aspID      uint32
+	aspIDKnown bool
+	// rcs is the Routing Contexts of the ASes the peer is active for
+	rcs []uint32
+	// tmt is the Traffic Mode Type requested by the peer, zero if not
+	tmt uint32
+}
+
+// setPeerASP records the ASP Identifier of the peer in ASP Up.
+func (c *Conn) setPeerASP(aspID *params.Param) {
+	id, err := aspID.DecodeAspIdentifier()
+
+	c.as.mu.Lock()
+	defer c.as.mu.Unlock()
+	c.as.aspID, c.as.aspIDKnown = id, err == nil
+}
+
+// checkAspUpAllowed checks if the ASP is allowed by any AS in Store.
+func (c *Conn) checkAspUpAllowed(aspID *params.Param) error {
+	if c.store == nil {
+		return nil
+	}
+	id, err := aspID.DecodeAspIdentifier()
+	if !c.store.allowsASP(id, err == nil) {
+		return ErrManagementBlocking
+	}
+	return nil
+}
+
+// activateAS checks the ASes requested in ASP Active against Store, and
+// records them as the ones the peer is active for. It returns the Routing
+// Contexts of the ASes, which are all the ones allowed if none is requested.
+func (c *Conn) activateAS(aspActive *messages.AspActive) ([]uint32, error) {
+	c.as.mu.Lock()
+	defer c.as.mu.Unlock()
+
+	var tmt uint32
+	if aspActive.TrafficModeType != nil {
+		tmt = aspActive.TrafficModeType.TrafficModeType()
+	}
+
+	var rcs []uint32
+	if aspActive.RoutingContext != nil {
+		rcs = aspActive.RoutingContext.RoutingContexts()
+	}
+	if len(rcs) == 0 {
+		rcs = c.store.allowedRCs(c.as.aspID, c.as.aspIDKnown)
+		if len(rcs) == 0 {
+			return nil, ErrNoConfiguredAS
+		}
+	}
+	for _, rc := range rcs {
+		as := c.store.lookup(rc)
+		if !as.allows(c.as.aspID, c.as.aspIDKnown) {
+			return nil, NewInvalidRoutingContextError(rc)
+		}
+		if tmt != 0 && as.TrafficModeType != 0 && tmt != as.TrafficModeType {
+			return nil, NewUnsupportedTrafficModeTypeError(tmt)
+		}
+	}
+
+	c.as.rcs = rcs
+	c.as.tmt = tmt
+	return rcs, nil
+}
+
+// deactivateAS clears the ASes the peer is active for.
+func (c *Conn) deactivateAS() {
+	c.as.mu.Lock()
+	defer c.as.mu.Unlock()
+	c.as.rcs = nil
+}
+
+// reconcileAS applies the change of the AS in Store to the Conn.
+// The old or the new is nil if the AS is added or removed respectively.
+func (c *Conn) reconcileAS(rc uint32, old, new *AS) {
+	switch c.State() {
+	case StateAspInactive, StateAspActive:
+	default:
+		return
+	}
+
+	c.as.mu.Lock()
+	aspID, known := c.as.aspID, c.as.aspIDKnown
+	wasAllowed, isAllowed := old.allows(aspID, known), new.allows(aspID, known)
+	deactivate := slices.Contains(c.as.rcs, rc) &&
+		(!isAllowed || (new.TrafficModeType != 0 && c.as.tmt != 0 && new.TrafficModeType != c.as.tmt))
+	if deactivate {
+		c.as.rcs = slices.DeleteFunc(c.as.rcs, func(v uint32) bool { return v == rc })
+	}
+	remaining := len(c.as.rcs)
+	c.as.mu.Unlock()
+
+	rcParam := params.NewRoutingContext(rc)
+	var msgs []messages.M3UA
+	if deactivate {
+		msgs = append(msgs, messages.NewAspInactiveAck(rcParam, nil))
+	}
+	if old == nil && isAllowed {
+		msgs = append(msgs, messages.NewNotify(params.NewStatus(params.AsStateInactive), nil, rcParam, nil))
+	}
+
+	oldDPC, hadDPC := old.dpc()
+	newDPC, hasDPC := new.dpc()
+	hadDPC, hasDPC = hadDPC && wasAllowed, hasDPC && isAllowed
+	if hadDPC && (!hasDPC || oldDPC != newDPC) {
+		msgs = append(msgs, messages.NewDestinationUnavailable(nil, rcParam, params.NewAffectedPointCode(oldDPC), nil))
+	}
+	if hasDPC && (!hadDPC || oldDPC != newDPC) {
+		msgs = append(msgs, messages.NewDestinationAvailable(nil, rcParam, params.NewAffectedPointCode(newDPC), nil))
+	}
+
+	for _, m := range msgs {
+		if _, err := c.WriteSignal(m); err != nil {
+			c.log().Warn("failed to notify the change of AS", "routing_context", rc, "message", m.MessageTypeName(), "error", err)
+			return
+		}
+	}
+	if deactivate {
+		c.log().Info("deactivated AS", "routing_context", rc)
+		if remaining == 0 {
+			c.setState(StateAspInactive)
+		}
+	}
+}
+
+// setState sets the state of the Conn from outside the state machine, which
+// does not trigger the actions on the state update.
+func (c *Conn) setState(s State) {
+	c.muState.Lock()
+	defer c.muState.Unlock()
+
+	if c.state == s {
+		return
+	}
+	c.log().Info("state changed", "from", c.state.String(), "to", s.String())
+	c.changeState(c.state, s)
+	c.state = s
+}
@@ -0,0 +1,97 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestStore(t *testing.T) {
+	as := &AS{RoutingContext: 10, AllowedASPs: []uint32{1}}
+	s := NewStore(as, &AS{RoutingContext: 20})
+
+	// the AS given is copied.
+	as.AllowedASPs[0] = 2
+	if got := s.AS(10).AllowedASPs; got[0] != 1 {
+		t.Errorf("AS is shared: %v", got)
+	}
+
+	s.SetAS(&AS{RoutingContext: 5, TrafficModeType: params.TrafficModeLoadshare})
+	var rcs []uint32
+	for _, as := range s.ASes() {
+		rcs = append(rcs, as.RoutingContext)
+	}
+	if diff := cmp.Diff([]uint32{5, 10, 20}, rcs); diff != "" {
+		t.Error(diff)
+	}
+
+	if !s.RemoveAS(20) || s.RemoveAS(20) || s.AS(20) != nil {
+		t.Error("AS is not removed")
+	}
+	if diff := cmp.Diff([]uint32{5, 10}, s.allowedRCs(1, true)); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]uint32{5}, s.allowedRCs(0, false)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestActivateAS(t *testing.T) {
+	s := NewStore(
+		&AS{RoutingContext: 10, AllowedASPs: []uint32{1}},
+		&AS{RoutingContext: 20, AllowedASPs: []uint32{2}, TrafficModeType: params.TrafficModeOverride},
+	)
+	c := &Conn{store: s}
+
+	if err := c.checkAspUpAllowed(params.NewAspIdentifier(3)); !errors.Is(err, ErrManagementBlocking) {
+		t.Errorf("got %v, want %v", err, ErrManagementBlocking)
+	}
+	if err := c.checkAspUpAllowed(nil); !errors.Is(err, ErrManagementBlocking) {
+		t.Errorf("got %v, want %v for ASP without ASP Identifier", err, ErrManagementBlocking)
+	}
+	if err := c.checkAspUpAllowed(params.NewAspIdentifier(2)); err != nil {
+		t.Fatal(err)
+	}
+	c.setPeerASP(params.NewAspIdentifier(2))
+
+	var invalidRC *InvalidRoutingContextError
+	if _, err := c.activateAS(messages.NewAspActive(nil, params.NewRoutingContext(10), nil)); !errors.As(err, &invalidRC) {
+		t.Errorf("got %v, want InvalidRoutingContextError", err)
+	}
+	var unsupportedTMT *UnsupportedTrafficModeTypeError
+	if _, err := c.activateAS(messages.NewAspActive(
+		params.NewTrafficModeType(params.TrafficModeLoadshare), params.NewRoutingContext(20), nil,
+	)); !errors.As(err, &unsupportedTMT) {
+		t.Errorf("got %v, want UnsupportedTrafficModeTypeError", err)
+	}
+
+	// all the ASes allowed are activated without Routing Context.
+	rcs, err := c.activateAS(messages.NewAspActive(nil, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{20}, rcs); diff != "" {
+		t.Error(diff)
+	}
+
+	// the Conn not in ASP-Inactive or ASP-Active is not touched by the change.
+	s.register(c)
+	c.muState = new(sync.RWMutex)
+	s.RemoveAS(20)
+	if diff := cmp.Diff([]uint32{20}, c.as.rcs); diff != "" {
+		t.Error(diff)
+	}
+
+	c.deactivateAS()
+	if _, err := c.activateAS(messages.NewAspActive(nil, nil, nil)); !errors.Is(err, ErrNoConfiguredAS) {
+		t.Errorf("got %v, want %v", err, ErrNoConfiguredAS)
+	}
+}
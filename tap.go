@@ -0,0 +1,111 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"net"
+	"time"
+
+	"github.com/ishidawataru/sctp"
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+// Direction is the direction of the M3UA message passed to Tap.
+type Direction uint8
+
+// Direction definitions.
+const (
+	DirectionReceived Direction = iota + 1
+	DirectionSent
+)
+
+// String returns the name of Direction.
+func (d Direction) String() string {
+	switch d {
+	case DirectionReceived:
+		return "Received"
+	case DirectionSent:
+		return "Sent"
+	default:
+		return "Unknown"
+	}
+}
+
+// Frame is a M3UA message sent or received on a Conn, which is passed to Tap.
+//
+// Message and Raw refer to the buffers that are reused after Tap returns,
+// so they should be copied if retained, e.g., with Message.MarshalBinary.
+type Frame struct {
+	// Conn is the connection that the message is sent or received on.
+	Conn *Conn
+	// LocalAddr and RemoteAddr are the primary addresses of the SCTP
+	// association, which are retrieved once when the Conn is established.
+	LocalAddr, RemoteAddr net.Addr
+	// Direction is the direction of the message.
+	Direction Direction
+	// Timestamp is the time when the message is sent or received.
+	Timestamp time.Time
+	// StreamID is the SCTP stream the message is sent or received on.
+	StreamID uint16
+	// Message is the parsed message, which is nil if it cannot be parsed.
+	Message messages.M3UA
+	// Raw is the raw bytes of the message.
+	Raw []byte
+	// Err is the error that occurred in parsing the received message.
+	Err error
+}
+
+// Tap is the interface to intercept every M3UA message sent or received on
+// a Conn, which is set in Config. This is for tracing the messages, e.g.,
+// writing them to a capture file or logging them, and for the assertions
+// in the tests.
+//
+// Tap is called synchronously in the goroutines that send or receive the
+// messages, which can be the different ones for a Conn, so the implementation
+// should be safe for concurrent use and return quickly.
+type Tap interface {
+	Tap(f Frame)
+}
+
+// TapFunc is an adapter to use an ordinary function as Tap.
+type TapFunc func(f Frame)
+
+// Tap calls f(fr).
+func (f TapFunc) Tap(fr Frame) {
+	f(fr)
+}
+
+// initTap prepares the Conn for Tap if it is set in the Config.
+// This subscribes the SCTP events to know the stream IDs of the received
+// messages, which is not done without Tap to avoid the overhead.
+func (c *Conn) initTap() {
+	if c.config().Tap == nil {
+		return
+	}
+
+	if err := c.sctpConn.SubscribeEvents(sctp.SCTP_EVENT_DATA_IO); err != nil {
+		c.log().Warn("failed to subscribe SCTP events, stream IDs of received messages are not available", "error", err)
+	}
+}
+
+// tap passes the message to the Tap in the Config if set.
+func (c *Conn) tap(dir Direction, streamID uint16, m messages.M3UA, raw []byte, err error) {
+	t := c.config().Tap
+	if t == nil {
+		return
+	}
+
+	t.Tap(Frame{
+		Conn:       c,
+		LocalAddr:  c.laddr,
+		RemoteAddr: c.raddr,
+		Direction:  dir,
+		Timestamp:  time.Now(),
+		StreamID:   streamID,
+		Message:    m,
+		Raw:        raw,
+		Err:        err,
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages"
+)
+
+func TestTap(t *testing.T) {
+	var frames []Frame
+	c := withConfig(&Conn{}, NewConfig(1, 2, 3, 0, 0, 0).SetTap(TapFunc(func(f Frame) {
+		frames = append(frames, f)
+	})))
+
+	m := messages.NewAspUp(nil, nil)
+	raw, _ := m.MarshalBinary()
+	parseErr := errors.New("parse error")
+	c.tap(DirectionSent, 0, m, raw, nil)
+	c.tap(DirectionReceived, 3, nil, raw[:4], parseErr)
+
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want 2", len(frames))
+	}
+	if f := frames[0]; f.Conn != c || f.Direction != DirectionSent || f.Message != m || f.Err != nil || f.Timestamp.IsZero() {
+		t.Errorf("unexpected frame: %+v", f)
+	}
+	if f := frames[1]; f.Direction != DirectionReceived || f.StreamID != 3 || f.Message != nil || len(f.Raw) != 4 || f.Err != parseErr {
+		t.Errorf("unexpected frame: %+v", f)
+	}
+
+	// nothing happens without Tap.
+	c.config().Tap = nil
+	c.tap(DirectionSent, 0, m, raw, nil)
+	if len(frames) != 2 {
+		t.Errorf("got %d frames, want 2", len(frames))
+	}
+}
@@ -5,10 +5,12 @@
 package m3ua
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
 	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
 )
 
 func (c *Conn) handleData(ctx context.Context, data *messages.Data) {
@@ -24,11 +26,17 @@ func (c *Conn) handleData(ctx context.Context, data *messages.Data) {
 		return
 	}
 
-	pd, err := data.ProtocolData.ProtocolData()
-	if err != nil {
+	if data.ProtocolData == nil {
+		c.errChan <- ErrFailedToPeelOff
+		return
+	}
+	pd := &params.ProtocolDataPayload{}
+	if err := pd.UnmarshalBinary(data.ProtocolData.Data); err != nil {
 		c.errChan <- ErrFailedToPeelOff
 		return
 	}
+	// data refers to the receive buffer, which is reused after this returns.
+	pd.Data = bytes.Clone(pd.Data)
 
 	select {
 	case c.dataChan <- pd:
@@ -0,0 +1,93 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"slices"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+// validateAspIdentifier checks if the ASP Identifier has a valid format.
+func (c *Conn) validateAspIdentifier(msg messages.M3UA, aspID *params.Param) error {
+	if aspID == nil {
+		return nil
+	}
+	if len(aspID.Data) != 4 {
+		return NewInvalidParameterValueError(msg, params.AspIdentifier)
+	}
+
+	return nil
+}
+
+// validateTrafficModeType checks if the Traffic Mode Type has a valid format
+// and value. In strict mode, it must also match the one expected, which is
+// the one in Config.
+func (c *Conn) validateTrafficModeType(msg messages.M3UA, tmt, expected *params.Param) error {
+	if tmt == nil {
+		return nil
+	}
+	if len(tmt.Data) != 4 {
+		return NewInvalidParameterValueError(msg, params.TrafficModeType)
+	}
+
+	mode := tmt.TrafficModeType()
+	switch mode {
+	case params.TrafficModeOverride, params.TrafficModeLoadshare, params.TrafficModeBroadcast:
+	default:
+		return NewUnsupportedTrafficModeTypeError(mode)
+	}
+
+	if !c.config().StrictValidation || expected == nil {
+		return nil
+	}
+	if mode != expected.TrafficModeType() {
+		return NewUnsupportedTrafficModeTypeError(mode)
+	}
+
+	return nil
+}
+
+// validateRoutingContexts checks if the Routing Context has a valid format.
+// In strict mode, it must also be present if the expected one is given, and
+// all the values in it must be found in the expected one.
+func (c *Conn) validateRoutingContexts(msg messages.M3UA, rtCtx, expected *params.Param) error {
+	if rtCtx == nil {
+		if c.config().StrictValidation && expected != nil {
+			return NewMissingParameterError(msg, params.RoutingContext)
+		}
+		return nil
+	}
+	if len(rtCtx.Data) == 0 || len(rtCtx.Data)%4 != 0 {
+		return NewInvalidParameterValueError(msg, params.RoutingContext)
+	}
+
+	if !c.config().StrictValidation || expected == nil {
+		return nil
+	}
+
+	known := expected.RoutingContexts()
+	for _, rc := range rtCtx.RoutingContexts() {
+		if !slices.Contains(known, rc) {
+			return NewInvalidRoutingContextError(rc)
+		}
+	}
+
+	return nil
+}
+
+// validateNotRequested checks if the parameter in an acknowledgement is the one
+// that was sent in the request. This is done only in strict mode.
+func (c *Conn) validateNotRequested(msg messages.M3UA, param, requested *params.Param) error {
+	if !c.config().StrictValidation {
+		return nil
+	}
+	if param != nil && requested == nil {
+		return NewUnexpectedParameterError(msg, param.Tag)
+	}
+
+	return nil
+}
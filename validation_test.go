@@ -0,0 +1,115 @@
+// Copyright 2018-2024 go-m3ua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package m3ua
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wmnsk/go-m3ua/messages"
+	"github.com/wmnsk/go-m3ua/messages/params"
+)
+
+func TestValidateAspActive(t *testing.T) {
+	cfg := NewConfig(0x11111111, 0x22222222, params.ServiceIndSCCP, 0, 0, 1).
+		SetTrafficModeType(params.TrafficModeLoadshare).
+		SetRoutingContexts(1, 2)
+
+	cases := []struct {
+		name    string
+		strict  bool
+		msg     *messages.AspActive
+		wantErr interface{}
+	}{
+		{
+			"valid",
+			true,
+			messages.NewAspActive(params.NewTrafficModeType(params.TrafficModeLoadshare), params.NewRoutingContext(1), nil),
+			nil,
+		},
+		{
+			"unknown-tmt/lenient",
+			false,
+			messages.NewAspActive(params.NewTrafficModeType(4), nil, nil),
+			new(*UnsupportedTrafficModeTypeError),
+		},
+		{
+			"different-tmt/lenient",
+			false,
+			messages.NewAspActive(params.NewTrafficModeType(params.TrafficModeOverride), nil, nil),
+			nil,
+		},
+		{
+			"different-tmt/strict",
+			true,
+			messages.NewAspActive(params.NewTrafficModeType(params.TrafficModeOverride), params.NewRoutingContext(1), nil),
+			new(*UnsupportedTrafficModeTypeError),
+		},
+		{
+			"unknown-rc/lenient",
+			false,
+			messages.NewAspActive(nil, params.NewRoutingContext(3), nil),
+			nil,
+		},
+		{
+			"unknown-rc/strict",
+			true,
+			messages.NewAspActive(nil, params.NewRoutingContext(1, 3), nil),
+			new(*InvalidRoutingContextError),
+		},
+		{
+			"missing-rc/strict",
+			true,
+			messages.NewAspActive(nil, nil, nil),
+			new(*MissingParameterError),
+		},
+		{
+			"malformed-rc/lenient",
+			false,
+			messages.NewAspActive(nil, params.NewParam(int(params.RoutingContext), []byte{0x01}), nil),
+			new(*InvalidParameterValueError),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg.StrictValidation = c.strict
+			conn := withConfig(&Conn{}, cfg)
+
+			err := conn.validateTrafficModeType(c.msg, c.msg.TrafficModeType, cfg.TrafficModeType)
+			if err == nil {
+				err = conn.validateRoutingContexts(c.msg, c.msg.RoutingContext, cfg.RoutingContexts)
+			}
+
+			if c.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, c.wantErr) {
+				t.Fatalf("got %v, want %T", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAspActiveAck(t *testing.T) {
+	cfg := NewConfig(0x11111111, 0x22222222, params.ServiceIndSCCP, 0, 0, 1).
+		SetRoutingContexts(1).
+		SetStrictValidation(true)
+	conn := withConfig(&Conn{}, cfg)
+
+	ack := messages.NewAspActiveAck(params.NewTrafficModeType(params.TrafficModeLoadshare), params.NewRoutingContext(1), nil)
+	err := conn.validateNotRequested(ack, ack.TrafficModeType, cfg.TrafficModeType)
+
+	var target *UnexpectedParameterError
+	if !errors.As(err, &target) {
+		t.Fatalf("got %v, want %T", err, target)
+	}
+	if target.Tag != params.TrafficModeType {
+		t.Errorf("got tag %d, want %d", target.Tag, params.TrafficModeType)
+	}
+}